@@ -10,11 +10,39 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/tnguyen21/kestral-tui/internal/app"
+	"github.com/tnguyen21/kestral-tui/internal/cli"
 	"github.com/tnguyen21/kestral-tui/internal/config"
 	"github.com/tnguyen21/kestral-tui/internal/server"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "tui" {
+		tui(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		err := cli.Run(ctx, os.Args[1], os.Args[2:], os.Stdout)
+		stop()
+		if err == flag.ErrHelp {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "kestral %s: %v\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	flag.Usage = func() {
+		w := flag.CommandLine.Output()
+		fmt.Fprintln(w, "Usage: kestral [flags] | kestral tui [-ssh] [flags] | kestral <subcommand> [flags]")
+		fmt.Fprintln(w, "\nWithout a subcommand, kestral serves the TUI over SSH; kestral tui runs it on this terminal.")
+		flag.PrintDefaults()
+		fmt.Fprintln(w)
+		cli.Usage(w)
+	}
 	configPath := flag.String("config", config.DefaultConfigPath, "path to config file")
 	port := flag.Int("port", 0, "override listen port")
 	flag.Parse()
@@ -27,7 +55,36 @@ func main() {
 	if *port > 0 {
 		cfg.Port = *port
 	}
+	serve(cfg)
+}
+
+// tui runs the TUI on the local terminal, or with -ssh serves it like
+// plain kestral.
+func tui(args []string) {
+	fs := flag.NewFlagSet("kestral tui", flag.ExitOnError)
+	configPath := fs.String("config", config.DefaultConfigPath, "path to config file")
+	ssh := fs.Bool("ssh", false, "serve the TUI over SSH instead of running it here")
+	port := fs.Int("port", 0, "override listen port (with -ssh)")
+	fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("loading config: %v", err)
+	}
+	if *ssh {
+		if *port > 0 {
+			cfg.Port = *port
+		}
+		serve(cfg)
+		return
+	}
+	if err := app.RunLocal(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
 
+// serve runs the SSH server until SIGINT or SIGTERM.
+func serve(cfg config.Config) {
 	srv, err := server.New(&cfg)
 	if err != nil {
 		log.Fatalf("creating server: %v", err)
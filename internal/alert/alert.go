@@ -0,0 +1,292 @@
+// Package alert evaluates configurable rules against incoming data updates
+// and emits de-duplicated events for the notification banner and bell.
+package alert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// Event is a single fired alert.
+type Event struct {
+	Rule     string // name of the rule that fired
+	Key      string // de-duplication key (rule + subject)
+	Severity string // info, warn, fail
+	Message  string
+	Bell     bool
+	At       time.Time
+}
+
+// hit is a rule condition that currently holds for one subject.
+type hit struct {
+	subject string
+	message string
+}
+
+// Engine evaluates alert rules on every update message. An alert fires when
+// its condition first becomes true for a subject and is suppressed while it
+// stays true, unless the cooldown has elapsed. It re-arms once the condition
+// clears.
+type Engine struct {
+	rules    []config.AlertRule
+	cooldown time.Duration
+	fired    map[string]time.Time // dedup key -> last fired
+	cpu      map[string][]float64 // session name -> recent CPU samples
+	samples  int                  // number of CPU samples to retain
+}
+
+// NewEngine creates an Engine for the given alert configuration.
+func NewEngine(cfg config.Alerts) *Engine {
+	e := &Engine{
+		rules:    cfg.Rules,
+		cooldown: time.Duration(cfg.Cooldown) * time.Second,
+		fired:    make(map[string]time.Time),
+		cpu:      make(map[string][]float64),
+	}
+	for _, r := range cfg.Rules {
+		if r.Kind == config.AlertCPU && r.Samples > e.samples {
+			e.samples = r.Samples
+		}
+	}
+	return e
+}
+
+// Evaluate checks every rule relevant to msg and returns newly fired events.
+// Messages that carry no rule-relevant data return nil.
+func (e *Engine) Evaluate(msg tea.Msg, now time.Time) []Event {
+	if e == nil || len(e.rules) == 0 {
+		return nil
+	}
+
+	if rm, ok := msg.(pane.ResourceUpdateMsg); ok && rm.Err == nil {
+		e.recordCPU(rm)
+	}
+
+	var events []Event
+	for _, r := range e.rules {
+		hits, ok := e.match(r, msg)
+		if !ok {
+			continue
+		}
+		events = append(events, e.dedup(r, hits, now)...)
+	}
+	return events
+}
+
+// match returns the subjects for which rule r currently holds. The boolean
+// is false when msg does not carry data for r, in which case the rule's
+// active alerts are left untouched.
+func (e *Engine) match(r config.AlertRule, msg tea.Msg) ([]hit, bool) {
+	switch r.Kind {
+	case config.AlertAgentStatus:
+		m, ok := msg.(pane.AgentUpdateMsg)
+		if !ok || m.Err != nil {
+			return nil, false
+		}
+		var hits []hit
+		for _, a := range m.Agents {
+			if a.Status != r.Status && a.State != r.Status {
+				continue
+			}
+			subject := a.Rig + "/" + a.Name
+			state := a.Status
+			if a.Status != r.Status {
+				state = pane.AgentStateLabel(a.State)
+			}
+			hits = append(hits, hit{
+				subject: subject,
+				message: fmt.Sprintf("%s is %s (active %s)", subject, state, pane.FormatAge(a.Age)),
+			})
+		}
+		return hits, true
+
+	case config.AlertCPU:
+		m, ok := msg.(pane.ResourceUpdateMsg)
+		if !ok || m.Err != nil {
+			return nil, false
+		}
+		var hits []hit
+		for _, s := range m.Sessions {
+			if !sustainedAbove(e.cpu[s.Name], r.Threshold, r.Samples) {
+				continue
+			}
+			hits = append(hits, hit{
+				subject: s.Name,
+				message: fmt.Sprintf("%s CPU %.0f%% for %d polls", s.Name, s.CPUPercent, r.Samples),
+			})
+		}
+		return hits, true
+
+	case config.AlertWitnessHeartbeat:
+		m, ok := msg.(pane.WitnessUpdateMsg)
+		if !ok || m.Err != nil {
+			return nil, false
+		}
+		maxAge := time.Duration(r.MaxAge) * time.Second
+		var hits []hit
+		for _, w := range m.Witnesses {
+			switch {
+			case !w.HasSession:
+				hits = append(hits, hit{
+					subject: w.Rig,
+					message: fmt.Sprintf("witness %s has no session", w.Rig),
+				})
+			case w.LastHeartbeat > maxAge:
+				hits = append(hits, hit{
+					subject: w.Rig,
+					message: fmt.Sprintf("witness %s silent, last heartbeat %s", w.Rig, pane.FormatAge(w.LastHeartbeat)),
+				})
+			}
+		}
+		return hits, true
+
+	case config.AlertQueueDepth:
+		m, ok := msg.(pane.RefineryUpdateMsg)
+		if !ok || m.Err != nil {
+			return nil, false
+		}
+		var hits []hit
+		for _, s := range m.Statuses {
+			if float64(s.QueueDepth) < r.Threshold {
+				continue
+			}
+			hits = append(hits, hit{
+				subject: s.Rig,
+				message: fmt.Sprintf("refinery %s queue depth %d", s.Rig, s.QueueDepth),
+			})
+		}
+		return hits, true
+
+	case config.AlertCIFailure:
+		m, ok := msg.(pane.PRUpdateMsg)
+		if !ok || m.Err != nil {
+			return nil, false
+		}
+		var hits []hit
+		for _, pr := range m.PRs {
+			for _, c := range pr.StatusChecks {
+				if c.Conclusion != "FAILURE" && c.Conclusion != "CANCELLED" && c.Conclusion != "TIMED_OUT" {
+					continue
+				}
+				hits = append(hits, hit{
+					subject: fmt.Sprintf("#%d", pr.Number),
+					message: fmt.Sprintf("PR #%d %s: %s failed", pr.Number, pr.Title, c.Name),
+				})
+				break
+			}
+		}
+		return hits, true
+
+	case config.AlertConvoyComplete:
+		m, ok := msg.(pane.ConvoyUpdateMsg)
+		// A nil Progress map means the fetch failed.
+		if !ok || m.Progress == nil {
+			return nil, false
+		}
+		var hits []hit
+		for _, c := range m.Convoys {
+			p := m.Progress[c.ID]
+			if p[1] == 0 || p[0] < p[1] {
+				continue
+			}
+			hits = append(hits, hit{
+				subject: c.ID,
+				message: fmt.Sprintf("convoy %s complete (%d/%d)", c.Title, p[0], p[1]),
+			})
+		}
+		return hits, true
+	}
+	return nil, false
+}
+
+// dedup turns the current hits for rule r into events, suppressing alerts
+// that already fired and clearing those whose condition no longer holds.
+func (e *Engine) dedup(r config.AlertRule, hits []hit, now time.Time) []Event {
+	severity := r.Severity
+	if severity == "" {
+		severity = "warn"
+	}
+
+	current := make(map[string]bool, len(hits))
+	var events []Event
+	for _, h := range hits {
+		key := r.Name + ":" + h.subject
+		current[key] = true
+
+		last, active := e.fired[key]
+		if active && (e.cooldown == 0 || now.Sub(last) < e.cooldown) {
+			continue
+		}
+		e.fired[key] = now
+		events = append(events, Event{
+			Rule:     r.Name,
+			Key:      key,
+			Severity: severity,
+			Message:  h.message,
+			Bell:     r.Bell,
+			At:       now,
+		})
+	}
+
+	prefix := r.Name + ":"
+	for key := range e.fired {
+		if strings.HasPrefix(key, prefix) && !current[key] {
+			delete(e.fired, key)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Key < events[j].Key
+	})
+	return events
+}
+
+// Active returns the number of alerts whose condition currently holds.
+func (e *Engine) Active() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.fired)
+}
+
+// recordCPU appends the latest CPU sample for each session and drops
+// sessions that have disappeared.
+func (e *Engine) recordCPU(m pane.ResourceUpdateMsg) {
+	if e.samples == 0 {
+		return
+	}
+	seen := make(map[string]bool, len(m.Sessions))
+	for _, s := range m.Sessions {
+		seen[s.Name] = true
+		samples := append(e.cpu[s.Name], s.CPUPercent)
+		if len(samples) > e.samples {
+			samples = samples[len(samples)-e.samples:]
+		}
+		e.cpu[s.Name] = samples
+	}
+	for name := range e.cpu {
+		if !seen[name] {
+			delete(e.cpu, name)
+		}
+	}
+}
+
+// sustainedAbove reports whether the last n samples are all >= threshold.
+func sustainedAbove(samples []float64, threshold float64, n int) bool {
+	if len(samples) < n {
+		return false
+	}
+	for _, v := range samples[len(samples)-n:] {
+		if v < threshold {
+			return false
+		}
+	}
+	return true
+}
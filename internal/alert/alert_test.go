@@ -0,0 +1,202 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+func engineWith(cooldown int, rules ...config.AlertRule) *Engine {
+	return NewEngine(config.Alerts{Cooldown: cooldown, Rules: rules})
+}
+
+func TestAgentStatusRuleFiresOnce(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "stuck", Kind: config.AlertAgentStatus, Status: "stuck", Severity: "fail"})
+	now := time.Now()
+	msg := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{
+		{Name: "quartz", Rig: "kt", Status: "stuck", Age: 45 * time.Minute},
+		{Name: "jasper", Rig: "kt", Status: "working"},
+	}}
+
+	events := e.Evaluate(msg, now)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Key != "stuck:kt/quartz" {
+		t.Errorf("Key = %q, want %q", events[0].Key, "stuck:kt/quartz")
+	}
+	if events[0].Severity != "fail" {
+		t.Errorf("Severity = %q, want fail", events[0].Severity)
+	}
+
+	// Same condition on the next poll is de-duplicated.
+	if events := e.Evaluate(msg, now.Add(time.Minute)); len(events) != 0 {
+		t.Errorf("repeat poll should not re-fire, got %d events", len(events))
+	}
+	if e.Active() != 1 {
+		t.Errorf("Active() = %d, want 1", e.Active())
+	}
+}
+
+func TestAgentStatusRuleMatchesOutputState(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "waiting", Kind: config.AlertAgentStatus, Status: data.StateWaiting, Severity: "warn"})
+	msg := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{
+		{Name: "quartz", Rig: "kt", Status: "working", State: data.StateWaiting, Age: time.Minute},
+		{Name: "jasper", Rig: "kt", Status: "working"},
+	}}
+
+	events := e.Evaluate(msg, time.Now())
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if want := "kt/quartz is waiting for input (active 1m ago)"; events[0].Message != want {
+		t.Errorf("Message = %q, want %q", events[0].Message, want)
+	}
+}
+
+func TestAlertRearmsAfterClearing(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "stuck", Kind: config.AlertAgentStatus, Status: "stuck"})
+	now := time.Now()
+	stuck := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{{Name: "quartz", Rig: "kt", Status: "stuck"}}}
+	working := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{{Name: "quartz", Rig: "kt", Status: "working"}}}
+
+	e.Evaluate(stuck, now)
+	e.Evaluate(working, now.Add(time.Minute))
+	if e.Active() != 0 {
+		t.Fatalf("Active() = %d after clearing, want 0", e.Active())
+	}
+	if events := e.Evaluate(stuck, now.Add(2*time.Minute)); len(events) != 1 {
+		t.Errorf("expected alert to re-fire after clearing, got %d events", len(events))
+	}
+}
+
+func TestAlertCooldownRepeats(t *testing.T) {
+	e := engineWith(300, config.AlertRule{Name: "stuck", Kind: config.AlertAgentStatus, Status: "stuck"})
+	now := time.Now()
+	msg := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{{Name: "quartz", Rig: "kt", Status: "stuck"}}}
+
+	e.Evaluate(msg, now)
+	if events := e.Evaluate(msg, now.Add(time.Minute)); len(events) != 0 {
+		t.Errorf("within cooldown: got %d events, want 0", len(events))
+	}
+	if events := e.Evaluate(msg, now.Add(6*time.Minute)); len(events) != 1 {
+		t.Errorf("after cooldown: got %d events, want 1", len(events))
+	}
+}
+
+func TestFetchErrorKeepsActiveAlerts(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "stuck", Kind: config.AlertAgentStatus, Status: "stuck"})
+	now := time.Now()
+	e.Evaluate(pane.AgentUpdateMsg{Agents: []pane.AgentInfo{{Name: "quartz", Rig: "kt", Status: "stuck"}}}, now)
+
+	if events := e.Evaluate(pane.AgentUpdateMsg{Err: errors.New("tmux down")}, now); events != nil {
+		t.Errorf("errored update should not fire, got %v", events)
+	}
+	if e.Active() != 1 {
+		t.Errorf("Active() = %d, errored update should not clear alerts", e.Active())
+	}
+}
+
+func TestCPURuleRequiresSustainedSamples(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "cpu", Kind: config.AlertCPU, Threshold: 90, Samples: 3})
+	now := time.Now()
+	msg := pane.ResourceUpdateMsg{Sessions: []data.SessionResource{{Name: "gt-kt-quartz", CPUPercent: 99}}}
+
+	for i := 0; i < 2; i++ {
+		if events := e.Evaluate(msg, now); len(events) != 0 {
+			t.Fatalf("sample %d: fired before threshold sustained", i+1)
+		}
+	}
+	if events := e.Evaluate(msg, now); len(events) != 1 {
+		t.Errorf("third sample: got %d events, want 1", len(events))
+	}
+}
+
+func TestWitnessHeartbeatRule(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "witness", Kind: config.AlertWitnessHeartbeat, MaxAge: 600})
+	msg := pane.WitnessUpdateMsg{Witnesses: []pane.WitnessInfo{
+		{Rig: "alive", HasSession: true, LastHeartbeat: time.Minute},
+		{Rig: "silent", HasSession: true, LastHeartbeat: 20 * time.Minute},
+		{Rig: "gone", HasSession: false},
+	}}
+
+	events := e.Evaluate(msg, time.Now())
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Key != "witness:gone" || events[1].Key != "witness:silent" {
+		t.Errorf("unexpected keys: %q, %q", events[0].Key, events[1].Key)
+	}
+}
+
+func TestQueueDepthRule(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "queue", Kind: config.AlertQueueDepth, Threshold: 5})
+	msg := pane.RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "kt", QueueDepth: 7},
+		{Rig: "gt", QueueDepth: 2},
+	}}
+
+	events := e.Evaluate(msg, time.Now())
+	if len(events) != 1 || events[0].Key != "queue:kt" {
+		t.Errorf("expected single queue:kt event, got %v", events)
+	}
+}
+
+func TestCIFailureRule(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "ci", Kind: config.AlertCIFailure})
+	msg := pane.PRUpdateMsg{PRs: []data.PRInfo{
+		{Number: 1, StatusChecks: []data.PRStatusCheck{{Name: "test", Conclusion: "SUCCESS"}}},
+		{Number: 2, StatusChecks: []data.PRStatusCheck{{Name: "lint", Conclusion: "FAILURE"}, {Name: "test", Conclusion: "FAILURE"}}},
+	}}
+
+	events := e.Evaluate(msg, time.Now())
+	if len(events) != 1 || events[0].Key != "ci:#2" {
+		t.Errorf("expected single ci:#2 event, got %v", events)
+	}
+}
+
+func TestConvoyCompleteRule(t *testing.T) {
+	e := engineWith(0, config.AlertRule{Name: "done", Kind: config.AlertConvoyComplete, Severity: "info"})
+	msg := pane.ConvoyUpdateMsg{
+		Convoys: []data.ConvoyInfo{
+			{ID: "cv-1", Title: "Phase 1"},
+			{ID: "cv-2", Title: "Phase 2"},
+			{ID: "cv-3", Title: "Empty"},
+		},
+		Progress: map[string][2]int{"cv-1": {3, 3}, "cv-2": {1, 3}, "cv-3": {0, 0}},
+	}
+
+	events := e.Evaluate(msg, time.Now())
+	if len(events) != 1 || events[0].Key != "done:cv-1" {
+		t.Errorf("expected single done:cv-1 event, got %v", events)
+	}
+
+	// Failed convoy fetches arrive with nil Progress and are ignored.
+	if events := e.Evaluate(pane.ConvoyUpdateMsg{}, time.Now()); events != nil {
+		t.Errorf("failed fetch should not be evaluated, got %v", events)
+	}
+	if e.Active() != 1 {
+		t.Errorf("Active() = %d, want 1", e.Active())
+	}
+}
+
+func TestEvaluateIgnoresUnrelatedMessages(t *testing.T) {
+	e := NewEngine(config.Alerts{Rules: config.DefaultAlertRules()})
+	if events := e.Evaluate(pane.MailUpdateMsg{}, time.Now()); events != nil {
+		t.Errorf("unrelated message fired events: %v", events)
+	}
+}
+
+func TestNilEngine(t *testing.T) {
+	var e *Engine
+	if events := e.Evaluate(pane.AgentUpdateMsg{}, time.Now()); events != nil {
+		t.Error("nil engine should return no events")
+	}
+	if e.Active() != 0 {
+		t.Error("nil engine should have no active alerts")
+	}
+}
@@ -0,0 +1,70 @@
+// Package api serves Kestral's aggregated town data as read-only JSON over
+// HTTP, for scripts and status boards.
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// NewHandler returns an http.Handler exposing src under /api/. Every
+// request must carry "Authorization: Bearer <token>".
+//
+//	GET /api/status     gt status (TownStatus)
+//	GET /api/sessions   tmux sessions
+//	GET /api/agents     agents with status and hooked issue (AgentDetail)
+//	GET /api/refinery   merge queues per rig (RefineryStatus)
+//	GET /api/resources  CPU and memory per session (SessionResource)
+//	GET /api/witnesses  witness heartbeats (WitnessDetail)
+//	GET /api/prs        open pull requests (PRInfo)
+func NewHandler(src data.Source, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/status", serve(src.FetchStatus))
+	mux.HandleFunc("GET /api/sessions", serve(src.FetchSessions))
+	mux.HandleFunc("GET /api/agents", serve(src.FetchAgents))
+	mux.HandleFunc("GET /api/refinery", serve(src.FetchRefineryStatus))
+	mux.HandleFunc("GET /api/resources", serve(src.FetchResources))
+	mux.HandleFunc("GET /api/witnesses", serve(src.FetchWitnesses))
+	mux.HandleFunc("GET /api/prs", serve(src.FetchPullRequests))
+	return requireToken(token, mux)
+}
+
+// serve adapts a fetch to a handler that writes its result as JSON, or a
+// 502 if the underlying command failed.
+func serve[T any](fetch func() (T, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v, err := fetch()
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+// requireToken rejects requests without the expected bearer token.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="kestral"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
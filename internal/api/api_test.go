@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// fakeSource serves canned data. Unused Source methods are left to the
+// embedded nil interface and panic if called.
+type fakeSource struct {
+	data.Source
+	agents   []data.AgentDetail
+	agentErr error
+}
+
+func (f fakeSource) FetchAgents() ([]data.AgentDetail, error) { return f.agents, f.agentErr }
+func (f fakeSource) FetchPullRequests() ([]data.PRInfo, error) {
+	return []data.PRInfo{{Number: 42, Title: "Add API"}}, nil
+}
+
+func get(h http.Handler, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAgentsEndpoint(t *testing.T) {
+	h := NewHandler(fakeSource{agents: []data.AgentDetail{
+		{Name: "quartz", Rig: "kt", Role: "polecat", Status: "working"},
+	}}, "secret")
+
+	rec := get(h, "/api/agents", "secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var agents []data.AgentDetail
+	if err := json.Unmarshal(rec.Body.Bytes(), &agents); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(agents) != 1 || agents[0].Name != "quartz" || agents[0].Status != "working" {
+		t.Errorf("unexpected agents %+v", agents)
+	}
+}
+
+func TestPRsEndpoint(t *testing.T) {
+	h := NewHandler(fakeSource{}, "secret")
+	rec := get(h, "/api/prs", "secret")
+	var prs []data.PRInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &prs); err != nil || len(prs) != 1 || prs[0].Number != 42 {
+		t.Errorf("unexpected response %s (%v)", rec.Body.String(), err)
+	}
+}
+
+func TestFetchErrorReturnsBadGateway(t *testing.T) {
+	h := NewHandler(fakeSource{agentErr: errors.New("tmux not running")}, "secret")
+	rec := get(h, "/api/agents", "secret")
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want 502", rec.Code)
+	}
+	var body map[string]string
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if body["error"] != "tmux not running" {
+		t.Errorf("error body = %v", body)
+	}
+}
+
+func TestTokenRequired(t *testing.T) {
+	h := NewHandler(fakeSource{}, "secret")
+	for _, token := range []string{"", "wrong"} {
+		rec := get(h, "/api/agents", token)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("token %q: status = %d, want 401", token, rec.Code)
+		}
+	}
+
+	// An empty configured token never authorizes.
+	if rec := get(NewHandler(fakeSource{}, ""), "/api/agents", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("empty token: status = %d, want 401", rec.Code)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	h := NewHandler(fakeSource{}, "secret")
+	req := httptest.NewRequest(http.MethodPost, "/api/agents", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+
+	if rec := get(h, "/api/unknown", "secret"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown path status = %d, want 404", rec.Code)
+	}
+}
@@ -1,7 +1,9 @@
 package app
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -10,9 +12,15 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/tnguyen21/kestral-tui/internal/alert"
 	"github.com/tnguyen21/kestral-tui/internal/config"
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/event"
+	"github.com/tnguyen21/kestral-tui/internal/export"
+	"github.com/tnguyen21/kestral-tui/internal/feed"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/pane"
+	"github.com/tnguyen21/kestral-tui/internal/session"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -25,7 +33,7 @@ type Model struct {
 	height      int
 	layoutMode  LayoutMode
 	keys        KeyMap
-	fetcher     *data.Fetcher
+	fetcher     data.Source
 	config      *config.Config
 	help        help.Model
 	showHelp     bool
@@ -33,31 +41,287 @@ type Model struct {
 	pickerCursor int
 	lastRefresh  time.Time
 	detailAgent  *pane.AgentInfo // agent currently viewed in detail mode
+	witnessRig   string          // rig whose witness is viewed in detail mode
+	nudge        *nudgeWatch     // nudged agent whose reply is being watched
+	alerts       *alert.Engine
+	banner       *alert.Event // most recent alert, shown in place of the status bar
+	bannerMore   int          // other alerts fired alongside banner
+	bannerSeq    int          // incremented per banner so stale expiries are ignored
+	out          io.Writer    // client terminal, for the bell; nil disables it
+	differ       *event.Differ
+	events       *pane.EventsPane // also one of panes; read for the ticker
+	split        Split                     // layout preset; see Regions
+	regions      []int                     // pane index shown in each split region
+	focus        int                       // focused region; regions[focus] == activePane
+	pending      map[string]pane.PaneState // restored state awaiting pane data, by pane title
+	saveSession  func(session.State)       // receives UI state after input; nil disables
+	th           *theme.Theme              // this session's palette
+	themes       []*theme.Theme            // theme picker entries
+	showThemes   bool
+	themeCursor  int
+	slots        []int  // pane index per number key (pane_1..pane_9, pane_0); -1 if unused
+	toast        string // short confirmation shown in place of the status bar
+	toastSeq     int    // incremented per toast so stale expiries are ignored
+	yankNext     int    // candidate the next yank copies; reset by other keys
+	towns        []town             // every configured town; see switchTown
+	town         int                // index of the town shown
+	townGen      int                // incremented per switch so the old town's polls are dropped
+	townStatus   []pane.TownSummary // latest health of every town
+	showTowns    bool
+	townCursor   int
 }
 
-// New creates a root Model with the given config.
+// bannerDuration is how long an alert banner replaces the status bar.
+const bannerDuration = 10 * time.Second
+
+// bannerExpireMsg clears the alert banner if it is still the one identified
+// by seq.
+type bannerExpireMsg struct {
+	seq int
+}
+
+// toastDuration is how long a toast replaces the status bar.
+const toastDuration = 3 * time.Second
+
+// toastExpireMsg clears the toast if it is still the one identified by seq.
+type toastExpireMsg struct {
+	seq int
+}
+
+// New creates a root Model with the given config. Each configured town
+// gets its own panes; the first one is shown.
 func New(cfg config.Config) Model {
-	fetcher := &data.Fetcher{TownRoot: cfg.TownRoot}
-	panes := []pane.Pane{
+	km, err := keymap.New(cfg.Keybindings)
+	if err != nil {
+		km = keymap.Default() // config.Load has already reported it
+	}
+
+	list := cfg.TownList()
+	towns := make([]town, len(list))
+	var slots []int
+	for i, t := range list {
+		towns[i] = newTown(t.Name, cfg.ForTown(t), km)
+		slots = towns[i].slots
+	}
+	cur := &towns[0]
+	regions := layoutPanes(cur.panes, cfg.Layout.Panes)
+
+	h := help.New()
+	h.ShowAll = true
+
+	themes := theme.All(cfg.Themes)
+	th, ok := theme.Find(themes, cfg.Theme)
+	if !ok {
+		th = theme.Default() // config.Load has already reported it
+	}
+
+	m := Model{
+		panes:      cur.panes,
+		activePane: regions[0],
+		keys:       NewKeyMap(km),
+		fetcher:    cur.src,
+		config:     &cur.cfg,
+		help:       h,
+		alerts:     cur.alerts,
+		differ:     cur.differ,
+		events:     cur.events,
+		split:      ParseSplit(cfg.Layout.Mode),
+		regions:    regions,
+		themes:     themes,
+		slots:      slots,
+		towns:      towns,
+	}
+	m.setTheme(th)
+	return m
+}
+
+// newPanes builds the enabled panes for one town, bound to km, and returns
+// them with the pane index behind each number key and the Events pane.
+func newPanes(cfg config.Config, km keymap.Map) ([]pane.Pane, []int, *pane.EventsPane) {
+	events := pane.NewEventsPane(cfg.EventHistory)
+	newIssue := pane.NewNewIssuePane()
+	newIssue.SetTownRoot(cfg.TownRoot)
+	dispatch := pane.NewDispatchPane()
+	dispatch.SetTownRoot(cfg.TownRoot)
+	services := pane.NewServicesPane()
+	services.SetTownRoot(cfg.TownRoot)
+	convoys := pane.NewConvoysPane()
+	convoys.SetTownRoot(cfg.TownRoot)
+	refinery := pane.NewRefineryPane()
+	refinery.SetTownRoot(cfg.TownRoot)
+	resources := pane.NewResourcesPane()
+	resources.SetThresholds(cfg.Thresholds())
+	witness := pane.NewWitnessPane()
+	witness.SetThresholds(cfg.Thresholds())
+	panes, slots := enabledPanes([]pane.Pane{
 		pane.NewDashboard(),
 		pane.NewAgentsPane(),
-		pane.NewRefineryPane(),
+		refinery,
 		pane.NewPRsPane(),
-		pane.NewConvoysPane(),
-		pane.NewResourcesPane(),
+		convoys,
+		resources,
 		pane.NewHistoryPane(),
-		pane.NewNewIssuePane(),
+		newIssue,
 		pane.NewMailPane(),
-		pane.NewWitnessPane(),
+		witness,
+		events,
+		dispatch,
+		services,
+	}, cfg.Panes)
+	for _, p := range panes {
+		if r, ok := p.(pane.Rebindable); ok {
+			r.SetKeyMap(km)
+		}
+	}
+	return panes, slots, events
+}
+
+// paneSlots is the number of pane number keys: pane_1 to pane_9, then
+// pane_0.
+const paneSlots = 10
+
+// enabledPanes returns the panes named in refs, in that order, and the pane
+// index behind each number key. Panes pinned to a key take it; the rest
+// fill the free keys in order, and any beyond the tenth are reached through
+// the picker. config.Load rejects unknown titles; one in a config built in
+// code is skipped, and if none match every pane stays enabled rather than
+// leaving the session with nothing to show.
+func enabledPanes(all []pane.Pane, refs []config.PaneRef) ([]pane.Pane, []int) {
+	panes := all
+	var pinned []string
+	if len(refs) > 0 {
+		var picked []pane.Pane
+		for _, ref := range refs {
+			for _, p := range all {
+				if strings.EqualFold(p.Title(), ref.Name) {
+					picked = append(picked, p)
+					pinned = append(pinned, ref.Key)
+					break
+				}
+			}
+		}
+		if len(picked) > 0 {
+			panes = picked
+		} else {
+			pinned = nil
+		}
 	}
 
-	return Model{
-		panes:   panes,
-		keys:    DefaultKeyMap(),
-		fetcher: fetcher,
-		config:  &cfg,
-		help:    help.New(),
+	slots := make([]int, paneSlots)
+	for i := range slots {
+		slots[i] = -1
 	}
+	assigned := make(map[int]bool)
+	for i, k := range pinned {
+		if k == "" {
+			continue
+		}
+		slot := int(k[0]-'0') - 1
+		if slot < 0 {
+			slot = paneSlots - 1 // "0" is the tenth key
+		}
+		slots[slot] = i
+		assigned[i] = true
+	}
+	next := 0
+	for i := range panes {
+		if assigned[i] {
+			continue
+		}
+		for next < paneSlots && slots[next] >= 0 {
+			next++
+		}
+		if next == paneSlots {
+			break
+		}
+		slots[next] = i
+	}
+	return panes, slots
+}
+
+// layoutPanes returns the pane index for each of the four split regions:
+// the panes named in titles, then the remaining panes in order. Unknown
+// titles are skipped.
+func layoutPanes(panes []pane.Pane, titles []string) []int {
+	var regions []int
+	used := make(map[int]bool)
+	for _, title := range titles {
+		for i, p := range panes {
+			if strings.EqualFold(p.Title(), title) && !used[i] {
+				regions = append(regions, i)
+				used[i] = true
+				break
+			}
+		}
+	}
+	for i := range panes {
+		if len(regions) == 4 {
+			break
+		}
+		if !used[i] {
+			regions = append(regions, i)
+		}
+	}
+	return regions
+}
+
+// WithOutput returns a copy of m that writes out-of-band escape sequences
+// such as the terminal bell to w. w should be the program's output, wrapped
+// in a TerminalWriter shared with it.
+func (m Model) WithOutput(w io.Writer) Model {
+	m.out = w
+	return m
+}
+
+// WithSource returns a copy of m that reads the current town's data from
+// src, such as a data.Cache shared between sessions. With several towns,
+// use WithTownSources.
+func (m Model) WithSource(src data.Source) Model {
+	m.fetcher = src
+	m.towns[m.town].src = src
+	return m
+}
+
+// WithSession returns a copy of m that resumes from st and passes its UI
+// state to save after every key or mouse input. Pane state is applied as
+// each pane's data arrives, so selections survive a reconnect.
+func (m Model) WithSession(st session.State, save func(session.State)) Model {
+	for i, t := range m.towns {
+		if t.name == st.Town {
+			m.switchTown(i) // Init starts the polls
+		}
+	}
+	for i, p := range m.panes {
+		if p.Title() == st.ActivePane {
+			m.activePane = i
+		}
+	}
+	m.pending = make(map[string]pane.PaneState, len(st.Panes))
+	for title, ps := range st.Panes {
+		m.pending[title] = ps
+	}
+	if th, ok := theme.Find(m.themes, st.Theme); ok {
+		m.setTheme(th)
+	}
+	m.saveSession = save
+	return m
+}
+
+// setTheme switches the session, its panes and the help view to th.
+func (m *Model) setTheme(th *theme.Theme) {
+	m.th = th
+	for _, p := range m.panes {
+		if t, ok := p.(pane.Themed); ok {
+			t.SetTheme(th)
+		}
+	}
+	m.help.Styles.ShortKey = th.AccentStyle
+	m.help.Styles.ShortDesc = th.MutedStyle
+	m.help.Styles.ShortSeparator = th.MutedStyle
+	m.help.Styles.FullKey = th.AccentStyle
+	m.help.Styles.FullDesc = th.MutedStyle
+	m.help.Styles.FullSeparator = th.MutedStyle
+	m.help.Styles.Ellipsis = th.MutedStyle
 }
 
 // ShortHelp implements help.KeyMap for the application key bindings.
@@ -70,43 +334,215 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Quit, k.Tab, k.ShiftTab, k.PanePicker},
 		{k.Pane1, k.Pane2, k.Pane3, k.Pane4},
-		{k.Up, k.Down, k.Select, k.Back},
-		{k.Refresh, k.Help},
+		{k.Up, k.Down, k.Select, k.Back, k.Yank, k.Export, k.ExportFile},
+		{k.Refresh, k.Help, k.Focus, k.Layout, k.Theme, k.Town},
 	}
 }
 
 // Ensure KeyMap satisfies help.KeyMap at compile time.
 var _ help.KeyMap = KeyMap{}
 
+// helpKeys is the help view's key map: the active pane's bindings followed
+// by the global ones, all reflecting any remapping.
+type helpKeys struct {
+	KeyMap
+	pane []key.Binding
+}
+
+// FullHelp implements help.KeyMap.
+func (h helpKeys) FullHelp() [][]key.Binding {
+	groups := h.KeyMap.FullHelp()
+	if len(h.pane) == 0 {
+		return groups
+	}
+	return append([][]key.Binding{h.pane}, groups...)
+}
+
+// helpKeyMap returns the bindings shown in the help view.
+func (m Model) helpKeyMap() help.KeyMap {
+	h := helpKeys{KeyMap: m.keys}
+	if m.activePane < len(m.panes) {
+		if r, ok := m.panes[m.activePane].(pane.Rebindable); ok {
+			h.pane = r.KeyBindings()
+		}
+	}
+	return h
+}
+
 // Init starts the initial data fetches.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		fetchStatusCmd(m.fetcher),
-		fetchAgentsCmd(m.fetcher),
-		fetchConvoysCmd(m.fetcher),
-		fetchHistoryCmd(m.fetcher),
-		fetchRigsCmd(m.fetcher),
-		fetchMailCmd(m.fetcher),
-		fetchRefineryCmd(m.fetcher),
-		fetchResourcesCmd(m.fetcher),
-		fetchWitnessesCmd(m.fetcher),
-		fetchPRsCmd(m.fetcher),
-	)
-}
-
-// Update handles all incoming messages.
+	cmds := m.pollCmds()
+	if m.showsPane(pane.PaneNewIssue, pane.PaneDispatch, pane.PaneServices) {
+		cmds = append(cmds, m.tag(fetchRigsCmd(m.fetcher)))
+	}
+	if m.multiTown() && m.showsPane(pane.PaneDashboard) {
+		cmds = append(cmds, fetchTownsCmd(m.towns))
+	}
+	return tea.Batch(cmds...)
+}
+
+// polls lists each polled fetch and the panes drawn from it. The Events
+// pane is built from changes between polls, so it needs every feed the
+// event differ reads. Status is always polled for the status bar.
+var polls = []struct {
+	fetch func(data.Source) tea.Cmd
+	panes []pane.PaneID // nil = always
+}{
+	{fetchStatusCmd, nil},
+	{feed.Agents, []pane.PaneID{pane.PaneAgents, pane.PaneEvents, pane.PaneDispatch}},
+	{feed.Convoys, []pane.PaneID{pane.PaneDashboard, pane.PaneConvoys, pane.PaneEvents}},
+	{feed.Mail, []pane.PaneID{pane.PaneMail, pane.PaneEvents}},
+	{feed.Refinery, []pane.PaneID{pane.PaneRefinery, pane.PaneEvents}},
+	{fetchResourcesCmd, []pane.PaneID{pane.PaneResources}},
+	{feed.Witnesses, []pane.PaneID{pane.PaneWitness, pane.PaneEvents}},
+	{feed.PRs, []pane.PaneID{pane.PanePRs, pane.PaneEvents}},
+	{feed.History, []pane.PaneID{pane.PaneHistory, pane.PaneEvents}},
+	{fetchReadyCmd, []pane.PaneID{pane.PaneDispatch}},
+	{fetchServicesCmd, []pane.PaneID{pane.PaneServices}},
+}
+
+// pollCmds returns the fetches for the enabled panes, tagged with the
+// current town. Each one's result schedules the next poll, so a fetch left
+// out here is never polled.
+func (m Model) pollCmds() []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, poll := range polls {
+		if poll.panes == nil || m.showsPane(poll.panes...) {
+			cmds = append(cmds, m.tag(poll.fetch(m.fetcher)))
+		}
+	}
+	return cmds
+}
+
+// showsPane reports whether any of ids is enabled.
+func (m Model) showsPane(ids ...pane.PaneID) bool {
+	for _, p := range m.panes {
+		for _, id := range ids {
+			if p.ID() == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Update handles all incoming messages. Every message is first run through
+// the alert engine and the event differ so rules are evaluated and changes
+// recorded on each data update. Polls of a town the session has switched
+// away from are dropped.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	gen := -1
+	if tm, ok := msg.(townMsg); ok {
+		if tm.gen != m.townGen {
+			return m, nil
+		}
+		msg, gen = tm.msg, tm.gen
+	}
+
+	now := time.Now()
+	var extra []tea.Cmd
+	if alerts := m.alerts.Evaluate(msg, now); len(alerts) > 0 {
+		extra = append(extra, m.raiseAlerts(alerts))
+	}
+	if events := m.differ.Diff(msg, now); len(events) > 0 {
+		extra = append(extra, m.forwardToAllPanes(pane.EventMsg{Events: events})...)
+		if !m.showsPane(pane.PaneEvents) {
+			m.events.Update(pane.EventMsg{Events: events}) // still feeds the ticker
+		}
+	}
+
+	newModel, cmd := m.update(msg)
+	if gen >= 0 {
+		cmd = tagged(gen, cmd) // keep the town's poll chain tagged
+	}
+	if nm, ok := newModel.(Model); ok {
+		nm.syncRegions()
+		extra = append(extra, nm.restorePending()...)
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			nm.persistSession(now)
+		}
+	}
+	if len(extra) == 0 {
+		return newModel, cmd
+	}
+	return newModel, tea.Batch(append(extra, cmd)...)
+}
+
+// restorePending applies saved pane state to panes that can now accept it
+// and returns the commands their restores produced.
+func (m Model) restorePending() []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, p := range m.panes {
+		ps, ok := m.pending[p.Title()]
+		if !ok {
+			continue
+		}
+		sp, ok := p.(pane.Stateful)
+		if !ok {
+			delete(m.pending, p.Title())
+			continue
+		}
+		done, cmd := sp.RestoreState(ps)
+		if done {
+			delete(m.pending, p.Title())
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+// persistSession hands the current UI state to the session sink. Panes
+// still waiting to restore keep their saved state, so reconnecting again
+// before data loads loses nothing.
+func (m Model) persistSession(now time.Time) {
+	if m.saveSession == nil {
+		return
+	}
+	st := session.State{Theme: m.th.Name, Panes: make(map[string]pane.PaneState), SavedAt: now}
+	if m.multiTown() {
+		st.Town = m.towns[m.town].name
+	}
+	if m.activePane < len(m.panes) {
+		st.ActivePane = m.panes[m.activePane].Title()
+	}
+	for _, p := range m.panes {
+		if ps, ok := m.pending[p.Title()]; ok {
+			st.Panes[p.Title()] = ps
+		} else if sp, ok := p.(pane.Stateful); ok {
+			st.Panes[p.Title()] = sp.SaveState()
+		}
+	}
+	m.saveSession(st)
+}
+
+// update routes msg to the appropriate handler.
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case bannerExpireMsg:
+		if msg.seq == m.bannerSeq {
+			m.banner = nil
+			m.bannerMore = 0
+		}
+		return m, nil
+
+	case toastExpireMsg:
+		if msg.seq == m.toastSeq {
+			m.toast = ""
+		}
+		return m, nil
+
+	case exportDoneMsg:
+		return m, m.showToast(msg.toast())
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.layoutMode = GetLayoutMode(msg.Width)
 		m.help.Width = msg.Width
-		contentH := ContentHeight(msg.Height)
-		for i, p := range m.panes {
-			p.SetSize(msg.Width, contentH)
-			m.panes[i] = p
-		}
+		m.resizePanes()
 		return m, nil
 
 	case tea.KeyMsg:
@@ -119,21 +555,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case data.StatusTickMsg:
 		return m, fetchStatusCmd(m.fetcher)
 	case data.AgentTickMsg:
-		return m, fetchAgentsCmd(m.fetcher)
+		return m, feed.Agents(m.fetcher)
 	case data.ConvoyTickMsg:
-		return m, fetchConvoysCmd(m.fetcher)
+		return m, feed.Convoys(m.fetcher)
 	case data.MailTickMsg:
-		return m, fetchMailCmd(m.fetcher)
+		return m, feed.Mail(m.fetcher)
 	case data.RefineryTickMsg:
-		return m, fetchRefineryCmd(m.fetcher)
+		return m, feed.Refinery(m.fetcher)
 	case data.ResourceTickMsg:
 		return m, fetchResourcesCmd(m.fetcher)
 	case data.WitnessTickMsg:
-		return m, fetchWitnessesCmd(m.fetcher)
+		return m, feed.Witnesses(m.fetcher)
 	case data.PRTickMsg:
-		return m, fetchPRsCmd(m.fetcher)
+		return m, feed.PRs(m.fetcher)
 	case data.HistoryTickMsg:
-		return m, fetchHistoryCmd(m.fetcher)
+		return m, feed.History(m.fetcher)
+	case data.ReadyTickMsg:
+		return m, fetchReadyCmd(m.fetcher)
+	case data.ServicesTickMsg:
+		return m, fetchServicesCmd(m.fetcher)
+	case data.TownsTickMsg:
+		return m, fetchTownsCmd(m.towns)
 
 	// Data update messages — forward to all panes and schedule next poll.
 	case pane.StatusUpdateMsg:
@@ -151,8 +593,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case pane.ConvoyUpdateMsg:
 		cmds := m.forwardToAllPanes(msg)
-		cmds = append(cmds, data.ScheduleConvoyPoll(
-			time.Duration(m.config.PollInterval.Convoys)*time.Second))
+		if !msg.Once {
+			cmds = append(cmds, data.ScheduleConvoyPoll(
+				time.Duration(m.config.PollInterval.Convoys)*time.Second))
+		}
+		return m, tea.Batch(cmds...)
+
+	case pane.ConvoyCheckMsg:
+		cmds := m.forwardToAllPanes(msg)
+		if msg.Err == nil {
+			cmds = append(cmds, m.tag(refreshConvoysCmd(m.fetcher)))
+		}
 		return m, tea.Batch(cmds...)
 
 	case pane.HistoryUpdateMsg:
@@ -161,6 +612,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			time.Duration(m.config.PollInterval.Convoys)*time.Second))
 		return m, tea.Batch(cmds...)
 
+	case pane.TownsUpdateMsg:
+		m.townStatus = msg.Towns
+		cmds := m.forwardToAllPanes(pane.TownsUpdateMsg{Towns: m.townSummaries()})
+		cmds = append(cmds, data.ScheduleTownsPoll(
+			time.Duration(m.config.PollInterval.Status)*time.Second))
+		return m, tea.Batch(cmds...)
+
 	// Rig list and issue submission — forward to all panes.
 	case pane.RigListMsg:
 		cmds := m.forwardToAllPanes(msg)
@@ -170,6 +628,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds := m.forwardToAllPanes(msg)
 		return m, tea.Batch(cmds...)
 
+	case pane.SlingResultMsg:
+		cmds := m.forwardToAllPanes(msg)
+		return m, tea.Batch(cmds...)
+
+	case pane.ServicesUpdateMsg:
+		cmds := m.forwardToAllPanes(msg)
+		if !msg.Once {
+			cmds = append(cmds, data.ScheduleServicesPoll(
+				time.Duration(m.config.PollInterval.Status)*time.Second))
+		}
+		return m, tea.Batch(cmds...)
+
+	case pane.ServiceStepMsg:
+		// Show each step's effect without waiting for the next poll.
+		cmds := m.forwardToAllPanes(msg)
+		cmds = append(cmds, m.tag(refreshServicesCmd(m.fetcher)))
+		return m, tea.Batch(cmds...)
+
+	case pane.ReadyUpdateMsg:
+		cmds := m.forwardToAllPanes(msg)
+		cmds = append(cmds, data.ScheduleReadyPoll(
+			time.Duration(m.config.PollInterval.Convoys)*time.Second))
+		return m, tea.Batch(cmds...)
+
 	case pane.MailUpdateMsg:
 		cmds := m.forwardToAllPanes(msg)
 		cmds = append(cmds, data.ScheduleMailPoll(
@@ -178,18 +660,88 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case pane.RefineryUpdateMsg:
 		cmds := m.forwardToAllPanes(msg)
-		cmds = append(cmds, data.ScheduleRefineryPoll(
-			time.Duration(m.config.PollInterval.Refinery)*time.Second))
+		if !msg.Once {
+			cmds = append(cmds, data.ScheduleRefineryPoll(
+				time.Duration(m.config.PollInterval.Refinery)*time.Second))
+		}
+		return m, tea.Batch(cmds...)
+
+	case pane.MRSelectedMsg:
+		return m, m.tag(fetchMROutputCmd(m.fetcher, msg))
+
+	case pane.MRActionMsg:
+		cmds := m.forwardToAllPanes(msg)
+		if msg.Err == nil {
+			cmds = append(cmds, m.tag(refreshRefineryCmd(m.fetcher)))
+		}
 		return m, tea.Batch(cmds...)
 
 	// Agent detail view messages
 	case pane.AgentSelectedMsg:
 		agent := msg.Agent
 		m.detailAgent = &agent
-		return m, fetchAgentDetailCmd(m.fetcher, agent.Rig, agent.Name)
+		return m, m.tag(fetchAgentDetailCmd(m.fetcher, agent))
 
 	case pane.AgentDeselectedMsg:
 		m.detailAgent = nil
+		m.nudge = nil
+		return m, nil
+
+	// Witness detail view messages
+	case pane.WitnessSelectedMsg:
+		m.witnessRig = msg.Rig
+		return m, m.tag(fetchWitnessDetailCmd(m.fetcher, msg.Rig))
+
+	case pane.WitnessDeselectedMsg:
+		m.witnessRig = ""
+		return m, nil
+
+	case pane.WitnessDetailMsg:
+		cmds := m.forwardToAllPanes(msg)
+		if m.witnessRig != "" && m.witnessRig == msg.Rig {
+			cmds = append(cmds, data.ScheduleWitnessDetailPoll(10*time.Second))
+		}
+		return m, tea.Batch(cmds...)
+
+	case data.WitnessDetailTickMsg:
+		if m.witnessRig != "" {
+			return m, m.tag(fetchWitnessDetailCmd(m.fetcher, m.witnessRig))
+		}
+		return m, nil
+
+	case pane.NudgeOpenMsg:
+		return m.openNudge(msg.Agent)
+
+	case pane.NudgeRequestMsg:
+		return m, m.tag(nudgeCmd(m.fetcher, m.config.TownRoot, msg))
+
+	case pane.NudgeSentMsg:
+		cmds := m.forwardToAllPanes(msg)
+		if msg.Err == nil {
+			m.nudge = newNudgeWatch(msg)
+			cmds = append(cmds, data.ScheduleNudgePoll(nudgePollInterval))
+		}
+		return m, tea.Batch(cmds...)
+
+	case data.NudgeTickMsg:
+		if m.nudge != nil {
+			return m, m.tag(fetchNudgeOutputCmd(m.fetcher, *m.nudge))
+		}
+		return m, nil
+
+	case pane.NudgeOutputMsg:
+		cmds := m.forwardToAllPanes(msg)
+		if m.nudge != nil && m.nudge.target == msg.Target {
+			if msg.Done {
+				m.nudge = nil
+			} else {
+				cmds = append(cmds, data.ScheduleNudgePoll(nudgePollInterval))
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case pane.EventSelectedMsg:
+		m.followLink(msg.Link)
 		return m, nil
 
 	case pane.AgentDetailDataMsg:
@@ -201,7 +753,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case data.AgentDetailTickMsg:
 		if m.detailAgent != nil {
-			return m, fetchAgentDetailCmd(m.fetcher, m.detailAgent.Rig, m.detailAgent.Name)
+			return m, m.tag(fetchAgentDetailCmd(m.fetcher, *m.detailAgent))
 		}
 		return m, nil
 
@@ -234,25 +786,33 @@ func (m Model) View() string {
 	}
 
 	headerBar := m.renderHeaderBar()
+	ticker := m.renderTicker()
 	statusBar := m.renderStatusBar()
 
 	var content string
-	if m.showPicker {
+	if m.showThemes {
+		content = m.renderThemePicker()
+	} else if m.showTowns {
+		content = m.renderTownPicker()
+	} else if m.showPicker {
 		content = m.renderPicker()
 	} else if m.showHelp {
-		content = m.help.View(m.keys)
+		content = m.help.View(m.helpKeyMap())
+	} else if regions := m.layoutRegions(); len(regions) > 1 {
+		content = m.renderRegions(regions)
 	} else if m.activePane < len(m.panes) {
 		content = m.panes[m.activePane].View()
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, headerBar, content, statusBar)
+	return lipgloss.JoinVertical(lipgloss.Left, headerBar, content, ticker, statusBar)
 }
 
 // inputPane returns true if the active pane captures keyboard input
 // (e.g., a form) and most global keys should be forwarded instead.
 func (m Model) inputPane() bool {
 	if m.activePane < len(m.panes) {
-		return m.panes[m.activePane].ID() == pane.PaneNewIssue
+		c, ok := m.panes[m.activePane].(pane.InputCapturer)
+		return ok && c.CapturesInput()
 	}
 	return false
 }
@@ -264,6 +824,12 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.showPicker {
 		return m.handlePickerKey(msg)
 	}
+	if m.showThemes {
+		return m.handleThemeKey(msg)
+	}
+	if m.showTowns {
+		return m.handleTownKey(msg)
+	}
 
 	// When an input-capturing pane is active, only handle ctrl+c for quit.
 	// All other keys go to the pane for text input.
@@ -274,10 +840,22 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateActivePane(msg)
 	}
 
+	yankNext := m.yankNext
+	m.yankNext = 0
+
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
 
+	case key.Matches(msg, m.keys.Yank):
+		return m.yank(yankNext)
+
+	case key.Matches(msg, m.keys.Export):
+		return m.export(false)
+
+	case key.Matches(msg, m.keys.ExportFile):
+		return m.export(true)
+
 	case key.Matches(msg, m.keys.Help):
 		m.showHelp = !m.showHelp
 		return m, nil
@@ -287,6 +865,19 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.pickerCursor = m.activePane
 		return m, nil
 
+	case key.Matches(msg, m.keys.Theme):
+		m.showThemes = true
+		m.themeCursor = 0
+		for i, t := range m.themes {
+			if t == m.th {
+				m.themeCursor = i
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Town):
+		return m.openTownPicker()
+
 	case key.Matches(msg, m.keys.Tab):
 		m.activePane = (m.activePane + 1) % len(m.panes)
 		return m, nil
@@ -295,18 +886,20 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.activePane = (m.activePane - 1 + len(m.panes)) % len(m.panes)
 		return m, nil
 
+	case key.Matches(msg, m.keys.Focus):
+		if n := len(m.layoutRegions()); n > 1 {
+			m.focus = (m.focus + 1) % n
+			m.activePane = m.regions[m.focus]
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Layout):
+		m.split = (m.split + 1) % splitCount
+		m.resizePanes()
+		return m, nil
+
 	case key.Matches(msg, m.keys.Refresh):
-		return m, tea.Batch(
-			fetchStatusCmd(m.fetcher),
-			fetchAgentsCmd(m.fetcher),
-			fetchConvoysCmd(m.fetcher),
-			fetchMailCmd(m.fetcher),
-			fetchRefineryCmd(m.fetcher),
-			fetchResourcesCmd(m.fetcher),
-			fetchWitnessesCmd(m.fetcher),
-			fetchPRsCmd(m.fetcher),
-			fetchHistoryCmd(m.fetcher),
-		)
+		return m, tea.Batch(m.pollCmds()...)
 	}
 
 	// Number keys for direct pane switching.
@@ -320,36 +913,52 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 // paneKeyIndex returns the pane index if msg matches a pane number key.
-// Keys 1-9 map to indices 0-8; key 0 maps to index 9.
 func (m Model) paneKeyIndex(msg tea.KeyMsg) (int, bool) {
-	paneKeys := []key.Binding{
-		m.keys.Pane1, m.keys.Pane2, m.keys.Pane3,
-		m.keys.Pane4, m.keys.Pane5, m.keys.Pane6, m.keys.Pane7,
-		m.keys.Pane8, m.keys.Pane9,
-	}
-	for i, k := range paneKeys {
-		if key.Matches(msg, k) && i < len(m.panes) {
-			return i, true
+	for slot, k := range m.keys.paneKeys() {
+		if key.Matches(msg, k) && m.slots[slot] >= 0 {
+			return m.slots[slot], true
 		}
 	}
-	// Key "0" maps to index 9 (10th pane).
-	if key.Matches(msg, m.keys.Pane0) && 9 < len(m.panes) {
-		return 9, true
-	}
 	return 0, false
 }
 
+// paneKeyLabel returns the number key that selects pane i, or "" if it has
+// none.
+func (m Model) paneKeyLabel(i int) string {
+	for slot, k := range m.keys.paneKeys() {
+		if m.slots[slot] == i {
+			return keymap.Label(k)
+		}
+	}
+	return ""
+}
+
 // handleMouse processes mouse events, detecting header/picker clicks.
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
 		if m.showPicker {
 			return m.handlePickerMouse(msg)
 		}
+		if m.showThemes {
+			return m.handleThemeMouse(msg)
+		}
+		if m.showTowns {
+			return m.handleTownMouse(msg)
+		}
+		if msg.Y == 0 && m.onTownLabel(msg.X) {
+			return m.openTownPicker()
+		}
 		if msg.Y == 0 { // Header bar row — open picker
 			m.showPicker = true
 			m.pickerCursor = m.activePane
 			return m, nil
 		}
+		// Clicking another split region focuses it.
+		if i, ok := m.regionAt(msg.X, msg.Y-TabBarHeight()); ok && i != m.focus {
+			m.focus = i
+			m.activePane = m.regions[i]
+			return m, nil
+		}
 	}
 
 	// Forward to active pane.
@@ -361,6 +970,8 @@ func (m Model) updateActivePane(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.activePane >= len(m.panes) {
 		return m, nil
 	}
+	// Input overrides anything still waiting to be restored.
+	delete(m.pending, m.panes[m.activePane].Title())
 	newModel, cmd := m.panes[m.activePane].Update(msg)
 	if newPane, ok := newModel.(pane.Pane); ok {
 		m.panes[m.activePane] = newPane
@@ -387,8 +998,8 @@ func (m *Model) forwardToAllPanes(msg tea.Msg) []tea.Cmd {
 // Header bar (replaces tab bar)
 // ---------------------------------------------------------------------------
 
-// renderHeaderBar renders a compact header showing the active pane name
-// and a hint for opening the pane picker.
+// renderHeaderBar renders a compact header showing the town, when there
+// are several, the active pane name and a hint for opening the pane picker.
 func (m Model) renderHeaderBar() string {
 	var title string
 	if m.activePane < len(m.panes) {
@@ -399,18 +1010,127 @@ func (m Model) renderHeaderBar() string {
 		}
 	}
 
-	left := theme.HeaderBarStyle.Render(title)
-	hint := theme.HeaderHintStyle.Render(
+	left := m.townLabel() + m.th.HeaderBarStyle.Render(title)
+	hint := m.th.HeaderHintStyle.Render(
 		fmt.Sprintf("%d/%d  ␣ panes", m.activePane+1, len(m.panes)))
 
 	gap := m.width - lipgloss.Width(left) - lipgloss.Width(hint)
 	if gap < 0 {
 		gap = 0
 	}
-	filler := theme.HeaderBarStyle.Render(strings.Repeat(" ", gap))
+	filler := m.th.HeaderBarStyle.Render(strings.Repeat(" ", gap))
 	return left + filler + hint
 }
 
+// ---------------------------------------------------------------------------
+// Split view
+// ---------------------------------------------------------------------------
+
+// layoutRegions returns the content regions for the current terminal size
+// and layout preset, dropping to a smaller preset when too few panes are
+// enabled to fill it. A single region means split view is off.
+func (m Model) layoutRegions() []Region {
+	for split := m.split; split > SplitSingle; split-- {
+		if r := Regions(split, m.width, ContentHeight(m.height)); len(r) <= len(m.regions) {
+			return r
+		}
+	}
+	return Regions(SplitSingle, m.width, ContentHeight(m.height))
+}
+
+// resizePanes sizes each pane for the region it is shown in. Panes that are
+// not on screen take the focused region's size, since that is where they
+// appear when selected.
+func (m *Model) resizePanes() {
+	regions := m.layoutRegions()
+	if m.focus >= len(regions) {
+		m.focus = 0
+	}
+	if len(regions) == 1 {
+		for _, p := range m.panes {
+			p.SetSize(m.width, ContentHeight(m.height))
+		}
+		return
+	}
+	f := regions[m.focus]
+	for _, p := range m.panes {
+		p.SetSize(f.Width, f.Height-1)
+	}
+	for i, r := range regions {
+		m.panes[m.regions[i]].SetSize(r.Width, r.Height-1)
+	}
+}
+
+// syncRegions puts the active pane in the focused region after a pane
+// switch. If another region already shows it, the two regions swap.
+func (m *Model) syncRegions() {
+	n := len(m.layoutRegions())
+	if m.focus >= n {
+		m.focus = 0
+	}
+	if m.regions[m.focus] == m.activePane {
+		return
+	}
+	for i := 0; i < n; i++ {
+		if m.regions[i] == m.activePane {
+			m.regions[i] = m.regions[m.focus]
+		}
+	}
+	m.regions[m.focus] = m.activePane
+	m.resizePanes()
+}
+
+// regionAt returns the split region containing content coordinates x, y.
+func (m Model) regionAt(x, y int) (int, bool) {
+	regions := m.layoutRegions()
+	if len(regions) == 1 {
+		return 0, false
+	}
+	for i, r := range regions {
+		if x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// renderRegions renders the split view, one row of regions at a time, with
+// a divider between columns.
+func (m Model) renderRegions(regions []Region) string {
+	var rows, row []string
+	for i, r := range regions {
+		if len(row) > 0 && r.X == 0 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+		}
+		if len(row) > 0 {
+			divider := strings.TrimSuffix(strings.Repeat("│\n", r.Height), "\n")
+			row = append(row, m.th.MutedStyle.Render(divider))
+		}
+		row = append(row, m.renderRegion(i, r))
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderRegion renders one region: a caption naming its pane, marked when
+// focused, above the pane clipped to the region.
+func (m Model) renderRegion(i int, r Region) string {
+	p := m.panes[m.regions[i]]
+	title := p.ShortTitle() + " " + p.Title()
+	if badge := p.Badge(); badge > 0 {
+		title += fmt.Sprintf("(%d)", badge)
+	}
+	caption := m.th.MutedStyle.Render(pane.TruncateWithEllipsis("  "+title, r.Width))
+	if i == m.focus {
+		caption = m.th.AccentStyle.Bold(true).Render(pane.TruncateWithEllipsis("▸ "+title, r.Width))
+	}
+
+	box := lipgloss.NewStyle().Width(r.Width).MaxWidth(r.Width)
+	body := box.Height(r.Height - 1).MaxHeight(r.Height - 1).Render(p.View())
+	return lipgloss.JoinVertical(lipgloss.Left, box.Render(caption), body)
+}
+
 // ---------------------------------------------------------------------------
 // Pane picker overlay
 // ---------------------------------------------------------------------------
@@ -418,15 +1138,12 @@ func (m Model) renderHeaderBar() string {
 // renderPicker renders a full-screen numbered list of all panes.
 func (m Model) renderPicker() string {
 	var b strings.Builder
-	b.WriteString(theme.PickerTitleStyle.Render("Select Pane"))
+	b.WriteString(m.th.PickerTitleStyle.Render("Select Pane"))
 	b.WriteString("\n")
 
 	for i, p := range m.panes {
-		// Number label: 1-9 for indices 0-8, 0 for index 9.
-		numKey := fmt.Sprintf("%d", i+1)
-		if i == 9 {
-			numKey = "0"
-		} else if i > 9 {
+		numKey := m.paneKeyLabel(i)
+		if numKey == "" {
 			numKey = " "
 		}
 
@@ -438,10 +1155,10 @@ func (m Model) renderPicker() string {
 		row := fmt.Sprintf("%s %s  %s", numKey, p.ShortTitle(), label)
 
 		if i == m.pickerCursor {
-			cursor := theme.PickerCursorStyle.Render("▸ ")
-			b.WriteString(cursor + theme.PickerActiveRowStyle.Render(row))
+			cursor := m.th.PickerCursorStyle.Render("▸ ")
+			b.WriteString(cursor + m.th.PickerActiveRowStyle.Render(row))
 		} else {
-			b.WriteString("  " + theme.PickerRowStyle.Render(row))
+			b.WriteString("  " + m.th.PickerRowStyle.Render(row))
 		}
 		b.WriteString("\n")
 	}
@@ -513,24 +1230,336 @@ func (m Model) pickerLabel(p pane.Pane) string {
 	return label
 }
 
+// ---------------------------------------------------------------------------
+// Theme picker overlay
+// ---------------------------------------------------------------------------
+
+// renderThemePicker renders the list of themes, marking the one in use.
+// Each name is drawn in its own accent color as a preview.
+func (m Model) renderThemePicker() string {
+	var b strings.Builder
+	b.WriteString(m.th.PickerTitleStyle.Render("Select Theme"))
+	b.WriteString("\n")
+
+	for i, t := range m.themes {
+		mark := " "
+		if t == m.th {
+			mark = "●"
+		}
+		row := fmt.Sprintf("%s %s  %s%s%s%s", mark, t.Name,
+			t.PassStyle.Render("■"), t.WarnStyle.Render("■"),
+			t.FailStyle.Render("■"), t.MutedStyle.Render("■"))
+
+		if i == m.themeCursor {
+			cursor := m.th.PickerCursorStyle.Render("▸ ")
+			b.WriteString(cursor + m.th.PickerActiveRowStyle.Render(row))
+		} else {
+			b.WriteString("  " + m.th.PickerRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// handleThemeKey handles key events when the theme picker is open.
+func (m Model) handleThemeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Theme):
+		m.showThemes = false
+		return m, nil
+
+	case key.Matches(msg, m.keys.Select):
+		m.setTheme(m.themes[m.themeCursor])
+		m.showThemes = false
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.themeCursor = (m.themeCursor + 1) % len(m.themes)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.themeCursor = (m.themeCursor - 1 + len(m.themes)) % len(m.themes)
+		return m, nil
+	}
+	return m, nil
+}
+
+// handleThemeMouse applies the clicked theme. Rows start at Y=2, below
+// the header bar and the picker title.
+func (m Model) handleThemeMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	row := msg.Y - 2
+	if row >= 0 && row < len(m.themes) {
+		m.setTheme(m.themes[row])
+	}
+	m.showThemes = false
+	return m, nil
+}
+
 // ---------------------------------------------------------------------------
 // Status bar
 // ---------------------------------------------------------------------------
 
-// renderStatusBar renders the bottom status bar.
+// renderStatusBar renders the bottom status bar, or the alert banner while
+// one is showing.
 func (m Model) renderStatusBar() string {
-	health := theme.PassStyle.Render("⚡ healthy")
+	if m.toast != "" {
+		text := pane.TruncateWithEllipsis(m.toast, m.width-2)
+		return m.th.StatusBarStyle.Width(m.width).Render(m.th.PassStyle.Bold(true).Render(text))
+	}
+	if m.banner != nil {
+		return m.renderBanner()
+	}
+
+	health := m.th.PassStyle.Render("⚡ healthy")
+	if n := m.alerts.Active(); n > 0 {
+		health = m.th.WarnStyle.Render(fmt.Sprintf("⚠ %d alerts", n))
+	}
 
 	age := "…"
 	if !m.lastRefresh.IsZero() {
 		age = pane.FormatAge(time.Since(m.lastRefresh))
 	}
-	refresh := theme.MutedStyle.Render("↻ " + age)
+	refresh := m.th.MutedStyle.Render("↻ " + age)
 
-	keys := theme.MutedStyle.Render("?=help  q=quit")
+	keys := m.th.MutedStyle.Render("?=help  q=quit")
 
 	bar := strings.Join([]string{health, refresh, keys}, "  |  ")
-	return theme.StatusBarStyle.Width(m.width).Render(bar)
+	return m.th.StatusBarStyle.Width(m.width).Render(bar)
+}
+
+// ---------------------------------------------------------------------------
+// Alerts
+// ---------------------------------------------------------------------------
+
+// raiseAlerts shows the most severe of events in the banner and returns a
+// command that expires it and, if any event asks for it, rings the bell.
+func (m *Model) raiseAlerts(events []alert.Event) tea.Cmd {
+	top := events[0]
+	bell := false
+	for _, e := range events {
+		if severityRank(e.Severity) > severityRank(top.Severity) {
+			top = e
+		}
+		bell = bell || e.Bell
+	}
+
+	m.banner = &top
+	m.bannerMore = len(events) - 1
+	m.bannerSeq++
+	seq := m.bannerSeq
+
+	cmds := []tea.Cmd{
+		tea.Tick(bannerDuration, func(time.Time) tea.Msg {
+			return bannerExpireMsg{seq: seq}
+		}),
+	}
+	if bell && m.out != nil {
+		cmds = append(cmds, bellCmd(m.out))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderBanner renders the current alert across the status bar row.
+func (m Model) renderBanner() string {
+	icon, style := "ℹ", m.th.AccentStyle
+	switch m.banner.Severity {
+	case "warn":
+		icon, style = "⚠", m.th.WarnStyle
+	case "fail":
+		icon, style = "✗", m.th.FailStyle
+	}
+
+	text := icon + " " + m.banner.Message
+	if m.bannerMore > 0 {
+		text += fmt.Sprintf("  (+%d more)", m.bannerMore)
+	}
+	text = pane.TruncateWithEllipsis(text, m.width-2)
+	return m.th.StatusBarStyle.Width(m.width).Render(style.Bold(true).Render(text))
+}
+
+// severityRank orders alert severities for picking the banner event.
+func severityRank(s string) int {
+	switch s {
+	case "fail":
+		return 2
+	case "warn":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Events
+// ---------------------------------------------------------------------------
+
+// renderTicker renders the most recent event on a single line.
+func (m Model) renderTicker() string {
+	e, ok := m.events.Latest()
+	if !ok {
+		return m.th.MutedStyle.Width(m.width).Render(
+			pane.TruncateWithEllipsis(" no events yet", m.width))
+	}
+	stamp := e.At.Format("15:04")
+	text := pane.TruncateWithEllipsis(e.Message, m.width-len(stamp)-4)
+	return lipgloss.NewStyle().Width(m.width).Render(fmt.Sprintf(" %s %s %s",
+		m.th.MutedStyle.Render(stamp), pane.EventStyle(m.th, e.Kind).Render("●"), text))
+}
+
+// followLink switches to the pane an event links to and, if the pane
+// supports it, moves its cursor to the linked entity.
+func (m *Model) followLink(link pane.EventLink) {
+	for i, p := range m.panes {
+		if p.ID() != link.Pane {
+			continue
+		}
+		m.activePane = i
+		if link.Pane == pane.PaneAgents {
+			// Focus leaves agent detail mode, so stop its polling.
+			m.detailAgent = nil
+		}
+		m.closeWitnessDetail()
+		if f, ok := p.(pane.Focuser); ok && link.Key != "" {
+			f.Focus(link.Key)
+		}
+		return
+	}
+}
+
+// closeWitnessDetail leaves the Witnesses pane's detail view and stops
+// polling it. A followed link leads away from it, even when it leads to
+// the Witnesses pane itself.
+func (m *Model) closeWitnessDetail() {
+	m.witnessRig = ""
+	for _, p := range m.panes {
+		if wp, ok := p.(*pane.WitnessPane); ok {
+			wp.CloseDetail()
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Clipboard
+// ---------------------------------------------------------------------------
+
+// yank copies candidate next of the active pane's yank list to the client
+// clipboard. Pressing yank again copies the following candidate, so one key
+// reaches an agent's issue, branch and each recent commit.
+func (m Model) yank(next int) (tea.Model, tea.Cmd) {
+	var ys []pane.Yank
+	if y, ok := m.panes[m.activePane].(pane.Yanker); ok {
+		ys = y.Yanks()
+	}
+	if len(ys) == 0 {
+		return m, m.showToast("nothing to copy here")
+	}
+	if m.out == nil {
+		return m, m.showToast("no clipboard in this session")
+	}
+
+	i := next % len(ys)
+	m.yankNext = i + 1
+	text := fmt.Sprintf("⧉ copied %s %s", ys[i].Label, ys[i].Value)
+	if len(ys) > 1 {
+		text += fmt.Sprintf("  (%s: %s)", keymap.Label(m.keys.Yank), ys[(i+1)%len(ys)].Label)
+	}
+	return m, tea.Batch(clipboardCmd(m.out, ys[i].Value), m.showToast(text))
+}
+
+// showToast replaces the status bar with text for toastDuration.
+func (m *Model) showToast(text string) tea.Cmd {
+	m.toast = text
+	m.toastSeq++
+	seq := m.toastSeq
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpireMsg{seq: seq}
+	})
+}
+
+// clipboardCmd sets the client's clipboard to s with an OSC 52 escape
+// sequence, which the SSH client's terminal applies locally.
+func clipboardCmd(w io.Writer, s string) tea.Cmd {
+	return func() tea.Msg {
+		seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(s)) + "\a"
+		_, _ = w.Write([]byte(seq))
+		return nil
+	}
+}
+
+// exportDoneMsg reports a finished export.
+type exportDoneMsg struct {
+	name string
+	path string // saved file; empty when copied to the clipboard
+	err  error
+}
+
+func (msg exportDoneMsg) toast() string {
+	switch {
+	case msg.err != nil:
+		return "export failed: " + msg.err.Error()
+	case msg.path != "":
+		return fmt.Sprintf("⧉ saved %s to %s", msg.name, msg.path)
+	default:
+		return fmt.Sprintf("⧉ copied %s as markdown", msg.name)
+	}
+}
+
+// export renders the active view as Markdown and copies it to the client
+// clipboard, or saves it under export.dir when toFile is set. The Dashboard
+// and Convoys panes export what they show; every other pane exports
+// today's digest, which is fetched in the background.
+func (m Model) export(toFile bool) (tea.Model, tea.Cmd) {
+	dir := m.config.Export.Dir
+	if toFile && dir == "" {
+		return m, m.showToast("set export.dir to save exports")
+	}
+	if !toFile && m.out == nil {
+		return m, m.showToast("no clipboard in this session")
+	}
+
+	now := time.Now()
+	var render func() (export.Doc, error)
+	var wait tea.Cmd
+	if e, ok := m.panes[m.activePane].(pane.Exporter); ok {
+		doc, ok := e.Export(now)
+		if !ok {
+			return m, m.showToast("nothing to export yet")
+		}
+		render = func() (export.Doc, error) { return doc, nil }
+	} else {
+		src := m.fetcher
+		render = func() (export.Doc, error) {
+			dg, err := export.FetchDigest(src, now, now)
+			return dg.Doc(), err
+		}
+		wait = m.showToast("building today's digest…")
+	}
+
+	out := m.out
+	return m, tea.Batch(wait, func() tea.Msg {
+		doc, err := render()
+		if err != nil {
+			return exportDoneMsg{err: err}
+		}
+		if toFile {
+			path, err := export.WriteFile(dir, doc, now)
+			return exportDoneMsg{name: doc.Name, path: path, err: err}
+		}
+		clipboardCmd(out, doc.Markdown)()
+		return exportDoneMsg{name: doc.Name}
+	})
+}
+
+// bellCmd writes the BEL character to the client terminal.
+func bellCmd(w io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		_, _ = w.Write([]byte("\a"))
+		return nil
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -538,7 +1567,7 @@ func (m Model) renderStatusBar() string {
 // ---------------------------------------------------------------------------
 
 // fetchStatusCmd fetches town status + sessions and returns a pane.StatusUpdateMsg.
-func fetchStatusCmd(f *data.Fetcher) tea.Cmd {
+func fetchStatusCmd(f data.Source) tea.Cmd {
 	return func() tea.Msg {
 		status, _ := f.FetchStatus()
 		sessions, _ := f.FetchSessions()
@@ -550,91 +1579,68 @@ func fetchStatusCmd(f *data.Fetcher) tea.Cmd {
 	}
 }
 
-// fetchAgentsCmd fetches enriched agent details and returns a pane.AgentUpdateMsg.
-func fetchAgentsCmd(f *data.Fetcher) tea.Cmd {
+// fetchReadyCmd fetches unblocked open issues and returns a pane.ReadyUpdateMsg.
+func fetchReadyCmd(f data.Source) tea.Cmd {
 	return func() tea.Msg {
-		details, err := f.FetchAgents()
-		agents := make([]pane.AgentInfo, len(details))
-		for i, d := range details {
-			agents[i] = pane.AgentInfo{
-				Name:       d.Name,
-				Rig:        d.Rig,
-				Role:       d.Role,
-				Status:     d.Status,
-				Age:        time.Duration(d.AgeSecs) * time.Second,
-				IssueID:    d.IssueID,
-				IssueTitle: d.IssueTitle,
-			}
-		}
-		return pane.AgentUpdateMsg{Agents: agents, Err: err}
+		issues, err := f.FetchReady()
+		return pane.ReadyUpdateMsg{Issues: issues, Err: err}
 	}
 }
 
-// fetchHistoryCmd fetches closed beads and all convoys for the history pane.
-func fetchHistoryCmd(f *data.Fetcher) tea.Cmd {
+// fetchRigsCmd fetches available rig names and returns a pane.RigListMsg.
+func fetchRigsCmd(f data.Source) tea.Cmd {
 	return func() tea.Msg {
-		beads, beadErr := f.FetchClosedBeads()
-		convoys, convoyErr := f.FetchAllConvoys()
-		var err error
-		if beadErr != nil {
-			err = beadErr
-		} else if convoyErr != nil {
-			err = convoyErr
-		}
-		return pane.HistoryUpdateMsg{
-			ClosedBeads: beads,
-			Convoys:     convoys,
-			Err:         err,
-		}
+		rigs, err := f.FetchRigs()
+		return pane.RigListMsg{Rigs: rigs, Err: err}
 	}
 }
 
-// fetchRigsCmd fetches available rig names and returns a pane.RigListMsg.
-func fetchRigsCmd(f *data.Fetcher) tea.Cmd {
+// refreshRefineryCmd is feed.Refinery outside the poll cycle, past any
+// cached result, to show the effect of a queue action.
+func refreshRefineryCmd(f data.Source) tea.Cmd {
+	fetch := feed.Refinery(f)
 	return func() tea.Msg {
-		rigs, err := f.FetchRigs()
-		return pane.RigListMsg{Rigs: rigs, Err: err}
+		invalidate(f, "refinery")
+		msg := fetch().(pane.RefineryUpdateMsg)
+		msg.Once = true
+		return msg
 	}
 }
 
-// fetchMailCmd fetches mail messages and returns a pane.MailUpdateMsg.
-func fetchMailCmd(f *data.Fetcher) tea.Cmd {
+// mrOutputLines is how much refinery scrollback an MR's detail view
+// searches for why it failed.
+const mrOutputLines = 500
+
+// fetchMROutputCmd captures the rig's refinery session output for an MR's
+// detail view.
+func fetchMROutputCmd(f data.Source, msg pane.MRSelectedMsg) tea.Cmd {
 	return func() tea.Msg {
-		messages, err := f.FetchMail()
-		infos := make([]pane.MailInfo, len(messages))
-		for i, m := range messages {
-			ts, _ := time.Parse(time.RFC3339Nano, m.Timestamp)
-			infos[i] = pane.MailInfo{
-				ID:        m.ID,
-				From:      m.From,
-				To:        m.To,
-				Subject:   m.Subject,
-				Body:      m.Body,
-				Timestamp: ts,
-				Read:      m.Read,
-				Priority:  m.Priority,
-				Type:      m.Type,
-				ThreadID:  m.ThreadID,
-			}
-		}
-		return pane.MailUpdateMsg{Messages: infos, Err: err}
+		output := f.FetchAgentOutput(msg.Rig, "refinery", mrOutputLines)
+		return pane.MROutputMsg{MR: msg.MR.ID, Output: output}
 	}
 }
 
-// fetchRefineryCmd fetches refinery merge queue status and returns a pane.RefineryUpdateMsg.
-func fetchRefineryCmd(f *data.Fetcher) tea.Cmd {
+// witnessOutputLines is how much witness scrollback the witness detail
+// view captures.
+const witnessOutputLines = 20
+
+// fetchWitnessDetailCmd fetches a rig's polecats and its witness session's
+// recent output for the witness detail view.
+func fetchWitnessDetailCmd(f data.Source, rig string) tea.Cmd {
 	return func() tea.Msg {
-		statuses, err := f.FetchRefineryStatus()
-		return pane.RefineryUpdateMsg{Statuses: statuses, Err: err}
+		polecats, err := f.FetchRigPolecats(rig)
+		output := f.FetchAgentOutput(rig, "witness", witnessOutputLines)
+		return pane.WitnessDetailMsg{Rig: rig, Polecats: polecats, Output: output, Err: err}
 	}
 }
 
 // fetchAgentDetailCmd fetches git branch, commits, and tmux output for a specific agent.
-func fetchAgentDetailCmd(f *data.Fetcher, rig, name string) tea.Cmd {
+func fetchAgentDetailCmd(f data.Source, a pane.AgentInfo) tea.Cmd {
+	rig, name, session := a.Rig, a.Name, a.Session()
 	return func() tea.Msg {
 		branch := f.FetchAgentBranch(rig, name)
 		commits := f.FetchAgentCommits(rig, name, 5)
-		output := f.FetchAgentOutput(rig, name, 15)
+		output := f.FetchAgentOutput(rig, session, 15)
 		return pane.AgentDetailDataMsg{
 			Name:    name,
 			Branch:  branch,
@@ -645,7 +1651,7 @@ func fetchAgentDetailCmd(f *data.Fetcher, rig, name string) tea.Cmd {
 }
 
 // fetchResourcesCmd fetches session resource data and returns a pane.ResourceUpdateMsg.
-func fetchResourcesCmd(f *data.Fetcher) tea.Cmd {
+func fetchResourcesCmd(f data.Source) tea.Cmd {
 	return func() tea.Msg {
 		resources, err := f.FetchResources()
 		return pane.ResourceUpdateMsg{
@@ -655,69 +1661,59 @@ func fetchResourcesCmd(f *data.Fetcher) tea.Cmd {
 	}
 }
 
-// fetchConvoysCmd fetches convoy data with progress and returns a pane.ConvoyUpdateMsg.
-func fetchConvoysCmd(f *data.Fetcher) tea.Cmd {
+// refreshConvoysCmd is feed.Convoys outside the poll cycle, past any
+// cached result, to show what gt convoy check closed.
+func refreshConvoysCmd(f data.Source) tea.Cmd {
 	return func() tea.Msg {
-		convoys, err := f.FetchConvoys()
-		if err != nil {
-			return pane.ConvoyUpdateMsg{}
-		}
-		progress := make(map[string][2]int)
-		issueMap := make(map[string][]data.IssueDetail)
-		for _, c := range convoys {
-			issues, err := f.FetchTrackedIssues(c.ID)
-			if err != nil {
-				continue
-			}
-			done := 0
-			for _, iss := range issues {
-				if iss.Status == "COMPLETED" || iss.Status == "CLOSED" {
-					done++
-				}
-			}
-			progress[c.ID] = [2]int{done, len(issues)}
-			issueMap[c.ID] = issues
-		}
-		return pane.ConvoyUpdateMsg{
-			Convoys:  convoys,
-			Progress: progress,
-			Issues:   issueMap,
-		}
+		invalidate(f, "convoys", "stranded")
+		msg, _ := feed.FetchConvoys(f)
+		msg.Once = true
+		return msg
+	}
+}
+
+// invalidate drops f's cached results for keys, if f caches.
+func invalidate(f data.Source, keys ...string) {
+	if c, ok := f.(*data.Cache); ok {
+		c.Invalidate(keys...)
 	}
 }
 
-// fetchWitnessesCmd fetches witness heartbeat data and returns a pane.WitnessUpdateMsg.
-func fetchWitnessesCmd(f *data.Fetcher) tea.Cmd {
+// fetchServicesCmd fetches the state of the Mayor and the Deacon and
+// returns a pane.ServicesUpdateMsg.
+func fetchServicesCmd(f data.Source) tea.Cmd {
 	return func() tea.Msg {
-		details, err := f.FetchWitnesses()
+		statuses, err := f.FetchServices()
 		now := time.Now()
-		witnesses := make([]pane.WitnessInfo, len(details))
-		for i, d := range details {
-			var lastHeartbeat time.Duration
-			if d.LastHeartbeat > 0 {
-				lastHeartbeat = now.Sub(time.Unix(d.LastHeartbeat, 0))
+		services := make([]pane.ServiceInfo, len(statuses))
+		for i, s := range statuses {
+			info := pane.ServiceInfo{
+				Name:       s.Name,
+				Running:    s.Running,
+				Status:     s.Status,
+				HasSession: s.Session != "",
+				Err:        s.StatusErr,
 			}
-			var uptime time.Duration
-			if d.SessionCreated > 0 {
-				uptime = now.Sub(time.Unix(d.SessionCreated, 0))
+			if s.Created > 0 {
+				info.Uptime = now.Sub(time.Unix(s.Created, 0))
 			}
-			witnesses[i] = pane.WitnessInfo{
-				Rig:           d.Rig,
-				Status:        d.Status,
-				LastHeartbeat: lastHeartbeat,
-				PolecatCount:  d.PolecatCount,
-				Uptime:        uptime,
-				HasSession:    d.HasSession,
+			if s.Activity > 0 {
+				info.LastActive = now.Sub(time.Unix(s.Activity, 0))
 			}
+			services[i] = info
 		}
-		return pane.WitnessUpdateMsg{Witnesses: witnesses, Err: err}
+		return pane.ServicesUpdateMsg{Services: services, Err: err}
 	}
 }
 
-// fetchPRsCmd fetches open PRs and returns a pane.PRUpdateMsg.
-func fetchPRsCmd(f *data.Fetcher) tea.Cmd {
+// refreshServicesCmd is fetchServicesCmd outside the poll cycle, past any
+// cached result.
+func refreshServicesCmd(f data.Source) tea.Cmd {
+	fetch := fetchServicesCmd(f)
 	return func() tea.Msg {
-		prs, err := f.FetchPullRequests()
-		return pane.PRUpdateMsg{PRs: prs, Err: err}
+		invalidate(f, "services")
+		msg := fetch().(pane.ServicesUpdateMsg)
+		msg.Once = true
+		return msg
 	}
 }
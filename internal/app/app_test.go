@@ -1,6 +1,11 @@
 package app
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +15,8 @@ import (
 	"github.com/tnguyen21/kestral-tui/internal/config"
 	"github.com/tnguyen21/kestral-tui/internal/data"
 	"github.com/tnguyen21/kestral-tui/internal/pane"
+	"github.com/tnguyen21/kestral-tui/internal/session"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 func testModel() Model {
@@ -29,8 +36,8 @@ func sized(m Model, w, h int) Model {
 func TestNew(t *testing.T) {
 	m := testModel()
 
-	if len(m.panes) != 10 {
-		t.Fatalf("expected 10 panes, got %d", len(m.panes))
+	if len(m.panes) != 13 {
+		t.Fatalf("expected 13 panes, got %d", len(m.panes))
 	}
 	if m.panes[0].ID() != pane.PaneDashboard {
 		t.Errorf("pane 0 should be Dashboard, got %d", m.panes[0].ID())
@@ -62,6 +69,15 @@ func TestNew(t *testing.T) {
 	if m.panes[9].ID() != pane.PaneWitness {
 		t.Errorf("pane 9 should be Witness, got %d", m.panes[9].ID())
 	}
+	if m.panes[10].ID() != pane.PaneEvents {
+		t.Errorf("pane 10 should be Events, got %d", m.panes[10].ID())
+	}
+	if m.panes[11].ID() != pane.PaneDispatch {
+		t.Errorf("pane 11 should be Dispatch, got %d", m.panes[11].ID())
+	}
+	if m.panes[12].ID() != pane.PaneServices {
+		t.Errorf("pane 12 should be Services, got %d", m.panes[12].ID())
+	}
 	if m.activePane != 0 {
 		t.Errorf("activePane should start at 0, got %d", m.activePane)
 	}
@@ -191,11 +207,11 @@ func TestShiftTabKey(t *testing.T) {
 	m := testModel()
 	m = sized(m, 80, 24)
 
-	// Shift+tab wraps backward: 0 -> 9 (last pane)
+	// Shift+tab wraps backward: 0 -> 12 (last pane)
 	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
 	m = newM.(Model)
-	if m.activePane != 9 {
-		t.Errorf("shift+tab from 0: activePane = %d, want 9", m.activePane)
+	if m.activePane != 12 {
+		t.Errorf("shift+tab from 0: activePane = %d, want 12", m.activePane)
 	}
 }
 
@@ -552,6 +568,876 @@ func TestPRTickTriggersCmd(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Alerts
+// ---------------------------------------------------------------------------
+
+func TestAlertShowsBanner(t *testing.T) {
+	m := testModel()
+	m = sized(m, 100, 24)
+
+	msg := pane.AgentUpdateMsg{
+		Agents: []pane.AgentInfo{
+			{Name: "quartz", Rig: "kt", Role: "polecat", Status: "stuck", Age: time.Hour},
+		},
+	}
+	newM, _ := m.Update(msg)
+	m = newM.(Model)
+
+	if m.banner == nil {
+		t.Fatal("stuck agent should raise an alert banner")
+	}
+	if !containsText(m.renderStatusBar(), "kt/quartz") {
+		t.Error("status bar should show the alert banner")
+	}
+
+	// Expiry for an older banner is ignored.
+	newM, _ = m.Update(bannerExpireMsg{seq: m.bannerSeq - 1})
+	m = newM.(Model)
+	if m.banner == nil {
+		t.Error("stale expiry should not clear the banner")
+	}
+
+	newM, _ = m.Update(bannerExpireMsg{seq: m.bannerSeq})
+	m = newM.(Model)
+	if m.banner != nil {
+		t.Error("banner should clear on expiry")
+	}
+	if !containsText(m.renderStatusBar(), "1 alerts") {
+		t.Error("status bar should show the active alert count")
+	}
+}
+
+func TestAlertBell(t *testing.T) {
+	cfg := config.Default()
+	cfg.Alerts.Rules = []config.AlertRule{
+		{Name: "stuck", Kind: config.AlertAgentStatus, Status: "stuck", Bell: true},
+	}
+	var out bytes.Buffer
+	m := New(cfg).WithOutput(&out)
+	m = sized(m, 80, 24)
+
+	_, cmd := m.Update(pane.AgentUpdateMsg{
+		Agents: []pane.AgentInfo{{Name: "quartz", Rig: "kt", Status: "stuck"}},
+	})
+	runCmds(cmd)
+
+	if out.String() != "\a" {
+		t.Errorf("expected bell to be written, got %q", out.String())
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Events
+// ---------------------------------------------------------------------------
+
+func TestEventTickerShowsLatestChange(t *testing.T) {
+	m := testModel()
+	m = sized(m, 100, 24)
+
+	if !containsText(m.renderTicker(), "no events yet") {
+		t.Error("ticker should show a placeholder before any events")
+	}
+
+	// The first snapshot is the baseline; the second produces an event.
+	for _, status := range []string{"working", "stuck"} {
+		newM, _ := m.Update(pane.AgentUpdateMsg{
+			Agents: []pane.AgentInfo{{Name: "quartz", Rig: "kt", Status: status}},
+		})
+		m = newM.(Model)
+	}
+
+	if !containsText(m.renderTicker(), "kt/quartz went stuck") {
+		t.Errorf("ticker should show the latest event, got %q", m.renderTicker())
+	}
+	if !containsText(m.View(), "kt/quartz went stuck") {
+		t.Error("View should include the ticker line")
+	}
+	if lines := strings.Count(m.View(), "\n") + 1; lines != 24 {
+		t.Errorf("View should fill the terminal height, got %d lines", lines)
+	}
+	if m.events.Badge() != 1 {
+		t.Errorf("Events pane badge = %d, want 1", m.events.Badge())
+	}
+}
+
+func TestEventSelectedFollowsLink(t *testing.T) {
+	m := testModel()
+	m = sized(m, 100, 24)
+
+	newM, _ := m.Update(pane.AgentUpdateMsg{
+		Agents: []pane.AgentInfo{
+			{Name: "amber", Rig: "kt", Status: "working"},
+			{Name: "quartz", Rig: "kt", Status: "stuck"},
+		},
+	})
+	m = newM.(Model)
+
+	newM, _ = m.Update(pane.EventSelectedMsg{
+		Link: pane.EventLink{Pane: pane.PaneAgents, Key: "kt/quartz"},
+	})
+	m = newM.(Model)
+
+	if m.panes[m.activePane].ID() != pane.PaneAgents {
+		t.Fatalf("active pane = %d, want Agents", m.panes[m.activePane].ID())
+	}
+	if !containsText(m.View(), "quartz") {
+		t.Error("Agents pane should be shown after following the link")
+	}
+}
+
+func TestNudgeFromWitnessOpensAgentDetail(t *testing.T) {
+	m := sized(testModel(), 100, 30)
+	newM, _ := m.Update(pane.AgentUpdateMsg{
+		Agents: []pane.AgentInfo{{Name: "witness", Rig: "kt", Role: "witness"}},
+	})
+	m = newM.(Model)
+
+	newM, _ = m.Update(pane.NudgeOpenMsg{Agent: "kt/witness"})
+	m = newM.(Model)
+	if m.panes[m.activePane].ID() != pane.PaneAgents || !m.inputPane() {
+		t.Fatal("nudging should open the composer in the Agents pane")
+	}
+
+	// Global keys are typed into the composer.
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = newM.(Model)
+	for _, msg := range collectMsgs(cmd) {
+		if _, ok := msg.(tea.QuitMsg); ok {
+			t.Fatal("q should not quit while composing")
+		}
+	}
+
+	newM, _ = m.Update(pane.NudgeSentMsg{Rig: "kt", Session: "witness", Target: "kt/witness", Text: "q"})
+	m = newM.(Model)
+	if m.nudge == nil || m.nudge.target != "kt/witness" {
+		t.Fatal("a sent nudge should be watched for a reply")
+	}
+	newM, _ = m.Update(pane.NudgeOutputMsg{Target: "kt/witness", Done: true})
+	if newM.(Model).nudge != nil {
+		t.Error("the last capture should end the watch")
+	}
+}
+
+func TestNudgeUnknownAgentShowsToast(t *testing.T) {
+	m := sized(testModel(), 100, 30)
+	newM, _ := m.Update(pane.NudgeOpenMsg{Agent: "kt/witness"})
+	if !containsText(newM.(Model).View(), "no session for kt/witness") {
+		t.Error("nudging an agent that isn't running should say so")
+	}
+}
+
+func TestServicesRefreshDoesNotSchedulePoll(t *testing.T) {
+	m := testModel()
+	if _, cmd := m.Update(pane.ServicesUpdateMsg{}); cmd == nil {
+		t.Error("a services poll should schedule the next one")
+	}
+	if _, cmd := m.Update(pane.ServicesUpdateMsg{Once: true}); cmd != nil {
+		t.Error("a refresh after an action should not start a second poll cycle")
+	}
+	if _, cmd := m.Update(pane.ServiceStepMsg{}); cmd == nil {
+		t.Error("a finished step should refresh the services")
+	}
+}
+
+func TestConvoyCheckRefreshesOnce(t *testing.T) {
+	m := testModel()
+	if _, cmd := m.Update(pane.ConvoyCheckMsg{}); cmd == nil {
+		t.Error("a finished convoy check should refresh the convoys")
+	}
+	if _, cmd := m.Update(pane.ConvoyCheckMsg{Err: errors.New("boom")}); cmd != nil {
+		t.Error("a failed convoy check has nothing to refresh")
+	}
+	if _, cmd := m.Update(pane.ConvoyUpdateMsg{Once: true}); cmd != nil {
+		t.Error("the refresh should not start a second poll cycle")
+	}
+}
+
+func TestMRActionRefreshesOnce(t *testing.T) {
+	m := testModel()
+	action := pane.MRAction{Kind: pane.MRSkip, Rig: "kt", MR: "kt-mr1"}
+	if _, cmd := m.Update(pane.MRActionMsg{Action: action}); cmd == nil {
+		t.Error("a queue action should refresh the refinery")
+	}
+	if _, cmd := m.Update(pane.RefineryUpdateMsg{Once: true}); cmd != nil {
+		t.Error("the refresh should not start a second poll cycle")
+	}
+}
+
+func TestWitnessDetailPollsUntilDeselected(t *testing.T) {
+	m := testModel()
+	updated, _ := m.Update(pane.WitnessSelectedMsg{Rig: "gastown"})
+	m = updated.(Model)
+	if _, cmd := m.Update(pane.WitnessDetailMsg{Rig: "gastown"}); cmd == nil {
+		t.Error("witness detail should schedule its next poll while open")
+	}
+	updated, _ = m.Update(pane.WitnessDeselectedMsg{})
+	m = updated.(Model)
+	if _, cmd := m.Update(data.WitnessDetailTickMsg{}); cmd != nil {
+		t.Error("witness detail should stop polling once closed")
+	}
+}
+
+func TestFollowedLinkStopsWitnessDetail(t *testing.T) {
+	m := testModel()
+	updated, _ := m.Update(pane.WitnessSelectedMsg{Rig: "gastown"})
+	m = updated.(Model)
+	updated, _ = m.Update(pane.EventSelectedMsg{Link: pane.EventLink{Pane: pane.PaneAgents, Key: "gastown/toast"}})
+	m = updated.(Model)
+	if m.witnessRig != "" {
+		t.Errorf("witnessRig = %q, want it cleared by the link", m.witnessRig)
+	}
+	if _, cmd := m.Update(data.WitnessDetailTickMsg{}); cmd != nil {
+		t.Error("witness detail should stop polling once a link leads away")
+	}
+}
+
+func TestTerminalWriterKeepsWritesWhole(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewTerminalWriter(&buf)
+	frame := strings.Repeat("x", 4096)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 50; i++ {
+			out.Write([]byte(frame))
+		}
+		close(done)
+	}()
+	for i := 0; i < 50; i++ {
+		runCmds(bellCmd(out))
+	}
+	<-done
+	for _, chunk := range strings.Split(buf.String(), "\a") {
+		if len(chunk)%len(frame) != 0 {
+			t.Fatalf("a bell landed inside a frame: chunk of %d bytes", len(chunk))
+		}
+	}
+}
+
+// runCmds executes cmd and any batched commands, skipping timers.
+func runCmds(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				runCmds(c)
+			}
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Clipboard
+// ---------------------------------------------------------------------------
+
+func TestYankCopiesWithOSC52(t *testing.T) {
+	var out bytes.Buffer
+	m := sized(testModel().WithOutput(&out), 100, 24)
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = newM.(Model)
+	newM, _ = m.Update(pane.AgentUpdateMsg{Agents: []pane.AgentInfo{
+		{Name: "amber", Rig: "kt", IssueID: "kt-42"},
+	}})
+	m = newM.(Model)
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = newM.(Model)
+	runCmds(cmd)
+	if want := "\x1b]52;c;a3QtNDI=\a"; out.String() != want {
+		t.Errorf("wrote %q, want %q", out.String(), want)
+	}
+	if !containsText(m.renderStatusBar(), "copied issue ID kt-42") {
+		t.Errorf("toast missing from status bar: %q", stripANSI(m.renderStatusBar()))
+	}
+
+	// Yanking again copies the next candidate, the agent address.
+	out.Reset()
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = newM.(Model)
+	runCmds(cmd)
+	if !containsText(m.renderStatusBar(), "copied agent kt/amber") {
+		t.Errorf("second yank should copy the agent, got %q", stripANSI(m.renderStatusBar()))
+	}
+
+	newM, _ = m.Update(toastExpireMsg{seq: m.toastSeq})
+	if containsText(newM.(Model).renderStatusBar(), "copied") {
+		t.Error("toast should clear when it expires")
+	}
+}
+
+func TestYankWithNothingFocused(t *testing.T) {
+	var out bytes.Buffer
+	m := sized(testModel().WithOutput(&out), 100, 24)
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	runCmds(cmd)
+	if out.Len() != 0 || !containsText(newM.(Model).renderStatusBar(), "nothing to copy") {
+		t.Error("the dashboard has nothing to yank")
+	}
+}
+
+// collectMsgs runs cmd, expanding batches, and returns the messages it
+// produced.
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var msgs []tea.Msg
+			for _, c := range batch {
+				msgs = append(msgs, collectMsgs(c)...)
+			}
+			return msgs
+		}
+		return []tea.Msg{msg}
+	case <-time.After(50 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestExportDashboardToFile(t *testing.T) {
+	cfg := config.Default()
+	cfg.Export.Dir = t.TempDir()
+	m := sized(New(cfg), 100, 24)
+	newM, _ := m.Update(pane.StatusUpdateMsg{Status: &data.TownStatus{Agents: []data.AgentInfo{
+		{Name: "mayor", Running: true},
+	}}})
+	m = newM.(Model)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	var done exportDoneMsg
+	for _, msg := range collectMsgs(cmd) {
+		if d, ok := msg.(exportDoneMsg); ok {
+			done = d
+		}
+	}
+	if done.err != nil || done.path == "" {
+		t.Fatalf("export = %+v, want a saved file", done)
+	}
+	body, err := os.ReadFile(done.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "| mayor | active |") {
+		t.Errorf("saved dashboard missing agent row:\n%s", body)
+	}
+
+	newM, _ = m.Update(done)
+	if !containsText(newM.(Model).renderStatusBar(), "saved dashboard") {
+		t.Errorf("toast missing from status bar: %q", stripANSI(newM.(Model).renderStatusBar()))
+	}
+}
+
+func TestExportCopiesConvoy(t *testing.T) {
+	var out bytes.Buffer
+	m := sized(testModel().WithOutput(&out), 100, 24)
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	m = newM.(Model)
+	newM, _ = m.Update(pane.ConvoyUpdateMsg{
+		Convoys:  []data.ConvoyInfo{{ID: "kt-c1", Title: "Auth", Status: "open"}},
+		Progress: map[string][2]int{"kt-c1": {1, 2}},
+		Issues: map[string][]data.IssueDetail{"kt-c1": {
+			{ID: "kt-1", Title: "Login", Status: "CLOSED"},
+			{ID: "kt-2", Title: "Logout", Status: "OPEN"},
+		}},
+	})
+	m = newM.(Model)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	runCmds(cmd)
+	seq := strings.TrimSuffix(strings.TrimPrefix(out.String(), "\x1b]52;c;"), "\a")
+	md, err := base64.StdEncoding.DecodeString(seq)
+	if err != nil {
+		t.Fatalf("clipboard write %q is not OSC 52: %v", out.String(), err)
+	}
+	if !strings.Contains(string(md), "## Convoy: Auth (`kt-c1`)") || !strings.Contains(string(md), "1/2 (50%)") {
+		t.Errorf("copied markdown:\n%s", md)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Keybindings
+// ---------------------------------------------------------------------------
+
+func TestRemappedKeybindings(t *testing.T) {
+	cfg := config.Default()
+	cfg.Keybindings = map[string]map[string][]string{
+		"global":  {"help": {"H"}},
+		"history": {"filter_date": {"d"}},
+	}
+	m := sized(New(cfg), 120, 30)
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = newM.(Model)
+	if m.showHelp {
+		t.Fatal("? should no longer open help once remapped")
+	}
+
+	// Switch to History (pane 7) and open help.
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("7")})
+	m = newM.(Model)
+	newM, _ = m.Update(pane.HistoryUpdateMsg{ClosedBeads: []data.ClosedBeadInfo{
+		{ID: "kt-1", Title: "Fix", ClosedAt: "2026-01-02T10:00:00Z"},
+	}})
+	m = newM.(Model)
+	if containsText(m.View(), "f=date") || !containsText(m.View(), "d=date") {
+		t.Error("History footer should show the remapped filter key")
+	}
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = newM.(Model)
+	if !m.showHelp {
+		t.Fatal("H should open help")
+	}
+	v := m.View()
+	if !containsText(v, "date filter") || !containsText(v, "H help") {
+		t.Error("help should list the active pane's keys and the remapped help key")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Split view
+// ---------------------------------------------------------------------------
+
+func splitModel(mode string, panes ...string) Model {
+	cfg := config.Default()
+	cfg.Layout = config.Layout{Mode: mode, Panes: panes}
+	return New(cfg)
+}
+
+func TestSplitViewRendersRegions(t *testing.T) {
+	m := sized(splitModel("split", "Agents", "Refinery"), 120, 30)
+
+	if m.panes[m.activePane].Title() != "Agents" {
+		t.Fatalf("first configured pane should be active, got %s", m.panes[m.activePane].Title())
+	}
+	view := m.View()
+	if !containsText(view, "▸ 🤖 Agents") || !containsText(view, "Refinery") {
+		t.Error("split view should caption both panes and mark the focused one")
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 30 {
+		t.Errorf("view has %d lines, want 30", lines)
+	}
+}
+
+func TestSplitViewFallsBackWhenNarrow(t *testing.T) {
+	m := sized(splitModel("split"), 80, 24)
+	if n := len(m.layoutRegions()); n != 1 {
+		t.Errorf("80 columns should show one pane, got %d regions", n)
+	}
+}
+
+func TestSplitFocusKey(t *testing.T) {
+	m := sized(splitModel("grid", "Agents", "Refinery", "PRs", "Convoys"), 120, 30)
+
+	for _, want := range []string{"Refinery", "PRs", "Convoys", "Agents"} {
+		newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+		m = newM.(Model)
+		if got := m.panes[m.activePane].Title(); got != want {
+			t.Errorf("after w: active = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestSplitPaneSwitchSwapsRegions(t *testing.T) {
+	m := sized(splitModel("split", "Agents", "Refinery"), 120, 30)
+
+	// Selecting the pane shown on the right swaps it into the focused region.
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	m = newM.(Model)
+	if m.panes[m.regions[0]].Title() != "Refinery" || m.panes[m.regions[1]].Title() != "Agents" {
+		t.Errorf("regions = %v, want Refinery and Agents swapped", m.regions)
+	}
+}
+
+func TestSplitMouseFocusesRegion(t *testing.T) {
+	m := sized(splitModel("split", "Agents", "Refinery"), 120, 30)
+
+	newM, _ := m.Update(tea.MouseMsg{X: 100, Y: 5, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	m = newM.(Model)
+	if m.focus != 1 || m.panes[m.activePane].Title() != "Refinery" {
+		t.Errorf("clicking the right region should focus it, focus = %d", m.focus)
+	}
+}
+
+func TestLayoutKeyCycles(t *testing.T) {
+	m := sized(testModel(), 120, 30)
+	for _, want := range []int{2, 4, 1} {
+		newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+		m = newM.(Model)
+		if n := len(m.layoutRegions()); n != want {
+			t.Errorf("regions = %d, want %d", n, want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Enabled panes
+// ---------------------------------------------------------------------------
+
+func panesModel(refs ...config.PaneRef) Model {
+	cfg := config.Default()
+	cfg.Panes = refs
+	return New(cfg)
+}
+
+func TestPaneTitlesMatchPanes(t *testing.T) {
+	var titles []string
+	for _, p := range New(config.Default()).panes {
+		titles = append(titles, p.Title())
+	}
+	if strings.Join(titles, ",") != strings.Join(config.PaneTitles, ",") {
+		t.Errorf("panes = %v, config.PaneTitles = %v", titles, config.PaneTitles)
+	}
+}
+
+func TestEnabledPanesOrderAndKeys(t *testing.T) {
+	m := sized(panesModel(
+		config.PaneRef{Name: "witnesses"},
+		config.PaneRef{Name: "Agents"},
+		config.PaneRef{Name: "Mail", Key: "1"},
+		config.PaneRef{Name: "Nonexistent"},
+	), 80, 24)
+
+	var titles []string
+	for _, p := range m.panes {
+		titles = append(titles, p.Title())
+	}
+	if strings.Join(titles, ",") != "Witnesses,Agents,Mail" {
+		t.Fatalf("panes = %v", titles)
+	}
+
+	// Mail is pinned to 1, so the others take 2 and 3 in order.
+	for key, want := range map[string]string{"1": "Mail", "2": "Witnesses", "3": "Agents"} {
+		newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = newM.(Model)
+		if got := m.panes[m.activePane].Title(); got != want {
+			t.Errorf("key %s: active = %s, want %s", key, got, want)
+		}
+	}
+	before := m.activePane
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	if newM.(Model).activePane != before {
+		t.Error("an unused number key should do nothing")
+	}
+}
+
+func TestPinnedKeyReachesEleventhPane(t *testing.T) {
+	var refs []config.PaneRef
+	for _, p := range testModel().panes {
+		refs = append(refs, config.PaneRef{Name: p.Title()})
+	}
+	refs[10].Key = "0" // Events, otherwise beyond the ten number keys
+	m := panesModel(refs...)
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0")})
+	m = newM.(Model)
+	if got := m.panes[m.activePane].Title(); got != "Events" {
+		t.Errorf("0 selects %s, want Events", got)
+	}
+	if m.paneKeyLabel(9) != "" {
+		t.Error("Witnesses gave up 0 and should have no number key")
+	}
+}
+
+func TestHiddenPanesAreNotPolled(t *testing.T) {
+	batch, ok := panesModel(config.PaneRef{Name: "Agents"}).Init()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Init should return a batch")
+	}
+	if len(batch) != 2 {
+		t.Errorf("Agents alone should poll status and agents, got %d fetches", len(batch))
+	}
+
+	all, _ := testModel().Init()().(tea.BatchMsg)
+	if len(all) != len(polls)+1 {
+		t.Errorf("all panes should poll every feed plus the rig list, got %d", len(all))
+	}
+}
+
+func TestSplitNeedsEnoughPanes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Panes = []config.PaneRef{{Name: "Agents"}, {Name: "PRs"}}
+	cfg.Layout.Mode = "grid"
+	m := sized(New(cfg), 120, 30)
+	if n := len(m.layoutRegions()); n != 2 {
+		t.Errorf("grid with two panes should fall back to split, got %d regions", n)
+	}
+	_ = m.View()
+}
+
+// ---------------------------------------------------------------------------
+// Themes
+// ---------------------------------------------------------------------------
+
+func TestNewUsesConfiguredTheme(t *testing.T) {
+	cfg := config.Default()
+	cfg.Theme = "nord"
+	cfg.Themes = map[string]theme.Colors{"nord": {Accent: "#88c0d0"}}
+	m := New(cfg)
+
+	if m.th.Name != "nord" {
+		t.Fatalf("theme = %s, want nord", m.th.Name)
+	}
+	if len(m.themes) != len(theme.Builtins())+1 {
+		t.Errorf("picker should list built-ins plus nord, got %d themes", len(m.themes))
+	}
+}
+
+func TestThemePickerSwitchesSessionTheme(t *testing.T) {
+	var last session.State
+	m := sized(testModel().WithSession(session.State{}, func(s session.State) { last = s }), 80, 24)
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	m = newM.(Model)
+	if !m.showThemes || !containsText(m.View(), "Select Theme") {
+		t.Fatal("T should open the theme picker")
+	}
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = newM.(Model)
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newM.(Model)
+
+	if m.showThemes || m.th.Name != "dracula" {
+		t.Fatalf("enter should apply dracula and close the picker, theme = %s", m.th.Name)
+	}
+	if last.Theme != "dracula" {
+		t.Errorf("saved theme = %q, want dracula", last.Theme)
+	}
+	if m.help.Styles.FullKey.GetForeground() != m.th.Palette.Accent {
+		t.Error("help view should follow the theme")
+	}
+}
+
+func TestThemePickerEscKeepsTheme(t *testing.T) {
+	m := sized(testModel(), 80, 24)
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("T")},
+		{Type: tea.KeyRunes, Runes: []rune("j")},
+		{Type: tea.KeyEsc},
+	} {
+		newM, _ := m.Update(k)
+		m = newM.(Model)
+	}
+	if m.showThemes || m.th.Name != theme.DefaultName {
+		t.Errorf("esc should close the picker without switching, theme = %s", m.th.Name)
+	}
+}
+
+func TestWithSessionRestoresTheme(t *testing.T) {
+	m := testModel().WithSession(session.State{Theme: "monochrome"}, nil)
+	if m.th.Name != "monochrome" {
+		t.Errorf("theme = %s, want monochrome", m.th.Name)
+	}
+
+	// A theme since removed from the config falls back to the default.
+	m = testModel().WithSession(session.State{Theme: "gone"}, nil)
+	if m.th.Name != theme.DefaultName {
+		t.Errorf("theme = %s, want %s", m.th.Name, theme.DefaultName)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Towns
+// ---------------------------------------------------------------------------
+
+func townsModel() Model {
+	cfg := config.Default()
+	cfg.Towns = []config.Town{{Name: "alpha", Root: "/a"}, {Name: "beta", Root: "/b"}}
+	return sized(New(cfg), 100, 24)
+}
+
+func TestTownPickerSwitchesTown(t *testing.T) {
+	m := townsModel()
+	if !containsText(m.renderHeaderBar(), "alpha") {
+		t.Error("header should name the current town")
+	}
+	alphaDash := m.panes[0]
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m = newM.(Model)
+	if !m.showTowns {
+		t.Fatal("g should open the town picker")
+	}
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	newM, _ = newM.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newM.(Model)
+
+	if m.showTowns || m.town != 1 {
+		t.Fatalf("enter should switch to beta, town = %d", m.town)
+	}
+	if m.config.TownRoot != "/b" || m.panes[0] == alphaDash {
+		t.Error("beta should have its own config and panes")
+	}
+	if !containsText(m.renderHeaderBar(), "beta") {
+		t.Error("header should name beta after switching")
+	}
+}
+
+func TestTownSwitchDropsStalePolls(t *testing.T) {
+	m := townsModel()
+	m.switchTown(1)
+
+	stale := townMsg{gen: 0, msg: pane.StatusUpdateMsg{FetchedAt: time.Now()}}
+	newM, cmd := m.Update(stale)
+	m = newM.(Model)
+	if cmd != nil || !m.lastRefresh.IsZero() {
+		t.Error("a poll of the previous town should be dropped")
+	}
+
+	fresh := townMsg{gen: m.townGen, msg: pane.StatusUpdateMsg{FetchedAt: time.Now()}}
+	newM, _ = m.Update(fresh)
+	if newM.(Model).lastRefresh.IsZero() {
+		t.Error("a poll of the current town should be applied")
+	}
+}
+
+func TestDetailPollsAreTagged(t *testing.T) {
+	m := townsModel()
+	newM, cmd := m.Update(pane.AgentSelectedMsg{Agent: pane.AgentInfo{Name: "amber", Rig: "kt", Role: "polecat"}})
+	m = newM.(Model)
+	if _, ok := cmd().(townMsg); !ok {
+		t.Error("an agent detail fetch should be tagged with its town")
+	}
+	newM, cmd = m.Update(data.AgentDetailTickMsg{})
+	m = newM.(Model)
+	if _, ok := cmd().(townMsg); !ok {
+		t.Error("an agent detail poll should be tagged with its town")
+	}
+
+	m.nudge = &nudgeWatch{rig: "kt", session: "gt-kt-amber", target: "kt/amber", until: time.Now()}
+	_, cmd = m.Update(data.NudgeTickMsg{})
+	if _, ok := cmd().(townMsg); !ok {
+		t.Error("a nudge output poll should be tagged with its town")
+	}
+}
+
+func TestSingleTownIsUntagged(t *testing.T) {
+	m := testModel()
+	if m.multiTown() {
+		t.Fatal("the default config has a single town")
+	}
+	if containsText(m.renderHeaderBar(), "⌂") {
+		t.Error("header should not show a town switcher for one town")
+	}
+	msg := func() tea.Msg { return data.StatusTickMsg{} }
+	if _, ok := m.tag(msg)().(townMsg); ok {
+		t.Error("single-town polls should not be tagged")
+	}
+}
+
+func TestTownsUpdateReachesDashboard(t *testing.T) {
+	m := townsModel()
+	newM, _ := m.Update(pane.TownsUpdateMsg{Towns: []pane.TownSummary{
+		{Name: "alpha", Status: &data.TownStatus{Agents: []data.AgentInfo{{Name: "mayor", Running: true}}}},
+		{Name: "beta"},
+	}})
+	m = newM.(Model)
+	view := m.panes[0].View()
+	if !containsText(view, "TOWNS") || !containsText(view, "unreachable") {
+		t.Errorf("dashboard should list both towns:\n%s", stripANSI(view))
+	}
+}
+
+func TestSessionRestoresTown(t *testing.T) {
+	var saved session.State
+	m := townsModel().WithSession(session.State{Town: "beta"}, func(st session.State) { saved = st })
+	if m.town != 1 {
+		t.Fatalf("town = %d, want beta restored", m.town)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if saved.Town != "beta" {
+		t.Errorf("saved town = %q, want beta", saved.Town)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Session persistence
+// ---------------------------------------------------------------------------
+
+func TestWithSessionRestoresAfterData(t *testing.T) {
+	st := session.State{
+		ActivePane: "Agents",
+		Panes: map[string]pane.PaneState{
+			"Agents": {"selected": "kt/quartz"},
+		},
+	}
+	var saved []session.State
+	m := sized(testModel().WithSession(st, func(s session.State) {
+		saved = append(saved, s)
+	}), 80, 24)
+
+	if m.panes[m.activePane].Title() != "Agents" {
+		t.Fatalf("active pane = %s, want Agents", m.panes[m.activePane].Title())
+	}
+
+	newM, _ := m.Update(pane.AgentUpdateMsg{Agents: []pane.AgentInfo{
+		{Name: "amber", Rig: "kt"},
+		{Name: "quartz", Rig: "kt"},
+	}})
+	m = newM.(Model)
+	if _, ok := m.pending["Agents"]; ok {
+		t.Error("Agents state should be restored once agents arrive")
+	}
+
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newM.(Model)
+	if len(saved) != 1 {
+		t.Fatalf("expected state saved after input, got %d saves", len(saved))
+	}
+	got := saved[0].Panes["Agents"]
+	if got["selected"] != "kt/quartz" || got["detail"] != "true" {
+		t.Errorf("saved Agents state = %v", got)
+	}
+}
+
+func TestSessionKeepsPendingStateUntilRestored(t *testing.T) {
+	st := session.State{
+		ActivePane: "Agents",
+		Panes: map[string]pane.PaneState{
+			"Convoys": {"selected": "hq-cv-1", "detail": "true"},
+		},
+	}
+	var last session.State
+	m := sized(testModel().WithSession(st, func(s session.State) { last = s }), 80, 24)
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = newM.(Model)
+	if got := last.Panes["Convoys"]; got["selected"] != "hq-cv-1" {
+		t.Errorf("unrestored Convoys state should be kept, got %v", got)
+	}
+}
+
+func TestSessionInputDropsPendingState(t *testing.T) {
+	st := session.State{
+		ActivePane: "New Issue",
+		Panes: map[string]pane.PaneState{
+			"New Issue": {"title": "draft", "rig": "alpha"},
+		},
+	}
+	m := sized(testModel().WithSession(st, func(session.State) {}), 80, 24)
+	if _, ok := m.pending["New Issue"]; !ok {
+		t.Fatal("draft should wait for the rig list")
+	}
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m = newM.(Model)
+	if _, ok := m.pending["New Issue"]; ok {
+		t.Error("typing into the form should stop the restore")
+	}
+	if !containsText(m.View(), "draft!") {
+		t.Error("the restored draft should be kept and edited")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // View rendering
 // ---------------------------------------------------------------------------
@@ -626,8 +1512,8 @@ func TestHeaderBarShowsPaneCount(t *testing.T) {
 	m = sized(m, 80, 24)
 
 	header := m.renderHeaderBar()
-	if !containsText(header, "1/10") {
-		t.Error("header should show '1/10' for first of 10 panes")
+	if !containsText(header, "1/13") {
+		t.Error("header should show '1/13' for first of 13 panes")
 	}
 }
 
@@ -643,8 +1529,8 @@ func TestHeaderBarUpdatesOnPaneSwitch(t *testing.T) {
 	if !containsText(header, "Agents") {
 		t.Error("header should show 'Agents' after switching")
 	}
-	if !containsText(header, "2/10") {
-		t.Error("header should show '2/10' for second pane")
+	if !containsText(header, "2/13") {
+		t.Error("header should show '2/13' for second pane")
 	}
 }
 
@@ -1,6 +1,10 @@
 package app
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+)
 
 // KeyMap defines all keybindings for the application.
 // Designed for mobile SSH friendliness: number keys for navigation,
@@ -26,90 +30,61 @@ type KeyMap struct {
 	Back     key.Binding
 	Refresh  key.Binding
 	Help     key.Binding
+	Focus    key.Binding // next region in split view
+	Layout   key.Binding // cycle single/split/grid
+	Theme    key.Binding // open the theme picker
+	Yank     key.Binding // copy the focused entity's ID to the clipboard
+	Export     key.Binding // copy the view as Markdown to the clipboard
+	ExportFile key.Binding // save the view as Markdown under export.dir
+	Town       key.Binding // open the town picker
 }
 
 // DefaultKeyMap returns the default set of keybindings.
 func DefaultKeyMap() KeyMap {
+	return NewKeyMap(keymap.Default())
+}
+
+// NewKeyMap builds the application keybindings from km's global scope.
+func NewKeyMap(km keymap.Map) KeyMap {
+	b := func(action string) key.Binding {
+		return km.Binding(keymap.Global, action)
+	}
 	return KeyMap{
-		Quit: key.NewBinding(
-			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q/ctrl+c", "quit"),
-		),
-		Tab: key.NewBinding(
-			key.WithKeys("tab"),
-			key.WithHelp("tab", "next pane"),
-		),
-		ShiftTab: key.NewBinding(
-			key.WithKeys("shift+tab"),
-			key.WithHelp("shift+tab", "prev pane"),
-		),
-		Pane1: key.NewBinding(
-			key.WithKeys("1"),
-			key.WithHelp("1", "pane 1"),
-		),
-		Pane2: key.NewBinding(
-			key.WithKeys("2"),
-			key.WithHelp("2", "pane 2"),
-		),
-		Pane3: key.NewBinding(
-			key.WithKeys("3"),
-			key.WithHelp("3", "pane 3"),
-		),
-		Pane4: key.NewBinding(
-			key.WithKeys("4"),
-			key.WithHelp("4", "pane 4"),
-		),
-		Pane5: key.NewBinding(
-			key.WithKeys("5"),
-			key.WithHelp("5", "pane 5"),
-		),
-		Pane6: key.NewBinding(
-			key.WithKeys("6"),
-			key.WithHelp("6", "pane 6"),
-		),
-		Pane7: key.NewBinding(
-			key.WithKeys("7"),
-			key.WithHelp("7", "pane 7"),
-		),
-		Pane8: key.NewBinding(
-			key.WithKeys("8"),
-			key.WithHelp("8", "pane 8"),
-		),
-		Pane9: key.NewBinding(
-			key.WithKeys("9"),
-			key.WithHelp("9", "pane 9"),
-		),
-		Pane0: key.NewBinding(
-			key.WithKeys("0"),
-			key.WithHelp("0", "pane 10"),
-		),
-		PanePicker: key.NewBinding(
-			key.WithKeys(" "),
-			key.WithHelp("space", "pane picker"),
-		),
-		Up: key.NewBinding(
-			key.WithKeys("k", "up"),
-			key.WithHelp("k/↑", "up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("j", "down"),
-			key.WithHelp("j/↓", "down"),
-		),
-		Select: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
-		Back: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "back"),
-		),
-		Refresh: key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "refresh"),
-		),
-		Help: key.NewBinding(
-			key.WithKeys("?"),
-			key.WithHelp("?", "help"),
-		),
+		Quit:       b("quit"),
+		Tab:        b("next_pane"),
+		ShiftTab:   b("prev_pane"),
+		Pane1:      b("pane_1"),
+		Pane2:      b("pane_2"),
+		Pane3:      b("pane_3"),
+		Pane4:      b("pane_4"),
+		Pane5:      b("pane_5"),
+		Pane6:      b("pane_6"),
+		Pane7:      b("pane_7"),
+		Pane8:      b("pane_8"),
+		Pane9:      b("pane_9"),
+		Pane0:      b("pane_0"),
+		PanePicker: b("pane_picker"),
+		Up:         b("up"),
+		Down:       b("down"),
+		Select:     b("select"),
+		Back:       b("back"),
+		Refresh:    b("refresh"),
+		Help:       b("help"),
+		Focus:      b("focus"),
+		Layout:     b("layout"),
+		Theme:      b("theme"),
+		Yank:       b("yank"),
+		Export:     b("export"),
+		ExportFile: b("export_file"),
+		Town:       b("town"),
+	}
+}
+
+// paneKeys returns the pane number keys in slot order: Pane1 to Pane9, then
+// Pane0.
+func (k KeyMap) paneKeys() []key.Binding {
+	return []key.Binding{
+		k.Pane1, k.Pane2, k.Pane3, k.Pane4, k.Pane5,
+		k.Pane6, k.Pane7, k.Pane8, k.Pane9, k.Pane0,
 	}
 }
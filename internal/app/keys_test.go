@@ -34,6 +34,8 @@ func TestDefaultKeyMap(t *testing.T) {
 		{"Back", km.Back, []string{"esc"}},
 		{"Refresh", km.Refresh, []string{"r"}},
 		{"Help", km.Help, []string{"?"}},
+		{"Focus", km.Focus, []string{"w"}},
+		{"Layout", km.Layout, []string{"L"}},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/session"
+)
+
+// RunLocal runs the TUI on the local terminal instead of over SSH. It
+// applies the config overrides for the local user name, and keeps their UI
+// state under a "local:<user>" key alongside the SSH sessions'. It blocks
+// until the user quits.
+func RunLocal(cfg config.Config) error {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	key := "local:" + name
+
+	sessions := session.NewStore(cfg.StateDir)
+	st, _ := sessions.Load(key)
+
+	out := NewTerminalWriter(os.Stdout)
+	model := New(cfg.ForUser(name, "")).
+		WithTownSources(TownSources(cfg)).
+		WithOutput(out).
+		WithSession(st, func(st session.State) { sessions.Save(key, st) })
+
+	_, err := tea.NewProgram(model, tea.WithOutput(out), tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	if ferr := sessions.Flush(key); err == nil && ferr != nil {
+		err = fmt.Errorf("saving session state: %w", ferr)
+	}
+	return err
+}
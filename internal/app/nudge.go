@@ -0,0 +1,83 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+const (
+	// nudgePollInterval is how often a nudged agent's output is captured
+	// while waiting for its reply.
+	nudgePollInterval = 2 * time.Second
+	// nudgeWatchFor is how long after a nudge its reply is watched for.
+	nudgeWatchFor = time.Minute
+	// nudgeLines is how much session output is compared for a reply.
+	nudgeLines = 60
+)
+
+// nudgeWatch is a nudged agent whose session output is captured until
+// the deadline, so the Agents detail view can show its reply.
+type nudgeWatch struct {
+	rig     string
+	session string
+	target  string
+	until   time.Time
+}
+
+func newNudgeWatch(msg pane.NudgeSentMsg) *nudgeWatch {
+	return &nudgeWatch{
+		rig:     msg.Rig,
+		session: msg.Session,
+		target:  msg.Target,
+		until:   time.Now().Add(nudgeWatchFor),
+	}
+}
+
+// nudgeCmd captures the agent's output as a baseline, then runs gt nudge
+// in the town at dir.
+func nudgeCmd(f data.Source, dir string, req pane.NudgeRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		baseline := f.FetchAgentOutput(req.Rig, req.Session, nudgeLines)
+		err := pane.SendNudge(dir, req.Target, req.Text)
+		return pane.NudgeSentMsg{
+			Rig:      req.Rig,
+			Session:  req.Session,
+			Target:   req.Target,
+			Text:     req.Text,
+			Baseline: baseline,
+			Err:      err,
+		}
+	}
+}
+
+// fetchNudgeOutputCmd captures the nudged agent's output, marking the
+// last capture before the watch's deadline as done.
+func fetchNudgeOutputCmd(f data.Source, w nudgeWatch) tea.Cmd {
+	return func() tea.Msg {
+		output := f.FetchAgentOutput(w.rig, w.session, nudgeLines)
+		done := time.Now().Add(nudgePollInterval).After(w.until)
+		return pane.NudgeOutputMsg{Target: w.target, Output: output, Done: done}
+	}
+}
+
+// openNudge switches to the Agents pane and opens the nudge composer in
+// the detail view of the agent identified by "rig/name".
+func (m Model) openNudge(agent string) (tea.Model, tea.Cmd) {
+	for i, p := range m.panes {
+		ap, ok := p.(*pane.AgentsPane)
+		if !ok {
+			continue
+		}
+		cmd, found := ap.OpenNudge(agent)
+		if !found {
+			return m, m.showToast("no session for " + agent)
+		}
+		m.activePane = i
+		return m, cmd
+	}
+	return m, m.showToast("enable the Agents pane to nudge")
+}
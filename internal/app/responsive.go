@@ -31,12 +31,86 @@ func StatusBarHeight() int {
 	return 1
 }
 
+// TickerHeight returns the height of the event ticker above the status bar
+// (always 1 row).
+func TickerHeight() int {
+	return 1
+}
+
 // ContentHeight returns the available height for content after subtracting
-// the tab bar and status bar from the total terminal height.
+// the tab bar, event ticker and status bar from the total terminal height.
 func ContentHeight(totalHeight int) int {
-	h := totalHeight - TabBarHeight() - StatusBarHeight()
+	h := totalHeight - TabBarHeight() - TickerHeight() - StatusBarHeight()
 	if h < 0 {
 		return 0
 	}
 	return h
 }
+
+// Split is a multi-pane layout preset for wide terminals.
+type Split int
+
+const (
+	SplitSingle Split = iota // one pane at a time
+	SplitPair                // two panes side by side
+	SplitGrid                // 2x2 grid
+	splitCount               // sentinel for cycling
+)
+
+// ParseSplit maps a config layout name to a Split. Unknown names, including
+// the empty string, mean SplitSingle.
+func ParseSplit(name string) Split {
+	switch name {
+	case "split":
+		return SplitPair
+	case "grid":
+		return SplitGrid
+	default:
+		return SplitSingle
+	}
+}
+
+const (
+	// SplitMinWidth is the narrowest terminal that shows panes side by side.
+	SplitMinWidth = 100
+	// GridMinHeight is the shortest content area that stacks two rows of
+	// panes.
+	GridMinHeight = 24
+)
+
+// Region is a rectangle of the content area that shows one pane. The first
+// row is the region's caption; the pane gets the rest.
+type Region struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Regions divides a w×h content area for split, falling back to fewer
+// regions when the area is too small. Columns are separated by a 1-cell
+// divider.
+func Regions(split Split, w, h int) []Region {
+	if split == SplitGrid && h < GridMinHeight {
+		split = SplitPair
+	}
+	if split == SplitSingle || w < SplitMinWidth {
+		return []Region{{Width: w, Height: h}}
+	}
+
+	left := (w - 1) / 2
+	right := w - 1 - left
+	if split == SplitPair {
+		return []Region{
+			{X: 0, Width: left, Height: h},
+			{X: left + 1, Width: right, Height: h},
+		}
+	}
+
+	top := h / 2
+	bottom := h - top
+	return []Region{
+		{X: 0, Y: 0, Width: left, Height: top},
+		{X: left + 1, Y: 0, Width: right, Height: top},
+		{X: 0, Y: top, Width: left, Height: bottom},
+		{X: left + 1, Y: top, Width: right, Height: bottom},
+	}
+}
@@ -37,16 +37,23 @@ func TestStatusBarHeight(t *testing.T) {
 	}
 }
 
+func TestTickerHeight(t *testing.T) {
+	if got := TickerHeight(); got != 1 {
+		t.Errorf("TickerHeight() = %d, want 1", got)
+	}
+}
+
 func TestContentHeight(t *testing.T) {
 	tests := []struct {
 		total int
 		want  int
 	}{
-		{24, 22},
+		{24, 21},
+		{3, 0},
 		{2, 0},
 		{1, 0},
 		{0, 0},
-		{80, 78},
+		{80, 77},
 	}
 	for _, tt := range tests {
 		got := ContentHeight(tt.total)
@@ -55,3 +62,54 @@ func TestContentHeight(t *testing.T) {
 		}
 	}
 }
+
+func TestRegions(t *testing.T) {
+	tests := []struct {
+		name  string
+		split Split
+		w, h  int
+		want  []Region
+	}{
+		{"single", SplitSingle, 160, 40, []Region{{Width: 160, Height: 40}}},
+		{"too narrow", SplitPair, 99, 40, []Region{{Width: 99, Height: 40}}},
+		{"pair", SplitPair, 121, 40, []Region{
+			{X: 0, Width: 60, Height: 40},
+			{X: 61, Width: 60, Height: 40},
+		}},
+		{"grid", SplitGrid, 120, 30, []Region{
+			{X: 0, Y: 0, Width: 59, Height: 15},
+			{X: 60, Y: 0, Width: 60, Height: 15},
+			{X: 0, Y: 15, Width: 59, Height: 15},
+			{X: 60, Y: 15, Width: 60, Height: 15},
+		}},
+		{"grid too short", SplitGrid, 120, 20, []Region{
+			{X: 0, Width: 59, Height: 20},
+			{X: 60, Width: 60, Height: 20},
+		}},
+	}
+	for _, tt := range tests {
+		got := Regions(tt.split, tt.w, tt.h)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d regions, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: region %d = %+v, want %+v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseSplit(t *testing.T) {
+	for name, want := range map[string]Split{
+		"":       SplitSingle,
+		"single": SplitSingle,
+		"split":  SplitPair,
+		"grid":   SplitGrid,
+	} {
+		if got := ParseSplit(name); got != want {
+			t.Errorf("ParseSplit(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"io"
+	"sync"
+)
+
+// TerminalWriter serialises writes to a client terminal. The program's
+// renderer and the model's out-of-band sequences (the bell, clipboard
+// copies) must share one, so a sequence written from a command can't land
+// in the middle of a rendered frame.
+type TerminalWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// ttyFile is what Bubble Tea checks its output for to treat it as a
+// terminal.
+type ttyFile interface {
+	io.ReadWriteCloser
+	Fd() uintptr
+}
+
+// terminalFile is a TerminalWriter over a terminal file, which keeps Bubble
+// Tea's terminal handling (raw mode, window size) working.
+type terminalFile struct {
+	*TerminalWriter
+	f ttyFile
+}
+
+func (t *terminalFile) Read(p []byte) (int, error) { return t.f.Read(p) }
+func (t *terminalFile) Close() error               { return t.f.Close() }
+func (t *terminalFile) Fd() uintptr                { return t.f.Fd() }
+
+// NewTerminalWriter wraps w for sharing between a program, as its
+// tea.WithOutput, and the model, as its WithOutput.
+func NewTerminalWriter(w io.Writer) io.Writer {
+	tw := &TerminalWriter{w: w}
+	if f, ok := w.(ttyFile); ok {
+		return &terminalFile{TerminalWriter: tw, f: f}
+	}
+	return tw
+}
+
+// Write writes p to the terminal in one piece.
+func (t *TerminalWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.w.Write(p)
+}
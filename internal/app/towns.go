@@ -0,0 +1,270 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tnguyen21/kestral-tui/internal/alert"
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/event"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// town is one configured Gas Town workspace and the panes showing it. Only
+// the town on screen is polled; the others keep what they last showed, so
+// switching back is instant while fresh data loads.
+type town struct {
+	name   string
+	cfg    config.Config // the session's config pointed at this town
+	src    data.Source
+	panes  []pane.Pane
+	slots  []int
+	events *pane.EventsPane
+	differ *event.Differ
+	alerts *alert.Engine
+}
+
+func newTown(name string, cfg config.Config, km keymap.Map) town {
+	t := town{
+		name:   name,
+		cfg:    cfg,
+		src:    &data.Fetcher{TownRoot: cfg.TownRoot, States: cfg.AgentClassifier(), Thresholds: cfg.Thresholds()},
+		differ: event.NewDiffer(),
+		alerts: alert.NewEngine(cfg.Alerts),
+	}
+	t.panes, t.slots, t.events = newPanes(cfg, km)
+	return t
+}
+
+// WithTownSources returns a copy of m that reads each town's data from the
+// source named after it, such as one data.Cache per town shared between
+// sessions. Towns missing from srcs keep their own fetcher.
+func (m Model) WithTownSources(srcs map[string]data.Source) Model {
+	for i := range m.towns {
+		if src, ok := srcs[m.towns[i].name]; ok {
+			m.towns[i].src = src
+		}
+	}
+	m.fetcher = m.towns[m.town].src
+	return m
+}
+
+// multiTown reports whether more than one town is configured, which turns
+// on the switcher and the Dashboard's towns section.
+func (m Model) multiTown() bool {
+	return len(m.towns) > 1
+}
+
+// switchTown shows town i: its panes, event history and alert state take
+// the place of the current town's, and polling restarts against its
+// source. Polls still in flight for the old town are dropped when they
+// arrive; see townMsg.
+func (m *Model) switchTown(i int) tea.Cmd {
+	if i == m.town || i < 0 || i >= len(m.towns) {
+		return nil
+	}
+	old := &m.towns[m.town]
+	old.panes, old.events, old.differ, old.alerts, old.src = m.panes, m.events, m.differ, m.alerts, m.fetcher
+
+	t := &m.towns[i]
+	m.town = i
+	m.panes, m.events, m.differ, m.alerts, m.fetcher = t.panes, t.events, t.differ, t.alerts, t.src
+	m.config = &t.cfg
+	m.townGen++
+	m.detailAgent = nil
+	m.witnessRig = ""
+	m.nudge = nil
+	m.pending = nil
+	m.lastRefresh = time.Time{}
+	m.setTheme(m.th)
+	m.resizePanes()
+
+	cmds := m.pollCmds()
+	if m.showsPane(pane.PaneNewIssue, pane.PaneDispatch, pane.PaneServices) {
+		cmds = append(cmds, m.tag(fetchRigsCmd(m.fetcher)))
+	}
+	if m.townStatus != nil {
+		cmds = append(cmds, m.forwardToAllPanes(pane.TownsUpdateMsg{Towns: m.townSummaries()})...)
+	}
+	return tea.Batch(cmds...)
+}
+
+// townMsg is a message produced by polling the town that was on screen at
+// generation gen. Update drops it if the session has switched town since.
+type townMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// tag marks cmd's messages as belonging to the current town. With a single
+// town nothing can go stale, so cmd is returned as is.
+func (m Model) tag(cmd tea.Cmd) tea.Cmd {
+	if !m.multiTown() || cmd == nil {
+		return cmd
+	}
+	return tagged(m.townGen, cmd)
+}
+
+// tagged wraps the message cmd produces in a townMsg for gen. Batches are
+// tagged command by command, so a poll's follow-up tick stays tagged.
+func tagged(gen int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case townMsg:
+			return msg
+		case tea.BatchMsg:
+			for i, c := range msg {
+				msg[i] = tagged(gen, c)
+			}
+			return msg
+		default:
+			return townMsg{gen: gen, msg: msg}
+		}
+	}
+}
+
+// townSummaries returns the latest health of every town, marking the one
+// on screen.
+func (m Model) townSummaries() []pane.TownSummary {
+	out := make([]pane.TownSummary, len(m.townStatus))
+	for i, s := range m.townStatus {
+		s.Current = s.Name == m.towns[m.town].name
+		out[i] = s
+	}
+	return out
+}
+
+// fetchTownsCmd fetches the status of every town for the Dashboard's towns
+// section.
+func fetchTownsCmd(towns []town) tea.Cmd {
+	names := make([]string, len(towns))
+	srcs := make([]data.Source, len(towns))
+	for i, t := range towns {
+		names[i], srcs[i] = t.name, t.src
+	}
+	return func() tea.Msg {
+		sums := make([]pane.TownSummary, len(srcs))
+		for i, src := range srcs {
+			st, _ := src.FetchStatus()
+			sums[i] = pane.TownSummary{Name: names[i], Status: st}
+		}
+		return pane.TownsUpdateMsg{Towns: sums}
+	}
+}
+
+// townLabel is the header bar's town switcher, empty with a single town.
+func (m Model) townLabel() string {
+	if !m.multiTown() {
+		return ""
+	}
+	return m.th.HeaderHintStyle.Render("⌂ " + m.towns[m.town].name + " ▾ ")
+}
+
+// ---------------------------------------------------------------------------
+// Town picker overlay
+// ---------------------------------------------------------------------------
+
+// openTownPicker opens the town picker with the current town selected.
+func (m Model) openTownPicker() (tea.Model, tea.Cmd) {
+	if !m.multiTown() {
+		return m, m.showToast("only one town is configured")
+	}
+	m.showTowns = true
+	m.townCursor = m.town
+	return m, nil
+}
+
+// renderTownPicker renders the list of towns with their last known health,
+// marking the one on screen.
+func (m Model) renderTownPicker() string {
+	var b strings.Builder
+	b.WriteString(m.th.PickerTitleStyle.Render("Select Town"))
+	b.WriteString("\n")
+
+	health := make(map[string]string, len(m.townStatus))
+	for _, s := range m.townStatus {
+		health[s.Name] = "unreachable"
+		if s.Status != nil {
+			health[s.Name] = s.Status.Health()
+		}
+	}
+
+	for i, t := range m.towns {
+		mark := " "
+		if i == m.town {
+			mark = "●"
+		}
+		row := fmt.Sprintf("%s %s  %s", mark, t.name, m.th.MutedStyle.Render(health[t.name]))
+
+		if i == m.townCursor {
+			cursor := m.th.PickerCursorStyle.Render("▸ ")
+			b.WriteString(cursor + m.th.PickerActiveRowStyle.Render(row))
+		} else {
+			b.WriteString("  " + m.th.PickerRowStyle.Render(row))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// handleTownKey handles key events when the town picker is open.
+func (m Model) handleTownKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Town):
+		m.showTowns = false
+		return m, nil
+
+	case key.Matches(msg, m.keys.Select):
+		m.showTowns = false
+		return m, m.switchTown(m.townCursor)
+
+	case key.Matches(msg, m.keys.Down):
+		m.townCursor = (m.townCursor + 1) % len(m.towns)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.townCursor = (m.townCursor - 1 + len(m.towns)) % len(m.towns)
+		return m, nil
+	}
+	return m, nil
+}
+
+// handleTownMouse switches to the clicked town. Rows start at Y=2, below
+// the header bar and the picker title.
+func (m Model) handleTownMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	m.showTowns = false
+	return m, m.switchTown(msg.Y - 2)
+}
+
+// onTownLabel reports whether a header bar click at x hit the town label.
+func (m Model) onTownLabel(x int) bool {
+	return x < lipgloss.Width(m.townLabel())
+}
+
+// TownSources creates a data.Cache for every configured town, keyed by town
+// name, for sharing between sessions through WithTownSources.
+func TownSources(cfg config.Config) map[string]data.Source {
+	ttl := time.Duration(cfg.CacheTTL) * time.Second
+	states, limits := cfg.AgentClassifier(), cfg.Thresholds()
+	srcs := make(map[string]data.Source)
+	for _, t := range cfg.TownList() {
+		srcs[t.Name] = data.NewCache(&data.Fetcher{TownRoot: t.Root, States: states, Thresholds: limits}, ttl)
+	}
+	return srcs
+}
@@ -0,0 +1,318 @@
+// Package cli implements Kestral's headless subcommands, which print the
+// same aggregated town data the TUI shows as plain text or JSON, for cron
+// jobs and shell scripts.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/export"
+	"github.com/tnguyen21/kestral-tui/internal/feed"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// env is what a subcommand runs against.
+type env struct {
+	ctx  context.Context
+	src  data.Source
+	cfg  config.Config
+	out  io.Writer
+	json bool
+	now  time.Time
+}
+
+type command struct {
+	summary string
+	// setup registers the subcommand's own flags and returns its body.
+	setup func(fs *flag.FlagSet) func(e env) error
+}
+
+var commands = map[string]command{
+	"status":  {"town health, agents and open convoys", simple(status)},
+	"agents":  {"agents with status, age and hooked issue", simple(agents)},
+	"convoys": {"open convoys with progress", convoysFlags},
+	"watch":   {"stream events as they are detected, until interrupted", simple(watch)},
+	"digest":  {"the daily digest as Markdown", digestFlags},
+}
+
+// simple adapts a subcommand without flags of its own.
+func simple(run func(e env) error) func(*flag.FlagSet) func(env) error {
+	return func(*flag.FlagSet) func(env) error { return run }
+}
+
+// IsCommand reports whether name is a subcommand.
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Usage lists the subcommands, for the main usage message.
+func Usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "Subcommands (run kestral <subcommand> -h for flags):")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", name, commands[name].summary)
+	}
+	tw.Flush()
+}
+
+// Run parses args for the subcommand name and runs it, fetching from a
+// town in the loaded config. Every subcommand takes -config, -town and
+// -json.
+func Run(ctx context.Context, name string, args []string, out io.Writer) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown subcommand %q", name)
+	}
+	fs := flag.NewFlagSet("kestral "+name, flag.ContinueOnError)
+	configPath := fs.String("config", config.DefaultConfigPath, "path to config file")
+	townName := fs.String("town", "", "town to report on (default the first configured)")
+	asJSON := fs.Bool("json", false, "print JSON instead of text")
+	run := cmd.setup(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%s takes no arguments, got %q", name, fs.Arg(0))
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return err
+	}
+	cfg, err = pickTown(cfg, *townName)
+	if err != nil {
+		return err
+	}
+	return run(env{
+		ctx:  ctx,
+		src:  &data.Fetcher{TownRoot: cfg.TownRoot, States: cfg.AgentClassifier(), Thresholds: cfg.Thresholds()},
+		cfg:  cfg,
+		out:  out,
+		json: *asJSON,
+		now:  time.Now(),
+	})
+}
+
+// pickTown points cfg at the town called name, or the first town when name
+// is empty.
+func pickTown(cfg config.Config, name string) (config.Config, error) {
+	towns := cfg.TownList()
+	if name == "" {
+		return cfg.ForTown(towns[0]), nil
+	}
+	var names []string
+	for _, t := range towns {
+		if t.Name == name {
+			return cfg.ForTown(t), nil
+		}
+		names = append(names, t.Name)
+	}
+	return cfg, fmt.Errorf("unknown town %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+// convoyReport is a convoy with its progress, as the Convoys pane counts it.
+type convoyReport struct {
+	data.ConvoyInfo
+	Done   int                `json:"done"`
+	Total  int                `json:"total"`
+	Issues []data.IssueDetail `json:"issues,omitempty"`
+}
+
+func convoyReports(msg pane.ConvoyUpdateMsg, withIssues bool) []convoyReport {
+	reports := make([]convoyReport, len(msg.Convoys))
+	for i, c := range msg.Convoys {
+		p := msg.Progress[c.ID]
+		reports[i] = convoyReport{ConvoyInfo: c, Done: p[0], Total: p[1]}
+		if withIssues {
+			reports[i].Issues = msg.Issues[c.ID]
+		}
+	}
+	return reports
+}
+
+// statusReport is the Dashboard's summary.
+type statusReport struct {
+	Health    string           `json:"health"`
+	Agents    []data.AgentInfo `json:"agents"`
+	Sessions  int              `json:"sessions"`
+	Convoys   []convoyReport   `json:"convoys"`
+	FetchedAt time.Time        `json:"fetched_at"`
+}
+
+func status(e env) error {
+	st, err := e.src.FetchStatus()
+	if err != nil {
+		return err
+	}
+	sessions, err := e.src.FetchSessions()
+	if err != nil {
+		return err
+	}
+	convoys, err := feed.FetchConvoys(e.src)
+	if err != nil {
+		return err
+	}
+	r := statusReport{
+		Health:    st.Health(),
+		Agents:    st.Agents,
+		Sessions:  len(sessions),
+		Convoys:   convoyReports(convoys, false),
+		FetchedAt: e.now,
+	}
+	if e.json {
+		return writeJSON(e.out, r)
+	}
+
+	running := 0
+	for _, a := range r.Agents {
+		if a.Running {
+			running++
+		}
+	}
+	fmt.Fprintf(e.out, "Town: %s    %d/%d agents running    %d tmux sessions    %d convoys open\n",
+		r.Health, running, len(r.Agents), r.Sessions, len(r.Convoys))
+	tw := tabwriter.NewWriter(e.out, 0, 0, 2, ' ', 0)
+	for _, a := range r.Agents {
+		state := a.State
+		if state == "" {
+			state = "stopped"
+			if a.Running {
+				state = "active"
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", a.Name, state)
+	}
+	tw.Flush()
+	return nil
+}
+
+func agents(e env) error {
+	details, err := e.src.FetchAgents()
+	if err != nil {
+		return err
+	}
+	if e.json {
+		return writeJSON(e.out, details)
+	}
+	tw := tabwriter.NewWriter(e.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "AGENT\tROLE\tSTATUS\tACTIVE\tISSUE")
+	for _, d := range details {
+		issue := d.IssueID
+		if d.IssueTitle != "" {
+			issue += " " + d.IssueTitle
+		}
+		status := d.Status
+		if d.State != "" {
+			status += " (" + d.State + ")"
+		}
+		fmt.Fprintf(tw, "%s/%s\t%s\t%s\t%s\t%s\n",
+			d.Rig, d.Name, d.Role, status,
+			pane.FormatAge(time.Duration(d.AgeSecs)*time.Second), issue)
+	}
+	return tw.Flush()
+}
+
+func convoysFlags(fs *flag.FlagSet) func(env) error {
+	withIssues := fs.Bool("issues", false, "list each convoy's tracked issues")
+	return func(e env) error {
+		msg, err := feed.FetchConvoys(e.src)
+		if err != nil {
+			return err
+		}
+		reports := convoyReports(msg, *withIssues)
+		if e.json {
+			return writeJSON(e.out, reports)
+		}
+		tw := tabwriter.NewWriter(e.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CONVOY\tTITLE\tPROGRESS")
+		for _, r := range reports {
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\n", r.ID, r.Title, r.Done, r.Total)
+			for _, iss := range r.Issues {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\n", iss.ID, iss.Title, strings.ToLower(iss.Status))
+			}
+		}
+		return tw.Flush()
+	}
+}
+
+// eventLine is one event printed by watch -json.
+type eventLine struct {
+	At      time.Time      `json:"at"`
+	Kind    pane.EventKind `json:"kind"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// watch prints events on the configured poll intervals until ctx is
+// cancelled. Like the Events pane, the first poll only sets the baseline.
+func watch(e env) error {
+	enc := json.NewEncoder(e.out)
+	feed.Watch(e.ctx, e.src, e.cfg, func(events []pane.Event) {
+		for _, ev := range events {
+			if e.json {
+				_ = enc.Encode(eventLine{At: ev.At, Kind: ev.Kind, Subject: ev.Subject, Message: ev.Message})
+				continue
+			}
+			fmt.Fprintf(e.out, "%s  %-16s  %s\n", ev.At.Format("15:04:05"), ev.Kind, ev.Message)
+		}
+	})
+	return nil
+}
+
+func digestFlags(fs *flag.FlagSet) func(env) error {
+	date := fs.String("date", "", "day to summarize as YYYY-MM-DD (default today)")
+	save := fs.Bool("save", false, "write the digest under export.dir and print its path")
+	return func(e env) error {
+		day := e.now
+		if *date != "" {
+			var err error
+			day, err = time.ParseInLocation("2006-01-02", *date, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid -date %q: want YYYY-MM-DD", *date)
+			}
+		}
+		dg, err := export.FetchDigest(e.src, day, e.now)
+		if err != nil {
+			return fmt.Errorf("building digest: %w", err)
+		}
+		if e.json {
+			return writeJSON(e.out, dg)
+		}
+		doc := dg.Doc()
+		if !*save {
+			_, err := io.WriteString(e.out, doc.Markdown)
+			return err
+		}
+		if e.cfg.Export.Dir == "" {
+			return fmt.Errorf("export.dir is not set")
+		}
+		path, err := export.WriteFile(e.cfg.Export.Dir, doc, e.now)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(e.out, path)
+		return err
+	}
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// fakeSource serves canned data. Unused Source methods are left to the
+// embedded nil interface and panic if called.
+type fakeSource struct {
+	data.Source
+}
+
+func (fakeSource) FetchStatus() (*data.TownStatus, error) {
+	return &data.TownStatus{Agents: []data.AgentInfo{
+		{Name: "mayor", Running: true},
+		{Name: "kt/witness", Running: false},
+	}}, nil
+}
+func (fakeSource) FetchSessions() ([]data.SessionInfo, error) {
+	return []data.SessionInfo{{Name: "gt-kt-mayor"}}, nil
+}
+func (fakeSource) FetchConvoys() ([]data.ConvoyInfo, error) {
+	return []data.ConvoyInfo{{ID: "kt-c1", Title: "Auth", Status: "open"}}, nil
+}
+func (fakeSource) FetchStrandedConvoys() ([]data.StrandedConvoy, error) {
+	return nil, nil
+}
+func (fakeSource) FetchTrackedIssues(string) ([]data.IssueDetail, error) {
+	return []data.IssueDetail{
+		{ID: "kt-1", Title: "Login", Status: "CLOSED"},
+		{ID: "kt-2", Title: "Logout", Status: "OPEN"},
+	}, nil
+}
+func (fakeSource) FetchAgents() ([]data.AgentDetail, error) {
+	return []data.AgentDetail{{Name: "amber", Rig: "kt", Role: "polecat", Status: "working", AgeSecs: 600, IssueID: "kt-2"}}, nil
+}
+
+func testEnv(out *bytes.Buffer, asJSON bool) env {
+	return env{
+		ctx:  context.Background(),
+		src:  fakeSource{},
+		cfg:  config.Default(),
+		out:  out,
+		json: asJSON,
+		now:  time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := status(testEnv(&out, true)); err != nil {
+		t.Fatal(err)
+	}
+	var r statusReport
+	if err := json.Unmarshal(out.Bytes(), &r); err != nil {
+		t.Fatalf("decoding %s: %v", out.String(), err)
+	}
+	if r.Health != "degraded" || r.Sessions != 1 || len(r.Agents) != 2 {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if len(r.Convoys) != 1 || r.Convoys[0].ID != "kt-c1" || r.Convoys[0].Done != 1 || r.Convoys[0].Total != 2 {
+		t.Errorf("convoy progress should match the Convoys pane, got %+v", r.Convoys)
+	}
+}
+
+func TestStatusText(t *testing.T) {
+	var out bytes.Buffer
+	if err := status(testEnv(&out, false)); err != nil {
+		t.Fatal(err)
+	}
+	want := "Town: degraded    1/2 agents running    1 tmux sessions    1 convoys open\n"
+	if !strings.HasPrefix(out.String(), want) {
+		t.Errorf("got %q, want prefix %q", out.String(), want)
+	}
+	if !strings.Contains(out.String(), "kt/witness  stopped") {
+		t.Errorf("missing stopped agent:\n%s", out.String())
+	}
+}
+
+func TestAgentsText(t *testing.T) {
+	var out bytes.Buffer
+	if err := agents(testEnv(&out, false)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "AGENT") {
+		t.Fatalf("got %q", out.String())
+	}
+	for _, want := range []string{"kt/amber", "polecat", "working", "10m ago", "kt-2"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("row %q missing %q", lines[1], want)
+		}
+	}
+}
+
+func TestConvoysWithIssues(t *testing.T) {
+	fs := flag.NewFlagSet("convoys", flag.ContinueOnError)
+	run := convoysFlags(fs)
+	if err := fs.Parse([]string{"-issues"}); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := run(testEnv(&out, false)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"kt-c1   Auth    1/2", "  kt-2  Logout  open"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunRejectsUnknownAndArgs(t *testing.T) {
+	if IsCommand("serve") {
+		t.Error("serve is not a subcommand")
+	}
+	if err := Run(context.Background(), "agents", []string{"extra"}, &bytes.Buffer{}); err == nil {
+		t.Error("stray arguments should be rejected")
+	}
+}
+
+func TestPickTown(t *testing.T) {
+	cfg := config.Default()
+	cfg.Towns = []config.Town{{Name: "alpha", Root: "/a"}, {Name: "beta", Root: "/b"}}
+
+	got, err := pickTown(cfg, "")
+	if err != nil || got.TownRoot != "/a" {
+		t.Errorf("default town root = %q, %v; want /a", got.TownRoot, err)
+	}
+	got, err = pickTown(cfg, "beta")
+	if err != nil || got.TownRoot != "/b" {
+		t.Errorf("beta root = %q, %v; want /b", got.TownRoot, err)
+	}
+	if _, err := pickTown(cfg, "gamma"); err == nil {
+		t.Error("an unknown town should be rejected")
+	}
+}
@@ -2,10 +2,18 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 const DefaultConfigPath = "~/.config/kestral/kestral.yaml"
@@ -21,11 +29,142 @@ type PollInterval struct {
 	PRs       int `yaml:"prs"`
 }
 
+// Town is one Gas Town workspace. Poll intervals left at 0 use the
+// top-level poll_interval.
+type Town struct {
+	Name         string       `yaml:"name"`
+	Root         string       `yaml:"root"`
+	PollInterval PollInterval `yaml:"poll_interval"`
+}
+
+// Alert rule kinds understood by the alert engine.
+const (
+	AlertAgentStatus      = "agent_status"      // agent status, or state read from its output, equals Status
+	AlertCPU              = "cpu"               // session CPU >= Threshold for Samples polls
+	AlertWitnessHeartbeat = "witness_heartbeat" // witness heartbeat older than MaxAge seconds
+	AlertQueueDepth       = "queue_depth"       // refinery queue depth >= Threshold
+	AlertCIFailure        = "ci_failure"        // a PR has a failing status check
+	AlertConvoyComplete   = "convoy_complete"   // all tracked issues in a convoy are done
+)
+
+// AlertRule describes a single condition evaluated on every data update.
+type AlertRule struct {
+	Name      string  `yaml:"name"`
+	Kind      string  `yaml:"kind"`
+	Severity  string  `yaml:"severity"`  // info, warn, fail
+	Status    string  `yaml:"status"`    // agent_status
+	Threshold float64 `yaml:"threshold"` // cpu, queue_depth
+	Samples   int     `yaml:"samples"`   // cpu
+	MaxAge    int     `yaml:"max_age"`   // witness_heartbeat, agent_status stale/stuck; in seconds
+	Bell      bool    `yaml:"bell"`      // ring the terminal bell when fired
+}
+
+// Alerts configures the alert rules engine.
+type Alerts struct {
+	// Cooldown is the number of seconds before an alert that is still
+	// active fires again. 0 means once per occurrence.
+	Cooldown int         `yaml:"cooldown"`
+	Rules    []AlertRule `yaml:"rules"`
+}
+
+// Webhook describes an HTTP endpoint that receives selected events.
+type Webhook struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Events lists the event kinds to deliver. Empty means agent_stuck,
+	// witness_dead, ci_failed and convoy_completed.
+	Events []string `yaml:"events"`
+	// Template is a text/template for the request body. Empty sends the
+	// default JSON payload.
+	Template  string            `yaml:"template"`
+	Headers   map[string]string `yaml:"headers"`
+	Retries   int               `yaml:"retries"`    // extra attempts after a failed delivery
+	RateLimit int               `yaml:"rate_limit"` // max deliveries per minute, 0 = unlimited
+}
+
+// Notify configures outbound event delivery.
+type Notify struct {
+	Webhooks []Webhook `yaml:"webhooks"`
+}
+
+// API configures the read-only HTTP/JSON API.
+type API struct {
+	Listen string `yaml:"listen"` // address such as ":8080"; empty disables the API
+	Token  string `yaml:"token"`  // bearer token required on every request
+}
+
+// Metrics configures the Prometheus exporter.
+type Metrics struct {
+	Listen string `yaml:"listen"` // address such as ":9100"; empty disables /metrics
+}
+
+// Export configures Markdown exports.
+type Export struct {
+	Dir string `yaml:"dir"` // where saved exports are written
+}
+
+// Layout is the split view preset for wide terminals.
+type Layout struct {
+	Mode  string   `yaml:"mode"`  // "single", "split" (two panes) or "grid" (2x2)
+	Panes []string `yaml:"panes"` // pane titles per region, left to right then top to bottom
+}
+
+// PaneRef enables one pane, by title, optionally pinned to a number key
+// ("1"-"9", or "0" for the tenth slot). In YAML it is either a bare title
+// or a mapping with name and key.
+type PaneRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+// UnmarshalYAML accepts a bare pane title as well as the full mapping.
+func (r *PaneRef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		r.Name = node.Value
+		return nil
+	}
+	type plain PaneRef
+	return node.Decode((*plain)(r))
+}
+
+// User holds per-user overrides, keyed in Config.Users by SSH user name or
+// public key fingerprint.
+type User struct {
+	Panes []PaneRef `yaml:"panes"`
+}
+
 type Config struct {
 	Port         int          `yaml:"port"`
 	TownRoot     string       `yaml:"town_root"`
+	Towns        []Town       `yaml:"towns"` // workspaces to switch between; empty = TownRoot alone
 	HostKeyDir   string       `yaml:"host_key_dir"`
+	StateDir     string       `yaml:"state_dir"` // per-user UI state, keyed by SSH key
 	PollInterval PollInterval `yaml:"poll_interval"`
+	Alerts       Alerts       `yaml:"alerts"`
+	EventHistory int          `yaml:"event_history"` // events kept in the Events pane
+	Notify       Notify       `yaml:"notify"`
+	API          API          `yaml:"api"`
+	Metrics      Metrics      `yaml:"metrics"`
+	Layout       Layout       `yaml:"layout"`
+	Export       Export       `yaml:"export"`
+	// Panes lists the enabled panes in tab order. Empty enables them all
+	// in the default order. Hidden panes are not polled.
+	Panes []PaneRef       `yaml:"panes"`
+	Users map[string]User `yaml:"users"`
+	// Keybindings remaps actions by scope ("global" or a pane such as
+	// "agents") then action name, e.g. keybindings.global.help: [h].
+	Keybindings map[string]map[string][]string `yaml:"keybindings"`
+	// Theme names the palette new sessions start with: a built-in or one
+	// of Themes. Each user can switch from the in-TUI theme picker.
+	Theme  string                  `yaml:"theme"`
+	Themes map[string]theme.Colors `yaml:"themes"` // user-defined palettes by name
+	// CacheTTL is how many seconds fetch results are shared between SSH
+	// sessions and API requests. 0 disables the shared cache.
+	CacheTTL int `yaml:"cache_ttl"`
+	// AgentStates are the rules that read an agent's state, such as
+	// waiting for input, from its session output. Setting them replaces
+	// the built-in rules; an empty list turns detection off.
+	AgentStates []data.StateRule `yaml:"agent_states"`
 }
 
 func Default() Config {
@@ -34,6 +173,8 @@ func Default() Config {
 		Port:       2222,
 		TownRoot:   filepath.Join(home, "gt"),
 		HostKeyDir: filepath.Join(home, ".ssh"),
+		StateDir:   filepath.Join(home, ".config", "kestral", "sessions"),
+		Export:     Export{Dir: filepath.Join(home, ".config", "kestral", "exports")},
 		PollInterval: PollInterval{
 			Status:    10,
 			Agents:    5,
@@ -44,9 +185,139 @@ func Default() Config {
 			Witnesses: 10,
 			PRs:       30,
 		},
+		Alerts: Alerts{
+			Rules: DefaultAlertRules(),
+		},
+		EventHistory: 100,
+		CacheTTL:     5,
+		Theme:        theme.DefaultName,
+		AgentStates:  data.DefaultStateRules(),
 	}
 }
 
+// DefaultAlertRules returns the built-in rules, mirroring the thresholds the
+// panes use for their own status colors.
+func DefaultAlertRules() []AlertRule {
+	return []AlertRule{
+		{Name: "agent-stuck", Kind: AlertAgentStatus, Status: "stuck", MaxAge: 1800, Severity: "fail"},
+		{Name: "cpu-sustained", Kind: AlertCPU, Threshold: 95, Samples: 10, Severity: "warn"},
+		{Name: "witness-dead", Kind: AlertWitnessHeartbeat, MaxAge: 900, Severity: "fail"},
+		{Name: "queue-backlog", Kind: AlertQueueDepth, Threshold: 10, Severity: "warn"},
+		{Name: "ci-failure", Kind: AlertCIFailure, Severity: "fail"},
+		{Name: "convoy-complete", Kind: AlertConvoyComplete, Severity: "info"},
+	}
+}
+
+// Thresholds derives the status cutoffs the fetcher and panes use from the
+// alert rules, so a pane shows an agent as stuck or a session as hot when
+// the rules would alert on it. An agent_status rule with max_age sets when
+// agents turn stale or stuck, the shortest witness_heartbeat max_age when a
+// witness is dead, and the highest cpu rule the CPU alert level. A second,
+// lower cpu rule sets the warning level. Anything without a rule keeps its
+// default.
+func (c Config) Thresholds() data.Thresholds {
+	t := data.DefaultThresholds()
+	var cpu []AlertRule
+	witnessDead := 0
+	for _, r := range c.Alerts.Rules {
+		age := time.Duration(r.MaxAge) * time.Second
+		switch {
+		case r.Kind == AlertAgentStatus && r.MaxAge > 0 && r.Status == "stale":
+			t.AgentStale = age
+		case r.Kind == AlertAgentStatus && r.MaxAge > 0 && r.Status == "stuck":
+			t.AgentStuck = age
+		case r.Kind == AlertWitnessHeartbeat && (witnessDead == 0 || r.MaxAge < witnessDead):
+			witnessDead = r.MaxAge
+		case r.Kind == AlertCPU:
+			cpu = append(cpu, r)
+		}
+	}
+	if witnessDead > 0 {
+		t.WitnessDead = time.Duration(witnessDead) * time.Second
+	}
+	if len(cpu) > 0 {
+		sort.SliceStable(cpu, func(i, j int) bool { return cpu[i].Threshold < cpu[j].Threshold })
+		high := cpu[len(cpu)-1]
+		t.CPUAlert, t.CPUAlertSamples = high.Threshold, high.Samples
+		if len(cpu) > 1 {
+			t.CPUWarn, t.CPUWarnSamples = cpu[0].Threshold, cpu[0].Samples
+		}
+	}
+	// Keep each warning level at or below the level it leads up to.
+	t.AgentStale = min(t.AgentStale, t.AgentStuck)
+	t.WitnessStale = min(t.WitnessStale, t.WitnessDead)
+	if t.CPUWarn > t.CPUAlert {
+		t.CPUWarn, t.CPUWarnSamples = t.CPUAlert, t.CPUAlertSamples
+	}
+	return t
+}
+
+// AgentClassifier compiles the agent_states rules, which Load has already
+// validated. It is nil when there are none, so agents are not sampled.
+func (c Config) AgentClassifier() *data.Classifier {
+	if len(c.AgentStates) == 0 {
+		return nil
+	}
+	states, err := data.NewClassifier(c.AgentStates)
+	if err != nil {
+		return nil
+	}
+	return states
+}
+
+// ForUser returns the config for one SSH session, with the overrides for
+// its public key fingerprint, or failing that its user name, applied.
+func (c Config) ForUser(name, fingerprint string) Config {
+	u, ok := c.Users[fingerprint]
+	if !ok {
+		u, ok = c.Users[name]
+	}
+	if ok && len(u.Panes) > 0 {
+		c.Panes = u.Panes
+	}
+	return c
+}
+
+// TownList returns the configured towns with every poll interval filled
+// in. Without a towns section it is the single town at TownRoot, named
+// after its directory.
+func (c Config) TownList() []Town {
+	if len(c.Towns) == 0 {
+		return []Town{{Name: filepath.Base(c.TownRoot), Root: c.TownRoot, PollInterval: c.PollInterval}}
+	}
+	towns := make([]Town, len(c.Towns))
+	for i, t := range c.Towns {
+		t.PollInterval = t.PollInterval.inherit(c.PollInterval)
+		towns[i] = t
+	}
+	return towns
+}
+
+// ForTown returns c pointed at town t: its root and poll intervals.
+func (c Config) ForTown(t Town) Config {
+	c.TownRoot = t.Root
+	c.PollInterval = t.PollInterval.inherit(c.PollInterval)
+	return c
+}
+
+// inherit fills the intervals left at 0 from def.
+func (p PollInterval) inherit(def PollInterval) PollInterval {
+	fill := func(v *int, d int) {
+		if *v == 0 {
+			*v = d
+		}
+	}
+	fill(&p.Status, def.Status)
+	fill(&p.Agents, def.Agents)
+	fill(&p.Convoys, def.Convoys)
+	fill(&p.Mail, def.Mail)
+	fill(&p.Refinery, def.Refinery)
+	fill(&p.Resources, def.Resources)
+	fill(&p.Witnesses, def.Witnesses)
+	fill(&p.PRs, def.PRs)
+	return p
+}
+
 func expandPath(path string) string {
 	if len(path) > 0 && path[0] == '~' {
 		home, err := os.UserHomeDir()
@@ -75,7 +346,12 @@ func Load(path string) (Config, error) {
 	}
 
 	cfg.TownRoot = expandPath(cfg.TownRoot)
+	for i := range cfg.Towns {
+		cfg.Towns[i].Root = expandPath(cfg.Towns[i].Root)
+	}
 	cfg.HostKeyDir = expandPath(cfg.HostKeyDir)
+	cfg.StateDir = expandPath(cfg.StateDir)
+	cfg.Export.Dir = expandPath(cfg.Export.Dir)
 
 	if err := validate(cfg); err != nil {
 		return cfg, err
@@ -114,5 +390,228 @@ func validate(cfg Config) error {
 		return fmt.Errorf("poll_interval.prs must be >= 1")
 	}
 
+	if err := validateTowns(cfg.Towns); err != nil {
+		return err
+	}
+
+	if cfg.EventHistory < 1 {
+		return fmt.Errorf("event_history must be >= 1")
+	}
+
+	if err := validateAlerts(cfg.Alerts); err != nil {
+		return err
+	}
+
+	if err := validateNotify(cfg.Notify); err != nil {
+		return err
+	}
+
+	if cfg.API.Listen != "" && cfg.API.Token == "" {
+		return fmt.Errorf("api.token is required when api.listen is set")
+	}
+	if cfg.Metrics.Listen != "" && cfg.Metrics.Listen == cfg.API.Listen {
+		return fmt.Errorf("metrics.listen must differ from api.listen")
+	}
+	if cfg.CacheTTL < 0 {
+		return fmt.Errorf("cache_ttl must be >= 0")
+	}
+
+	switch cfg.Layout.Mode {
+	case "", "single", "split", "grid":
+	default:
+		return fmt.Errorf("layout.mode must be single, split or grid, got %q", cfg.Layout.Mode)
+	}
+	if len(cfg.Layout.Panes) > 4 {
+		return fmt.Errorf("layout.panes lists %d panes, at most 4 fit", len(cfg.Layout.Panes))
+	}
+	for i, title := range cfg.Layout.Panes {
+		if !knownPane(title) {
+			return fmt.Errorf("layout.panes[%d]: unknown pane %q (valid: %s)", i, title, strings.Join(PaneTitles, ", "))
+		}
+	}
+
+	if _, err := keymap.New(cfg.Keybindings); err != nil {
+		return err
+	}
+
+	if _, err := data.NewClassifier(cfg.AgentStates); err != nil {
+		return err
+	}
+
+	if err := validateThemes(cfg); err != nil {
+		return err
+	}
+
+	if err := validatePanes("panes", cfg.Panes); err != nil {
+		return err
+	}
+	for name, u := range cfg.Users {
+		if err := validatePanes("users."+name+".panes", u.Panes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateTowns(towns []Town) error {
+	names := make(map[string]bool)
+	for i, t := range towns {
+		if t.Name == "" {
+			return fmt.Errorf("towns[%d]: name is required", i)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("towns[%d]: duplicate town name %q", i, t.Name)
+		}
+		names[t.Name] = true
+		if t.Root == "" {
+			return fmt.Errorf("towns[%d]: root is required", i)
+		}
+		p := t.PollInterval
+		for _, v := range []int{p.Status, p.Agents, p.Convoys, p.Mail, p.Refinery, p.Resources, p.Witnesses, p.PRs} {
+			if v < 0 {
+				return fmt.Errorf("towns[%d]: poll intervals must be >= 0 (0 uses poll_interval)", i)
+			}
+		}
+	}
+	return nil
+}
+
+// PaneTitles are the titles panes are listed by in panes, users and
+// layout.panes, in the default tab order. Names match case-insensitively.
+var PaneTitles = []string{
+	"Dashboard", "Agents", "Refinery", "PRs", "Convoys", "Resources", "History",
+	"New Issue", "Mail", "Witnesses", "Events", "Dispatch", "Services",
+}
+
+// knownPane reports whether name is one of PaneTitles.
+func knownPane(name string) bool {
+	for _, t := range PaneTitles {
+		if strings.EqualFold(t, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePanes(field string, refs []PaneRef) error {
+	names := make(map[string]bool)
+	keys := make(map[string]string)
+	for i, r := range refs {
+		if r.Name == "" {
+			return fmt.Errorf("%s[%d]: name is required", field, i)
+		}
+		if !knownPane(r.Name) {
+			return fmt.Errorf("%s[%d]: unknown pane %q (valid: %s)", field, i, r.Name, strings.Join(PaneTitles, ", "))
+		}
+		name := strings.ToLower(r.Name)
+		if names[name] {
+			return fmt.Errorf("%s[%d]: %s is listed twice", field, i, r.Name)
+		}
+		names[name] = true
+
+		if r.Key == "" {
+			continue
+		}
+		if len(r.Key) != 1 || r.Key[0] < '0' || r.Key[0] > '9' {
+			return fmt.Errorf("%s[%d]: key must be a digit 0-9, got %q", field, i, r.Key)
+		}
+		if other, ok := keys[r.Key]; ok {
+			return fmt.Errorf("%s[%d]: key %s is already used by %s", field, i, r.Key, other)
+		}
+		keys[r.Key] = r.Name
+	}
+	return nil
+}
+
+func validateThemes(cfg Config) error {
+	for name, c := range cfg.Themes {
+		if name == "" {
+			return fmt.Errorf("themes: name is required")
+		}
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("themes.%s.%w", name, err)
+		}
+	}
+	if _, ok := theme.Find(theme.All(cfg.Themes), cfg.Theme); !ok {
+		return fmt.Errorf("theme %q is neither built in nor defined under themes", cfg.Theme)
+	}
+	return nil
+}
+
+func validateAlerts(a Alerts) error {
+	if a.Cooldown < 0 {
+		return fmt.Errorf("alerts.cooldown must be >= 0")
+	}
+
+	seen := make(map[string]bool)
+	for i, r := range a.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("alerts.rules[%d]: name is required", i)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("alerts.rules[%d]: duplicate rule name %q", i, r.Name)
+		}
+		seen[r.Name] = true
+
+		switch r.Severity {
+		case "", "info", "warn", "fail":
+		default:
+			return fmt.Errorf("alerts.rules[%d]: unknown severity %q", i, r.Severity)
+		}
+
+		switch r.Kind {
+		case AlertAgentStatus:
+			if r.Status == "" {
+				return fmt.Errorf("alerts.rules[%d]: %s requires status", i, r.Kind)
+			}
+			if r.MaxAge < 0 || r.MaxAge > 0 && r.Status != "stale" && r.Status != "stuck" {
+				return fmt.Errorf("alerts.rules[%d]: max_age only applies to status stale or stuck", i)
+			}
+		case AlertCPU:
+			if r.Threshold <= 0 {
+				return fmt.Errorf("alerts.rules[%d]: %s requires threshold > 0", i, r.Kind)
+			}
+			if r.Samples < 1 {
+				return fmt.Errorf("alerts.rules[%d]: %s requires samples >= 1", i, r.Kind)
+			}
+		case AlertWitnessHeartbeat:
+			if r.MaxAge < 1 {
+				return fmt.Errorf("alerts.rules[%d]: %s requires max_age >= 1", i, r.Kind)
+			}
+		case AlertQueueDepth:
+			if r.Threshold < 1 {
+				return fmt.Errorf("alerts.rules[%d]: %s requires threshold >= 1", i, r.Kind)
+			}
+		case AlertCIFailure, AlertConvoyComplete:
+		default:
+			return fmt.Errorf("alerts.rules[%d]: unknown kind %q", i, r.Kind)
+		}
+	}
+	return nil
+}
+
+func validateNotify(n Notify) error {
+	seen := make(map[string]bool)
+	for i, w := range n.Webhooks {
+		if w.Name == "" {
+			return fmt.Errorf("notify.webhooks[%d]: name is required", i)
+		}
+		if seen[w.Name] {
+			return fmt.Errorf("notify.webhooks[%d]: duplicate webhook name %q", i, w.Name)
+		}
+		seen[w.Name] = true
+
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("notify.webhooks[%d]: url must be an http(s) URL", i)
+		}
+		if w.Retries < 0 {
+			return fmt.Errorf("notify.webhooks[%d]: retries must be >= 0", i)
+		}
+		if w.RateLimit < 0 {
+			return fmt.Errorf("notify.webhooks[%d]: rate_limit must be >= 0", i)
+		}
+	}
 	return nil
 }
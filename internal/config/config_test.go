@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefault(t *testing.T) {
@@ -24,6 +26,9 @@ func TestDefault(t *testing.T) {
 	if cfg.PollInterval.Witnesses != 10 {
 		t.Errorf("expected witnesses interval 10, got %d", cfg.PollInterval.Witnesses)
 	}
+	if cfg.EventHistory != 100 {
+		t.Errorf("expected event history 100, got %d", cfg.EventHistory)
+	}
 }
 
 func TestLoadMissingFile(t *testing.T) {
@@ -43,6 +48,7 @@ func TestLoadValidYAML(t *testing.T) {
 	data := []byte(`port: 3333
 town_root: /tmp/gt
 host_key_dir: /tmp/keys
+state_dir: /tmp/sessions
 poll_interval:
   status: 20
   agents: 10
@@ -66,6 +72,9 @@ poll_interval:
 	if cfg.HostKeyDir != "/tmp/keys" {
 		t.Errorf("expected host_key_dir /tmp/keys, got %s", cfg.HostKeyDir)
 	}
+	if cfg.StateDir != "/tmp/sessions" {
+		t.Errorf("expected state_dir /tmp/sessions, got %s", cfg.StateDir)
+	}
 	if cfg.PollInterval.Status != 20 {
 		t.Errorf("expected status 20, got %d", cfg.PollInterval.Status)
 	}
@@ -133,6 +142,22 @@ func TestLoadInvalidPollInterval(t *testing.T) {
 	}
 }
 
+func TestLoadInvalidEventHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kestral.yaml")
+
+	data := []byte(`event_history: 0
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected validation error for zero event_history")
+	}
+}
+
 func TestLoadInvalidYAML(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "kestral.yaml")
@@ -183,3 +208,480 @@ func TestLoadPortZero(t *testing.T) {
 		t.Fatal("expected validation error for port 0")
 	}
 }
+
+func TestDefaultAlertRulesValid(t *testing.T) {
+	cfg := Default()
+	if len(cfg.Alerts.Rules) == 0 {
+		t.Fatal("expected default alert rules")
+	}
+	if err := validate(cfg); err != nil {
+		t.Fatalf("default config should validate: %v", err)
+	}
+}
+
+func TestLoadAlertRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kestral.yaml")
+
+	data := []byte(`alerts:
+  cooldown: 600
+  rules:
+    - name: hot
+      kind: cpu
+      threshold: 85
+      samples: 3
+      bell: true
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Alerts.Cooldown != 600 {
+		t.Errorf("expected cooldown 600, got %d", cfg.Alerts.Cooldown)
+	}
+	if len(cfg.Alerts.Rules) != 1 {
+		t.Fatalf("configured rules should replace defaults, got %d rules", len(cfg.Alerts.Rules))
+	}
+	r := cfg.Alerts.Rules[0]
+	if r.Name != "hot" || r.Kind != AlertCPU || r.Threshold != 85 || r.Samples != 3 || !r.Bell {
+		t.Errorf("unexpected rule: %+v", r)
+	}
+}
+
+func TestLoadInvalidAlertRules(t *testing.T) {
+	tests := map[string]string{
+		"unknown kind": `alerts:
+  rules:
+    - name: x
+      kind: nope
+`,
+		"missing name": `alerts:
+  rules:
+    - kind: ci_failure
+`,
+		"duplicate name": `alerts:
+  rules:
+    - name: x
+      kind: ci_failure
+    - name: x
+      kind: convoy_complete
+`,
+		"cpu without samples": `alerts:
+  rules:
+    - name: x
+      kind: cpu
+      threshold: 90
+`,
+		"agent without status": `alerts:
+  rules:
+    - name: x
+      kind: agent_status
+`,
+		"bad severity": `alerts:
+  rules:
+    - name: x
+      kind: ci_failure
+      severity: loud
+`,
+		"negative cooldown": `alerts:
+  cooldown: -1
+`,
+	}
+
+	for name, body := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "kestral.yaml")
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestLoadWebhooks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kestral.yaml")
+
+	data := []byte(`notify:
+  webhooks:
+    - name: ntfy
+      url: https://ntfy.sh/kestral-oncall
+      events: [agent_stuck, witness_dead]
+      template: "{{.Message}}"
+      headers:
+        Title: Kestral
+      retries: 3
+      rate_limit: 10
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Notify.Webhooks) != 1 {
+		t.Fatalf("expected 1 webhook, got %d", len(cfg.Notify.Webhooks))
+	}
+	w := cfg.Notify.Webhooks[0]
+	if w.URL != "https://ntfy.sh/kestral-oncall" || len(w.Events) != 2 || w.Headers["Title"] != "Kestral" {
+		t.Errorf("unexpected webhook %+v", w)
+	}
+	if w.Retries != 3 || w.RateLimit != 10 {
+		t.Errorf("retries/rate_limit = %d/%d, want 3/10", w.Retries, w.RateLimit)
+	}
+}
+
+func TestLoadInvalidWebhooks(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{"missing name", "notify:\n  webhooks:\n    - url: http://localhost\n"},
+		{"bad url", "notify:\n  webhooks:\n    - name: a\n      url: localhost:8080\n"},
+		{"duplicate", "notify:\n  webhooks:\n    - name: a\n      url: http://x\n    - name: a\n      url: http://y\n"},
+		{"negative retries", "notify:\n  webhooks:\n    - name: a\n      url: http://x\n      retries: -1\n"},
+		{"negative rate limit", "notify:\n  webhooks:\n    - name: a\n      url: http://x\n      rate_limit: -1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "kestral.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestLoadAPIRequiresToken(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kestral.yaml")
+
+	if err := os.WriteFile(path, []byte("api:\n  listen: \":8080\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected validation error for api.listen without token")
+	}
+
+	if err := os.WriteFile(path, []byte("api:\n  listen: \":8080\"\n  token: s3cret\ncache_ttl: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.API.Listen != ":8080" || cfg.API.Token != "s3cret" || cfg.CacheTTL != 0 {
+		t.Errorf("unexpected api config %+v, cache_ttl %d", cfg.API, cfg.CacheTTL)
+	}
+}
+
+func TestLoadMetricsListenConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	data := []byte("api:\n  listen: \":8080\"\n  token: t\nmetrics:\n  listen: \":8080\"\n")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected validation error for metrics and api on the same address")
+	}
+}
+
+func TestLoadLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	data := []byte("layout:\n  mode: grid\n  panes: [Agents, Refinery, PRs, Events]\n")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Layout.Mode != "grid" || len(cfg.Layout.Panes) != 4 {
+		t.Errorf("unexpected layout %+v", cfg.Layout)
+	}
+
+	for _, bad := range []string{
+		"layout:\n  mode: tiled\n",
+		"layout:\n  mode: grid\n  panes: [A, B, C, D, E]\n",
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoadKeybindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	data := []byte("keybindings:\n  global:\n    help: [H]\n  history:\n    filter_date: [d]\n")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.Keybindings["global"]["help"]; len(got) != 1 || got[0] != "H" {
+		t.Errorf("global help keys = %v", got)
+	}
+
+	for _, bad := range []string{
+		"keybindings:\n  global:\n    help: [q]\n",         // conflicts with quit
+		"keybindings:\n  history:\n    filter_date: [r]\n", // shadowed by global refresh
+		"keybindings:\n  agents:\n    teleport: [x]\n",     // unknown action
+		"keybindings:\n  dashboard2:\n    up: [x]\n",       // unknown scope
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoadAgentStates(t *testing.T) {
+	if Default().AgentClassifier() == nil {
+		t.Error("the built-in agent state rules should be on by default")
+	}
+
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	custom := []byte("agent_states:\n  - {state: waiting, pattern: 'Approve\\?', lines: 3}\n")
+	if err := os.WriteFile(path, custom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.AgentStates) != 1 || cfg.AgentStates[0].Lines != 3 {
+		t.Errorf("AgentStates = %+v, want the one custom rule", cfg.AgentStates)
+	}
+	if got := cfg.AgentClassifier().Classify("Approve?\n"); got != "waiting" {
+		t.Errorf("custom rule classified %q, want waiting", got)
+	}
+
+	if err := os.WriteFile(path, []byte("agent_states: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err := Load(path); err != nil || cfg.AgentClassifier() != nil {
+		t.Errorf("an empty agent_states list should turn detection off (err %v)", err)
+	}
+
+	for _, bad := range []string{
+		"agent_states:\n  - {state: sleepy, pattern: zzz}\n",
+		"agent_states:\n  - {state: waiting, pattern: '(unclosed'}\n",
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoadThemes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	data := []byte("theme: nord\nthemes:\n  nord:\n    pass: \"#a3be8c\"\n    accent: \"110\"\n")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Theme != "nord" || cfg.Themes["nord"].Pass != "#a3be8c" {
+		t.Errorf("unexpected theme config %q %+v", cfg.Theme, cfg.Themes)
+	}
+
+	for _, bad := range []string{
+		"theme: neon\n", // not defined
+		"themes:\n  nord:\n    fail: red\n",
+		"themes:\n  nord:\n    bar: \"256\"\n",
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoadPanes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	data := []byte(`panes:
+  - Agents
+  - {name: Witnesses, key: "2"}
+users:
+  alice:
+    panes: [Mail]
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []PaneRef{{Name: "Agents"}, {Name: "Witnesses", Key: "2"}}
+	if len(cfg.Panes) != 2 || cfg.Panes[0] != want[0] || cfg.Panes[1] != want[1] {
+		t.Errorf("panes = %+v, want %+v", cfg.Panes, want)
+	}
+	if got := cfg.ForUser("alice", "SHA256:x").Panes; len(got) != 1 || got[0].Name != "Mail" {
+		t.Errorf("alice's panes = %+v", got)
+	}
+
+	for _, bad := range []string{
+		"panes: [Agents, agents]\n",
+		"panes:\n  - {name: Agents, key: \"10\"}\n",
+		"panes:\n  - {name: Agents, key: \"1\"}\n  - {name: Mail, key: \"1\"}\n",
+		"users:\n  bob:\n    panes:\n      - {key: \"1\"}\n",
+		"panes: [Agnets]\n",
+		"users:\n  bob:\n    panes: [Witness]\n",
+		"layout:\n  panes: [Agents, Refinary]\n",
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected validation error for %q", bad)
+		}
+	}
+}
+
+func TestLoadPanesListsValidTitles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	if err := os.WriteFile(path, []byte("panes: [Agents, Mial]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), `"Mial"`) || !strings.Contains(err.Error(), "Witnesses") {
+		t.Errorf("err = %v, want it to name the bad pane and list the valid ones", err)
+	}
+}
+
+func TestForUserPrefersFingerprint(t *testing.T) {
+	cfg := Default()
+	cfg.Panes = []PaneRef{{Name: "Agents"}}
+	cfg.Users = map[string]User{
+		"alice":      {Panes: []PaneRef{{Name: "Mail"}}},
+		"SHA256:key": {Panes: []PaneRef{{Name: "PRs"}}},
+	}
+	if got := cfg.ForUser("alice", "SHA256:key").Panes[0].Name; got != "PRs" {
+		t.Errorf("fingerprint override should win, got %s", got)
+	}
+	if got := cfg.ForUser("alice", "SHA256:other").Panes[0].Name; got != "Mail" {
+		t.Errorf("user name override should apply, got %s", got)
+	}
+	if got := cfg.ForUser("carol", "").Panes[0].Name; got != "Agents" {
+		t.Errorf("users without overrides keep the shared panes, got %s", got)
+	}
+}
+
+func TestLoadTowns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kestral.yaml")
+	data := []byte(`poll_interval:
+  agents: 7
+towns:
+  - name: alpha
+    root: /srv/alpha
+  - name: beta
+    root: /srv/beta
+    poll_interval:
+      agents: 30
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	towns := cfg.TownList()
+	if len(towns) != 2 || towns[0].Name != "alpha" || towns[1].Root != "/srv/beta" {
+		t.Fatalf("towns = %+v", towns)
+	}
+	if towns[0].PollInterval.Agents != 7 || towns[1].PollInterval.Agents != 30 {
+		t.Errorf("agent intervals = %d, %d, want 7 and 30",
+			towns[0].PollInterval.Agents, towns[1].PollInterval.Agents)
+	}
+	if towns[1].PollInterval.Status != cfg.PollInterval.Status {
+		t.Error("unset town intervals should inherit poll_interval")
+	}
+	if beta := cfg.ForTown(towns[1]); beta.TownRoot != "/srv/beta" || beta.PollInterval.Agents != 30 {
+		t.Errorf("ForTown(beta) = root %s, agents %d", beta.TownRoot, beta.PollInterval.Agents)
+	}
+
+	for _, bad := range []string{
+		"towns:\n  - {root: /srv/a}\n",
+		"towns:\n  - {name: a}\n",
+		"towns:\n  - {name: a, root: /srv/a}\n  - {name: a, root: /srv/b}\n",
+		"towns:\n  - {name: a, root: /srv/a, poll_interval: {status: -1}}\n",
+	} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestTownListDefaultsToTownRoot(t *testing.T) {
+	cfg := Default()
+	cfg.TownRoot = "/home/me/gt"
+	towns := cfg.TownList()
+	if len(towns) != 1 || towns[0].Name != "gt" || towns[0].Root != "/home/me/gt" {
+		t.Errorf("TownList() = %+v", towns)
+	}
+}
+
+func TestThresholdsFollowAlertRules(t *testing.T) {
+	def := Default().Thresholds()
+	if def.AgentStuck != 30*time.Minute || def.WitnessDead != 15*time.Minute || def.CPUAlert != 95 {
+		t.Errorf("default thresholds = %+v, want them to match the default rules", def)
+	}
+
+	cfg := Default()
+	cfg.Alerts.Rules = []AlertRule{
+		{Name: "stuck", Kind: AlertAgentStatus, Status: "stuck", MaxAge: 600},
+		{Name: "witness", Kind: AlertWitnessHeartbeat, MaxAge: 240},
+		{Name: "cpu-hot", Kind: AlertCPU, Threshold: 90, Samples: 6},
+		{Name: "cpu-warm", Kind: AlertCPU, Threshold: 60, Samples: 2},
+	}
+	got := cfg.Thresholds()
+	if got.AgentStuck != 10*time.Minute || got.AgentStale != 5*time.Minute {
+		t.Errorf("agent thresholds = %v/%v, want 5m/10m", got.AgentStale, got.AgentStuck)
+	}
+	if got.WitnessDead != 4*time.Minute || got.WitnessStale != 4*time.Minute {
+		t.Errorf("witness thresholds = %v/%v, want stale capped at the 4m dead age", got.WitnessStale, got.WitnessDead)
+	}
+	if got.CPUAlert != 90 || got.CPUAlertSamples != 6 || got.CPUWarn != 60 || got.CPUWarnSamples != 2 {
+		t.Errorf("cpu thresholds = %+v, want alert 90/6 and warn 60/2", got)
+	}
+
+	cfg.Alerts.Rules = []AlertRule{{Name: "cpu", Kind: AlertCPU, Threshold: 70, Samples: 3}}
+	if got := cfg.Thresholds(); got.CPUWarn != 70 || got.CPUWarnSamples != 3 {
+		t.Errorf("warn level = %v/%d, want it capped at the 70%% alert rule", got.CPUWarn, got.CPUWarnSamples)
+	}
+
+	cfg.Alerts.Rules = []AlertRule{{Name: "w", Kind: AlertAgentStatus, Status: "working", MaxAge: 60}}
+	if err := validateAlerts(cfg.Alerts); err == nil {
+		t.Error("max_age on a working rule should be rejected")
+	}
+}
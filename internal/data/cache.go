@@ -0,0 +1,199 @@
+package data
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Source is the set of fetches used by the TUI and the HTTP API. It is
+// implemented by *Fetcher and by *Cache.
+type Source interface {
+	FetchRigs() ([]string, error)
+	FetchStatus() (*TownStatus, error)
+	FetchSessions() ([]SessionInfo, error)
+	FetchAgents() ([]AgentDetail, error)
+	FetchMail() ([]MailMessage, error)
+	FetchRefineryStatus() ([]RefineryStatus, error)
+	FetchResources() ([]SessionResource, error)
+	FetchPullRequests() ([]PRInfo, error)
+	FetchConvoys() ([]ConvoyInfo, error)
+	FetchClosedBeads() ([]ClosedBeadInfo, error)
+	FetchReady() ([]ReadyIssue, error)
+	FetchStrandedConvoys() ([]StrandedConvoy, error)
+	FetchServices() ([]ServiceStatus, error)
+	FetchAllConvoys() ([]AllConvoyInfo, error)
+	FetchTrackedIssues(convoyID string) ([]IssueDetail, error)
+	FetchAgentBranch(rig, name string) string
+	FetchAgentCommits(rig, name string, count int) []CommitInfo
+	FetchAgentOutput(rig, name string, lines int) string
+	FetchWitnesses() ([]WitnessDetail, error)
+	FetchRigPolecats(rig string) ([]PolecatStatus, error)
+}
+
+// Cache wraps a Fetcher so that concurrent SSH sessions and API requests
+// share results instead of each shelling out. Town-wide fetches are cached
+// for TTL; per-agent and per-convoy fetches pass straight through.
+type Cache struct {
+	*Fetcher
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry holds the last successful result for one fetch. Its mutex is
+// held while fetching so concurrent callers wait for a single command.
+type cacheEntry struct {
+	mu        sync.Mutex
+	value     any
+	fetchedAt time.Time
+}
+
+// NewCache creates a Cache over f. A ttl of zero disables caching.
+func NewCache(f *Fetcher, ttl time.Duration) *Cache {
+	return &Cache{
+		Fetcher: f,
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// cached returns the cached value for key if it is younger than the TTL,
+// otherwise it calls fetch and caches a successful result.
+func cached[T any](c *Cache, key string, fetch func() (T, error)) (T, error) {
+	if c.ttl <= 0 {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &cacheEntry{}
+		c.entries[key] = e
+	}
+	c.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.fetchedAt.IsZero() && time.Since(e.fetchedAt) < c.ttl {
+		return ownCopy(e.value.(T)), nil
+	}
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	e.value = v
+	e.fetchedAt = time.Now()
+	return ownCopy(v), nil
+}
+
+// ownCopy returns a cached value for one caller to keep. Slices are copied,
+// so a pane sorting its result in place can't reorder what other sessions
+// are reading.
+func ownCopy[T any](v T) T {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.IsNil() {
+		return v
+	}
+	cp := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+	reflect.Copy(cp, rv)
+	return cp.Interface().(T)
+}
+
+// Invalidate drops the cached results for keys, so the next fetch sees a
+// change just made through gt or bd.
+func (c *Cache) Invalidate(keys ...string) {
+	for _, key := range keys {
+		c.mu.Lock()
+		e, ok := c.entries[key]
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		e.mu.Lock()
+		e.fetchedAt = time.Time{}
+		e.mu.Unlock()
+	}
+}
+
+// FetchRigs returns cached rig names.
+func (c *Cache) FetchRigs() ([]string, error) {
+	return cached(c, "rigs", c.Fetcher.FetchRigs)
+}
+
+// FetchStatus returns cached town status.
+func (c *Cache) FetchStatus() (*TownStatus, error) {
+	return cached(c, "status", c.Fetcher.FetchStatus)
+}
+
+// FetchSessions returns cached tmux sessions.
+func (c *Cache) FetchSessions() ([]SessionInfo, error) {
+	return cached(c, "sessions", c.Fetcher.FetchSessions)
+}
+
+// FetchAgents returns cached agent details.
+func (c *Cache) FetchAgents() ([]AgentDetail, error) {
+	return cached(c, "agents", c.Fetcher.FetchAgents)
+}
+
+// FetchMail returns the cached mail inbox.
+func (c *Cache) FetchMail() ([]MailMessage, error) {
+	return cached(c, "mail", c.Fetcher.FetchMail)
+}
+
+// FetchRefineryStatus returns cached refinery status.
+func (c *Cache) FetchRefineryStatus() ([]RefineryStatus, error) {
+	return cached(c, "refinery", c.Fetcher.FetchRefineryStatus)
+}
+
+// FetchResources returns cached session resource usage.
+func (c *Cache) FetchResources() ([]SessionResource, error) {
+	return cached(c, "resources", c.Fetcher.FetchResources)
+}
+
+// FetchPullRequests returns cached open PRs.
+func (c *Cache) FetchPullRequests() ([]PRInfo, error) {
+	return cached(c, "prs", c.Fetcher.FetchPullRequests)
+}
+
+// FetchConvoys returns cached open convoys.
+func (c *Cache) FetchConvoys() ([]ConvoyInfo, error) {
+	return cached(c, "convoys", c.Fetcher.FetchConvoys)
+}
+
+// FetchClosedBeads returns cached closed beads.
+func (c *Cache) FetchClosedBeads() ([]ClosedBeadInfo, error) {
+	return cached(c, "closed", c.Fetcher.FetchClosedBeads)
+}
+
+// FetchServices returns the cached state of the Mayor and the Deacon.
+func (c *Cache) FetchServices() ([]ServiceStatus, error) {
+	return cached(c, "services", c.Fetcher.FetchServices)
+}
+
+// FetchStrandedConvoys returns cached stranded convoys.
+func (c *Cache) FetchStrandedConvoys() ([]StrandedConvoy, error) {
+	return cached(c, "stranded", c.Fetcher.FetchStrandedConvoys)
+}
+
+// FetchReady returns cached ready issues.
+func (c *Cache) FetchReady() ([]ReadyIssue, error) {
+	return cached(c, "ready", c.Fetcher.FetchReady)
+}
+
+// FetchAllConvoys returns cached convoys including closed ones.
+func (c *Cache) FetchAllConvoys() ([]AllConvoyInfo, error) {
+	return cached(c, "all-convoys", c.Fetcher.FetchAllConvoys)
+}
+
+// FetchWitnesses returns cached witness details.
+func (c *Cache) FetchWitnesses() ([]WitnessDetail, error) {
+	return cached(c, "witnesses", c.Fetcher.FetchWitnesses)
+}
+
+// Ensure both implementations satisfy Source at compile time.
+var (
+	_ Source = (*Fetcher)(nil)
+	_ Source = (*Cache)(nil)
+)
@@ -0,0 +1,152 @@
+package data
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheReusesFreshResult(t *testing.T) {
+	c := NewCache(&Fetcher{}, time.Minute)
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"kt"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := cached(c, "rigs", fetch)
+		if err != nil || len(got) != 1 || got[0] != "kt" {
+			t.Fatalf("cached() = %v, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+}
+
+func TestCacheExpires(t *testing.T) {
+	c := NewCache(&Fetcher{}, time.Minute)
+	calls := 0
+	fetch := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	cached(c, "n", fetch)
+	c.entries["n"].fetchedAt = time.Now().Add(-2 * time.Minute)
+	if got, _ := cached(c, "n", fetch); got != 2 {
+		t.Errorf("expired entry should be refetched, got %d", got)
+	}
+}
+
+func TestCacheDoesNotStoreErrors(t *testing.T) {
+	c := NewCache(&Fetcher{}, time.Minute)
+	fail := true
+	fetch := func() (string, error) {
+		if fail {
+			return "", errors.New("boom")
+		}
+		return "ok", nil
+	}
+
+	if _, err := cached(c, "k", fetch); err == nil {
+		t.Fatal("expected error")
+	}
+	fail = false
+	if got, err := cached(c, "k", fetch); err != nil || got != "ok" {
+		t.Errorf("after error, cached() = %q, %v; want fresh fetch", got, err)
+	}
+}
+
+func TestCacheZeroTTLPassesThrough(t *testing.T) {
+	c := NewCache(&Fetcher{}, 0)
+	calls := 0
+	fetch := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+	cached(c, "n", fetch)
+	cached(c, "n", fetch)
+	if calls != 2 {
+		t.Errorf("fetch called %d times, want 2 with caching disabled", calls)
+	}
+}
+
+func TestCacheSharesConcurrentFetch(t *testing.T) {
+	c := NewCache(&Fetcher{}, time.Minute)
+	var mu sync.Mutex
+	calls := 0
+	fetch := func() (int, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return 1, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cached(c, "n", fetch)
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("concurrent callers should share one fetch, got %d", calls)
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	c := NewCache(&Fetcher{}, time.Minute)
+	calls := 0
+	fetch := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	cached(c, "n", fetch)
+	c.Invalidate("n", "missing")
+	if got, _ := cached(c, "n", fetch); got != 2 {
+		t.Errorf("invalidated entry should be refetched, got %d", got)
+	}
+	if got, _ := cached(c, "n", fetch); got != 2 {
+		t.Errorf("refetched entry should be cached again, got %d", got)
+	}
+}
+
+// Run with -race: each consumer sorts its result in place, as the
+// Resources pane does.
+func TestCacheConsumersGetTheirOwnSlice(t *testing.T) {
+	c := NewCache(&Fetcher{}, time.Minute)
+	fetch := func() ([]SessionResource, error) {
+		return []SessionResource{{Name: "b", CPUPercent: 1}, {Name: "a", CPUPercent: 2}, {Name: "c", CPUPercent: 3}}, nil
+	}
+	cached(c, "resources", fetch)
+
+	var wg sync.WaitGroup
+	for _, byName := range []bool{true, false} {
+		wg.Add(1)
+		go func(byName bool) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				got, _ := cached(c, "resources", fetch)
+				sort.Slice(got, func(i, j int) bool {
+					if byName {
+						return got[i].Name < got[j].Name
+					}
+					return got[i].CPUPercent > got[j].CPUPercent
+				})
+			}
+		}(byName)
+	}
+	wg.Wait()
+
+	if got, _ := cached(c, "resources", fetch); got[0].Name != "b" {
+		t.Errorf("a consumer's sort reached the cached slice: %+v", got)
+	}
+}
@@ -0,0 +1,176 @@
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Agent states read from a session's output. They refine the activity-based
+// status: an agent sitting at a permission prompt still looks "working" by
+// its tmux activity alone.
+const (
+	StateWaiting     = "waiting"      // blocked on a prompt for input
+	StateErrorLoop   = "error_loop"   // printing the same error over and over
+	StateRateLimited = "rate_limited" // held back by an API rate limit
+	StateDone        = "done"         // finished, its work awaiting merge
+)
+
+// AgentStates lists every state a rule can detect.
+var AgentStates = []string{StateWaiting, StateErrorLoop, StateRateLimited, StateDone}
+
+// agentSampleLines is how much of a session's scrollback FetchAgents reads
+// to classify it.
+const agentSampleLines = 40
+
+// agentResampleInterval is the least time between two samples of a session
+// that keeps printing. A quiet session is not sampled again at all: its
+// screen hasn't changed since the last one.
+const agentResampleInterval = 15 * time.Second
+
+// sampledState is the last classification of one session.
+type sampledState struct {
+	activity  int64 // the session's activity timestamp when sampled
+	state     string
+	sampledAt time.Time
+}
+
+// agentState returns the state of session, whose last activity was at the
+// unix time activity. sample captures its output; it is only called when
+// the session has printed since its last sample and that sample is older
+// than agentResampleInterval, so a poll doesn't run one tmux capture-pane
+// per agent.
+func (f *Fetcher) agentState(session string, activity int64, now time.Time, sample func() string) string {
+	f.statesMu.Lock()
+	last, ok := f.sampled[session]
+	f.statesMu.Unlock()
+	if ok && (last.activity == activity || now.Sub(last.sampledAt) < agentResampleInterval) {
+		return last.state
+	}
+
+	state := f.States.Classify(sample())
+	f.statesMu.Lock()
+	defer f.statesMu.Unlock()
+	if f.sampled == nil {
+		f.sampled = make(map[string]sampledState)
+	}
+	f.sampled[session] = sampledState{activity: activity, state: state, sampledAt: now}
+	return state
+}
+
+// forgetStates drops the samples of sessions not in live.
+func (f *Fetcher) forgetStates(live map[string]bool) {
+	f.statesMu.Lock()
+	defer f.statesMu.Unlock()
+	for session := range f.sampled {
+		if !live[session] {
+			delete(f.sampled, session)
+		}
+	}
+}
+
+// StateRule detects an agent state from the tail of its session output.
+// Pattern is a Go regular expression matched against each line.
+type StateRule struct {
+	State   string `yaml:"state"`
+	Pattern string `yaml:"pattern"`
+	// Lines limits the match to the last N non-blank lines; 0 means the
+	// whole sample. A prompt only matters while it is still on screen.
+	Lines int `yaml:"lines"`
+	// Repeat is how many times the same matched text must appear; 0 or 1
+	// means once.
+	Repeat int `yaml:"repeat"`
+}
+
+// DefaultStateRules returns the built-in rules for Claude Code sessions and
+// the gt commands polecats run.
+func DefaultStateRules() []StateRule {
+	return []StateRule{
+		{State: StateWaiting, Lines: 8,
+			Pattern: `(?i)(do you want to (proceed|make this edit|create)|\(y/n\)|\[y/N\]|press enter to continue|waiting for (your )?input)`},
+		{State: StateRateLimited, Lines: 10,
+			Pattern: `(?i)(rate.limit|429 too many requests|usage limit reached|overloaded_error)`},
+		{State: StateErrorLoop, Repeat: 3,
+			Pattern: `(?i)^\W*(error|fatal|panic)\b.*`},
+		{State: StateDone, Lines: 10,
+			Pattern: `(?i)(gt done|submitted to (the )?merge queue|awaiting merge)`},
+	}
+}
+
+// Classifier assigns agent states from session output with an ordered list
+// of compiled rules. The first rule that matches wins.
+type Classifier struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	StateRule
+	re *regexp.Regexp
+}
+
+// NewClassifier compiles rules, rejecting unknown states and bad patterns.
+func NewClassifier(rules []StateRule) (*Classifier, error) {
+	c := &Classifier{}
+	for i, r := range rules {
+		known := false
+		for _, s := range AgentStates {
+			if r.State == s {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("agent_states[%d]: state must be one of %s, got %q",
+				i, strings.Join(AgentStates, ", "), r.State)
+		}
+		if r.Lines < 0 || r.Repeat < 0 {
+			return nil, fmt.Errorf("agent_states[%d]: lines and repeat must be >= 0", i)
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("agent_states[%d]: pattern: %w", i, err)
+		}
+		c.rules = append(c.rules, compiledRule{r, re})
+	}
+	return c, nil
+}
+
+// Classify returns the state of the first rule matching output, or "" if
+// none does.
+func (c *Classifier) Classify(output string) string {
+	if c == nil {
+		return ""
+	}
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	for _, r := range c.rules {
+		if r.matches(lines) {
+			return r.State
+		}
+	}
+	return ""
+}
+
+// matches reports whether the rule holds for the non-blank output lines.
+func (r compiledRule) matches(lines []string) bool {
+	if r.Lines > 0 && len(lines) > r.Lines {
+		lines = lines[len(lines)-r.Lines:]
+	}
+	counts := make(map[string]int)
+	for _, line := range lines {
+		m := r.re.FindString(line)
+		if m == "" && !r.re.MatchString(line) {
+			continue
+		}
+		m = strings.TrimSpace(m)
+		counts[m]++
+		if counts[m] >= max(r.Repeat, 1) {
+			return true
+		}
+	}
+	return false
+}
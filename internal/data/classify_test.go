@@ -0,0 +1,111 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifierDefaultRules(t *testing.T) {
+	c, err := NewClassifier(DefaultStateRules())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"working", "Reading internal/app/app.go\nRunning go test ./...\nok  kt/internal/app\n", ""},
+		{"permission prompt", "Bash(go test ./...)\n\n Do you want to proceed?\n ❯ 1. Yes\n   2. No\n", StateWaiting},
+		{"answered prompt scrolled away", "Do you want to proceed?\n" + lines("editing file", 12), ""},
+		{"rate limited", "API Error: 429 Too Many Requests\nRetrying in 30s…\n", StateRateLimited},
+		{"same error three times", "error: cannot find module kt/x\nretrying\nerror: cannot find module kt/x\nretrying\nerror: cannot find module kt/x\n", StateErrorLoop},
+		{"different errors", "error: one\nerror: two\nerror: three\n", ""},
+		{"done", "All tests pass.\n$ gt done\nSubmitted to merge queue as kt-mr12\n", StateDone},
+	}
+	for _, tt := range tests {
+		if got := c.Classify(tt.output); got != tt.want {
+			t.Errorf("%s: Classify() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClassifierFirstRuleWins(t *testing.T) {
+	c, err := NewClassifier([]StateRule{
+		{State: StateDone, Pattern: `gt done`},
+		{State: StateWaiting, Pattern: `\(y/n\)`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Classify("gt done\nSubmit anyway? (y/n)\n"); got != StateDone {
+		t.Errorf("Classify() = %q, want %q", got, StateDone)
+	}
+	var none *Classifier
+	if got := none.Classify("(y/n)"); got != "" {
+		t.Errorf("nil classifier = %q, want no state", got)
+	}
+}
+
+func TestNewClassifierRejectsBadRules(t *testing.T) {
+	for _, r := range []StateRule{
+		{State: "sleepy", Pattern: `zzz`},
+		{State: StateWaiting, Pattern: `(unclosed`},
+		{State: StateWaiting, Pattern: `x`, Lines: -1},
+	} {
+		if _, err := NewClassifier([]StateRule{r}); err == nil {
+			t.Errorf("NewClassifier(%+v) should fail", r)
+		}
+	}
+}
+
+// lines returns n copies of s, one per line.
+func lines(s string, n int) string {
+	var out string
+	for i := 0; i < n; i++ {
+		out += s + "\n"
+	}
+	return out
+}
+
+func TestFetcherAgentStateSamplesOnlyWhenChanged(t *testing.T) {
+	c, err := NewClassifier(DefaultStateRules())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &Fetcher{States: c}
+	samples := 0
+	output := "Do you want to proceed? (y/n)"
+	sample := func() string { samples++; return output }
+
+	now := time.Now()
+	if got := f.agentState("gt-kt-amber", 100, now, sample); got != StateWaiting || samples != 1 {
+		t.Fatalf("first poll: state = %q after %d samples, want waiting after 1", got, samples)
+	}
+
+	// No new output: the last state stands, however long ago it was read.
+	output = ""
+	if got := f.agentState("gt-kt-amber", 100, now.Add(time.Hour), sample); got != StateWaiting || samples != 1 {
+		t.Errorf("quiet session: state = %q after %d samples, want waiting after 1", got, samples)
+	}
+
+	// New output is sampled, but no more than once per interval.
+	later := now.Add(time.Hour)
+	f.agentState("gt-kt-amber", 200, later, sample)
+	if samples != 2 {
+		t.Fatalf("a session that printed should be sampled again, samples = %d", samples)
+	}
+	if got := f.agentState("gt-kt-amber", 300, later.Add(agentResampleInterval/2), sample); got != "" || samples != 2 {
+		t.Errorf("busy session within the interval: state = %q after %d samples, want \"\" after 2", got, samples)
+	}
+	f.agentState("gt-kt-amber", 300, later.Add(agentResampleInterval), sample)
+	if samples != 3 {
+		t.Errorf("busy session after the interval should be sampled, samples = %d", samples)
+	}
+
+	f.forgetStates(map[string]bool{"gt-kt-onyx": true})
+	f.agentState("gt-kt-amber", 300, later.Add(agentResampleInterval), sample)
+	if samples != 4 {
+		t.Errorf("a forgotten session should be sampled afresh, samples = %d", samples)
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -24,18 +26,36 @@ const (
 // Fetcher shells out to gt/bd/gh/tmux CLIs to fetch data.
 type Fetcher struct {
 	TownRoot string // path to gt workspace
+	// States classifies agents from their session output; nil leaves
+	// them to their activity-based status.
+	States *Classifier
+	// Thresholds sets the agent and witness status cutoffs; the zero
+	// value uses DefaultThresholds.
+	Thresholds Thresholds
+
+	statesMu sync.Mutex
+	sampled  map[string]sampledState // session name -> last classification
 }
 
 // runCmd executes a command with a timeout and returns stdout.
 func runCmd(timeout time.Duration, name string, args ...string) (*bytes.Buffer, error) {
+	return runCmdIn("", timeout, name, args...)
+}
+
+// runCmdIn is runCmd in directory dir; "" means the current directory.
+func runCmdIn(dir string, timeout time.Duration, name string, args ...string) (*bytes.Buffer, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
 	var stdout bytes.Buffer
 	cmd.Stdout = &stdout
 
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err := cmd.Run()
+	recordCmd(name, args, time.Since(start), err)
+	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("%s timed out after %v", name, timeout)
 		}
@@ -44,6 +64,13 @@ func runCmd(timeout time.Duration, name string, args ...string) (*bytes.Buffer,
 	return &stdout, nil
 }
 
+// runGtCmd executes a gt command with cmdTimeout in TownRoot, so gt
+// resolves this fetcher's town rather than the one containing the current
+// directory.
+func (f *Fetcher) runGtCmd(args ...string) (*bytes.Buffer, error) {
+	return runCmdIn(f.TownRoot, cmdTimeout, "gt", args...)
+}
+
 // runBdCmd executes a bd command with cmdTimeout in TownRoot.
 func (f *Fetcher) runBdCmd(args ...string) (*bytes.Buffer, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
@@ -54,7 +81,14 @@ func (f *Fetcher) runBdCmd(args ...string) (*bytes.Buffer, error) {
 	var stdout bytes.Buffer
 	cmd.Stdout = &stdout
 
+	start := time.Now()
 	err := cmd.Run()
+	// Output on a non-zero exit is still used below, so it isn't an error.
+	recErr := err
+	if stdout.Len() > 0 && ctx.Err() == nil {
+		recErr = nil
+	}
+	recordCmd("bd", args, time.Since(start), recErr)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("bd timed out after %v", cmdTimeout)
@@ -70,7 +104,7 @@ func (f *Fetcher) runBdCmd(args ...string) (*bytes.Buffer, error) {
 
 // FetchRigs runs gt rig list and returns rig names.
 func (f *Fetcher) FetchRigs() ([]string, error) {
-	stdout, err := runCmd(cmdTimeout, "gt", "rig", "list")
+	stdout, err := f.runGtCmd("rig", "list")
 	if err != nil {
 		return nil, fmt.Errorf("listing rigs: %w", err)
 	}
@@ -87,7 +121,7 @@ func (f *Fetcher) FetchRigs() ([]string, error) {
 
 // FetchStatus runs gt status --json and parses agent info.
 func (f *Fetcher) FetchStatus() (*TownStatus, error) {
-	stdout, err := runCmd(cmdTimeout, "gt", "status", "--json")
+	stdout, err := f.runGtCmd("status", "--json")
 	if err != nil {
 		return nil, fmt.Errorf("running gt status: %w", err)
 	}
@@ -138,6 +172,7 @@ func (f *Fetcher) FetchAgents() ([]AgentDetail, error) {
 	assigned := f.fetchAssignedIssues()
 
 	now := time.Now()
+	live := make(map[string]bool)
 	var agents []AgentDetail
 	for _, s := range sessions {
 		if !strings.HasPrefix(s.Name, "gt-") {
@@ -160,6 +195,13 @@ func (f *Fetcher) FetchAgents() ([]AgentDetail, error) {
 			role = "refinery"
 		case "mayor":
 			role = "mayor"
+		case "deacon":
+			role = "deacon"
+		default:
+			// Crew workspaces run as gt-<rig>-crew-<name>.
+			if crew, ok := strings.CutPrefix(name, "crew-"); ok {
+				role, name = "crew", crew
+			}
 		}
 
 		var age time.Duration
@@ -170,14 +212,7 @@ func (f *Fetcher) FetchAgents() ([]AgentDetail, error) {
 		// Status based on activity age
 		status := "idle"
 		if s.Activity > 0 {
-			switch {
-			case age < 5*time.Minute:
-				status = "working"
-			case age < 30*time.Minute:
-				status = "stale"
-			default:
-				status = "stuck"
-			}
+			status = f.Thresholds.orDefault().AgentStatus(age)
 		}
 
 		ad := AgentDetail{
@@ -187,9 +222,18 @@ func (f *Fetcher) FetchAgents() ([]AgentDetail, error) {
 			Status:  status,
 			AgeSecs: int64(age.Seconds()),
 		}
+		if f.States != nil && s.Activity > 0 {
+			ad.State = f.agentState(s.Name, s.Activity, now, func() string {
+				return f.FetchAgentOutput(rig, parts[2], agentSampleLines)
+			})
+		}
+		live[s.Name] = true
 
 		// Look up hooked issue for this agent
 		assignee := fmt.Sprintf("%s/polecats/%s", rig, name)
+		if role == "crew" {
+			assignee = fmt.Sprintf("%s/crew/%s", rig, name)
+		}
 		if issue, ok := assigned[assignee]; ok {
 			ad.IssueID = issue.ID
 			ad.IssueTitle = issue.Title
@@ -197,6 +241,7 @@ func (f *Fetcher) FetchAgents() ([]AgentDetail, error) {
 
 		agents = append(agents, ad)
 	}
+	f.forgetStates(live)
 	return agents, nil
 }
 
@@ -224,7 +269,7 @@ func (f *Fetcher) fetchAssignedIssues() map[string]IssueDetail {
 
 // FetchMail runs gt mail inbox --all --json and parses the result.
 func (f *Fetcher) FetchMail() ([]MailMessage, error) {
-	stdout, err := runCmd(cmdTimeout, "gt", "mail", "inbox", "--all", "--json")
+	stdout, err := f.runGtCmd("mail", "inbox", "--all", "--json")
 	if err != nil {
 		return nil, fmt.Errorf("fetching mail: %w", err)
 	}
@@ -264,10 +309,12 @@ func (f *Fetcher) FetchRefineryStatus() ([]RefineryStatus, error) {
 		}
 	}
 
-	// Also check for MR beads even if no refinery session is active
-	queuedMRs := f.fetchMRsByStatus("OPEN")
-	inProgressMRs := f.fetchMRsByStatus("IN_PROGRESS")
-	completedMRs := f.fetchMRsByStatus("COMPLETED")
+	// Also check for MR beads even if no refinery session is active.
+	// Merged and rejected MRs alike are closed; the close reason tells
+	// them apart.
+	queuedMRs := f.fetchMRsByStatus("open")
+	inProgressMRs := f.fetchMRsByStatus("in_progress")
+	closedMRs := f.fetchMRsByStatus("closed")
 
 	// Track all rigs that have MRs
 	for _, mr := range queuedMRs {
@@ -284,12 +331,20 @@ func (f *Fetcher) FetchRefineryStatus() ([]RefineryStatus, error) {
 			Running: rigRunning[rig],
 		}
 
-		// Build queue from open MRs for this rig
+		// Build queue from open MRs for this rig, in the order the
+		// refinery takes them: by priority, then oldest first
 		for _, mr := range queuedMRs {
 			if mr.rig == rig {
 				rs.Queue = append(rs.Queue, mr.MergeRequest)
 			}
 		}
+		sort.SliceStable(rs.Queue, func(i, j int) bool {
+			a, b := rs.Queue[i], rs.Queue[j]
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+			return parseBdTime(a.QueuedAt).Before(parseBdTime(b.QueuedAt))
+		})
 
 		// Current = in-progress MR for this rig
 		for _, mr := range inProgressMRs {
@@ -305,64 +360,211 @@ func (f *Fetcher) FetchRefineryStatus() ([]RefineryStatus, error) {
 			rs.QueueDepth++
 		}
 
-		// History from completed MRs (last 10)
-		count := 0
-		for _, mr := range completedMRs {
-			if mr.rig == rig && count < 10 {
-				rs.History = append(rs.History, mr.MergeRequest)
-				count++
-			}
-		}
-
-		// Calculate metrics from history
-		if len(rs.History) > 0 {
-			passed := 0
-			for _, mr := range rs.History {
-				if mr.Status == "COMPLETED" || mr.Status == "merged" {
-					passed++
-				}
+		var closed []MergeRequest
+		for _, mr := range closedMRs {
+			if mr.rig == rig {
+				closed = append(closed, mr.MergeRequest)
 			}
-			rs.SuccessRate = float64(passed) / float64(len(rs.History)) * 100
 		}
+		summarizeMRHistory(&rs, closed)
 
 		statuses = append(statuses, rs)
 	}
 
+	// Keep the rig tabs in a stable order from poll to poll.
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Rig < statuses[j].Rig })
 	return statuses, nil
 }
 
+// maxMRHistory is how many finished MRs a rig's history keeps.
+const maxMRHistory = 10
+
+// summarizeMRHistory fills in a rig's history, newest first, and its merge
+// metrics from its closed MRs. The success rate covers the history shown;
+// merge times and the failure streak use every closed MR.
+func summarizeMRHistory(rs *RefineryStatus, closed []MergeRequest) {
+	sort.SliceStable(closed, func(i, j int) bool {
+		return parseBdTime(closed[i].MergedAt).After(parseBdTime(closed[j].MergedAt))
+	})
+
+	rs.FailStreak = 0
+	for _, mr := range closed {
+		if mr.Status != "failed" {
+			break
+		}
+		rs.FailStreak++
+	}
+
+	var times []int
+	for _, mr := range closed {
+		if mr.Status != "merged" {
+			continue
+		}
+		queued, merged := parseBdTime(mr.QueuedAt), parseBdTime(mr.MergedAt)
+		if queued.IsZero() || merged.Before(queued) {
+			continue
+		}
+		times = append(times, int(merged.Sub(queued).Seconds()))
+	}
+	if len(times) > 0 {
+		sum := 0
+		for _, t := range times {
+			sum += t
+		}
+		rs.AvgMergeTime = sum / len(times)
+		sort.Ints(times)
+		rs.P90MergeTime = times[(len(times)*9+9)/10-1]
+	}
+
+	if len(closed) > maxMRHistory {
+		closed = closed[:maxMRHistory]
+	}
+	rs.History = closed
+	if len(rs.History) > 0 {
+		passed := 0
+		for _, mr := range rs.History {
+			if mr.Status == "merged" {
+				passed++
+			}
+		}
+		rs.SuccessRate = float64(passed) / float64(len(rs.History)) * 100
+	}
+}
+
 // rigMR pairs a MergeRequest with its rig for internal routing.
 type rigMR struct {
 	rig string
 	MergeRequest
 }
 
+// mrBead is an MR-type bead from bd list --json. Gas Town writes the MR's
+// fields into the description as "key: value" lines.
+type mrBead struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Priority    int    `json:"priority"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	ClosedAt    string `json:"closed_at"`
+	CloseReason string `json:"close_reason"`
+}
+
 // fetchMRsByStatus lists MR-type beads with a given status.
 func (f *Fetcher) fetchMRsByStatus(status string) []rigMR {
-	stdout, err := f.runBdCmd("list", "--type=mr", "--status="+strings.ToLower(status), "--json")
+	stdout, err := f.runBdCmd("list", "--type=mr", "--status="+status, "--json")
 	if err != nil {
 		return nil
 	}
 
-	var issues []IssueDetail
-	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+	var beads []mrBead
+	if err := json.Unmarshal(stdout.Bytes(), &beads); err != nil {
 		return nil
 	}
 
-	var results []rigMR
-	for _, iss := range issues {
-		rig := extractRig(iss.ID)
-		mr := MergeRequest{
-			ID:     iss.ID,
-			BeadID: iss.ID,
-			Title:  iss.Title,
-			Status: iss.Status,
-		}
-		results = append(results, rigMR{rig: rig, MergeRequest: mr})
+	results := make([]rigMR, 0, len(beads))
+	for _, b := range beads {
+		results = append(results, parseMRBead(b))
 	}
 	return results
 }
 
+// prURLPattern finds a pull request link anywhere in an MR description.
+var prURLPattern = regexp.MustCompile(`https?://\S+/pull/\d+`)
+
+// parseMRBead maps an MR bead onto a MergeRequest. BeadID is the issue the
+// MR merges work for, falling back to the MR's own ID.
+func parseMRBead(b mrBead) rigMR {
+	fields := mrFields(b.Description)
+	mr := MergeRequest{
+		ID:       b.ID,
+		BeadID:   b.ID,
+		Title:    b.Title,
+		Priority: b.Priority,
+		Branch:   fields["branch"],
+		QueuedAt: b.CreatedAt,
+		MergedAt: b.ClosedAt,
+		PRURL:    fields["pr_url"],
+	}
+	if src := fields["source_issue"]; src != "" {
+		mr.BeadID = src
+	}
+	mr.Worker = fields["worker"]
+	if parts := strings.Split(mr.Branch, "/"); mr.Worker == "" && len(parts) == 3 && parts[0] == "polecat" {
+		mr.Worker = parts[1] // polecat/<name>/<issue>
+	}
+	if mr.PRURL == "" {
+		mr.PRURL = prURLPattern.FindString(b.Description)
+	}
+
+	switch strings.ToLower(b.Status) {
+	case "open":
+		mr.Status = "queued"
+	case "in_progress":
+		mr.Status = "testing"
+	case "closed":
+		mr.Status = closedMRStatus(b.CloseReason, fields)
+	default:
+		mr.Status = b.Status
+	}
+
+	rig := fields["rig"]
+	if rig == "" {
+		rig = extractRig(b.ID)
+	}
+	return rigMR{rig: rig, MergeRequest: mr}
+}
+
+// mrFields reads the "key: value" lines of an MR description. The first
+// of a repeated key wins.
+func mrFields(description string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(description, "\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		k = strings.ToLower(strings.TrimSpace(k))
+		if _, dup := fields[k]; !dup {
+			fields[k] = strings.TrimSpace(v)
+		}
+	}
+	return fields
+}
+
+// ClosedMRStatus returns "merged", "failed", "skipped" or "closed" for a
+// closed MR bead, as the refinery history reports it.
+func ClosedMRStatus(b ClosedBeadInfo) string {
+	return closedMRStatus(b.CloseReason, mrFields(b.Description))
+}
+
+// closedMRStatus tells a merged MR from a failed or skipped one by its
+// close reason. A closed MR with a merge commit and no reason merged.
+func closedMRStatus(reason string, fields map[string]string) string {
+	reason = strings.ToLower(reason + " " + fields["close_reason"])
+	switch {
+	case strings.Contains(reason, "skip"), strings.Contains(reason, "supersede"):
+		return "skipped"
+	case strings.Contains(reason, "fail"), strings.Contains(reason, "conflict"),
+		strings.Contains(reason, "reject"):
+		return "failed"
+	case strings.Contains(reason, "merge"), fields["merge_commit"] != "":
+		return "merged"
+	default:
+		return "closed"
+	}
+}
+
+// parseBdTime parses a bd timestamp; the zero time if it can't.
+func parseBdTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 // extractRig extracts the rig name from a bead ID prefix.
 // Bead IDs use format like "kt-xxx" where "kt" maps to a rig.
 func extractRig(id string) string {
@@ -565,6 +767,21 @@ func (f *Fetcher) FetchConvoys() ([]ConvoyInfo, error) {
 	return convoys, nil
 }
 
+// FetchStrandedConvoys runs gt convoy stranded --json and returns the
+// convoys that have stalled.
+func (f *Fetcher) FetchStrandedConvoys() ([]StrandedConvoy, error) {
+	stdout, err := f.runGtCmd("convoy", "stranded", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("listing stranded convoys: %w", err)
+	}
+
+	var convoys []StrandedConvoy
+	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
+		return nil, fmt.Errorf("parsing stranded convoys: %w", err)
+	}
+	return convoys, nil
+}
+
 // FetchClosedBeads runs bd list --status=closed --json in TownRoot.
 func (f *Fetcher) FetchClosedBeads() ([]ClosedBeadInfo, error) {
 	stdout, err := f.runBdCmd("list", "--status=closed", "--json")
@@ -579,9 +796,107 @@ func (f *Fetcher) FetchClosedBeads() ([]ClosedBeadInfo, error) {
 	return beads, nil
 }
 
+// FetchReady runs bd ready --json in TownRoot: open issues with no
+// blockers, which can be slung to a rig.
+func (f *Fetcher) FetchReady() ([]ReadyIssue, error) {
+	stdout, err := f.runBdCmd("ready", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("listing ready issues: %w", err)
+	}
+
+	var issues []ReadyIssue
+	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+		return nil, fmt.Errorf("parsing ready issues: %w", err)
+	}
+	return issues, nil
+}
+
+// Services are the town-level services, in the order they are shown.
+var Services = []string{"mayor", "deacon"}
+
+// FetchServices reports on the Mayor and the Deacon. Each runs in a tmux
+// session named hq-<service>, gt-<service> or gt-<rig>-<service>; gt
+// <service> status says whether it is up when the session can't be found.
+// No tmux server just means nothing is running.
+func (f *Fetcher) FetchServices() ([]ServiceStatus, error) {
+	var sessions []string
+	stdout, err := runCmd(tmuxCmdTimeout, "tmux", "list-sessions", "-F",
+		"#{session_name}|#{window_activity}|#{session_created}")
+	if err == nil {
+		sessions = strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	}
+
+	var out []ServiceStatus
+	failed := 0
+	var lastErr error
+	for _, name := range Services {
+		st := ServiceStatus{Name: name}
+		if line, ok := serviceSession(sessions, name); ok {
+			parts := strings.SplitN(line, "|", 3)
+			st.Session, st.Running = parts[0], true
+			if len(parts) == 3 {
+				fmt.Sscanf(parts[1], "%d", &st.Activity)
+				fmt.Sscanf(parts[2], "%d", &st.Created)
+			}
+		}
+
+		stdout, err := f.runGtCmd(name, "status")
+		if err != nil {
+			st.StatusErr = err.Error()
+			failed++
+			lastErr = err
+		} else {
+			var running, known bool
+			st.Status, running, known = parseServiceStatus(stdout.String())
+			if known && st.Session == "" {
+				st.Running = running
+			}
+		}
+		out = append(out, st)
+	}
+	if failed == len(Services) && len(sessions) == 0 {
+		return nil, fmt.Errorf("checking town services: %w", lastErr)
+	}
+	return out, nil
+}
+
+// serviceSession finds the tmux list-sessions line for service.
+func serviceSession(lines []string, service string) (string, bool) {
+	for _, line := range lines {
+		name, _, _ := strings.Cut(line, "|")
+		if name == "hq-"+service || name == "gt-"+service {
+			return line, true
+		}
+		if strings.HasPrefix(name, "gt-") && strings.HasSuffix(name, "-"+service) &&
+			strings.Count(name, "-") == 2 {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// parseServiceStatus returns the first non-blank line of gt <service>
+// status output and, if the output says, whether the service is running.
+func parseServiceStatus(out string) (line string, running, known bool) {
+	for _, l := range strings.Split(out, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			line = l
+			break
+		}
+	}
+	lower := strings.ToLower(out)
+	switch {
+	case strings.Contains(lower, "not running"), strings.Contains(lower, "stopped"):
+		return line, false, true
+	case strings.Contains(lower, "running"):
+		return line, true, true
+	}
+	return line, false, false
+}
+
 // FetchAllConvoys runs gt convoy list --all --json and returns all convoys.
 func (f *Fetcher) FetchAllConvoys() ([]AllConvoyInfo, error) {
-	stdout, err := runCmd(cmdTimeout, "gt", "convoy", "list", "--all", "--json")
+	stdout, err := f.runGtCmd("convoy", "list", "--all", "--json")
 	if err != nil {
 		return nil, fmt.Errorf("listing all convoys: %w", err)
 	}
@@ -678,6 +993,72 @@ func (f *Fetcher) FetchAgentOutput(rig, name string, lines int) string {
 	return stdout.String()
 }
 
+// FetchRigPolecats runs gt polecat list <rig> --json and joins each polecat
+// with its gt-<rig>-<name> tmux session. Polecats with a session that gt
+// doesn't list are included with no state. Hooked issues gt leaves out are
+// looked up from bd.
+func (f *Fetcher) FetchRigPolecats(rig string) ([]PolecatStatus, error) {
+	stdout, err := f.runGtCmd("polecat", "list", rig, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("listing polecats in %s: %w", rig, err)
+	}
+	var polecats []PolecatStatus
+	if err := json.Unmarshal(stdout.Bytes(), &polecats); err != nil {
+		return nil, fmt.Errorf("parsing polecats in %s: %w", rig, err)
+	}
+
+	// A missing tmux server just means no sessions.
+	sessions, _ := f.FetchSessions()
+	polecats = joinPolecatSessions(rig, polecats, sessions)
+
+	assigned := f.fetchAssignedIssues()
+	for i := range polecats {
+		p := &polecats[i]
+		p.Rig = rig
+		issue, ok := assigned[fmt.Sprintf("%s/polecats/%s", rig, p.Name)]
+		if !ok {
+			continue
+		}
+		if p.Issue == "" {
+			p.Issue = issue.ID
+		}
+		if p.Issue == issue.ID {
+			p.IssueTitle = issue.Title
+		}
+	}
+	return polecats, nil
+}
+
+// joinPolecatSessions fills in session activity for listed polecats and
+// adds unlisted polecat sessions of rig, sorted by name.
+func joinPolecatSessions(rig string, polecats []PolecatStatus, sessions []SessionInfo) []PolecatStatus {
+	index := make(map[string]int, len(polecats))
+	for i, p := range polecats {
+		index[p.Name] = i
+	}
+	prefix := "gt-" + rig + "-"
+	for _, s := range sessions {
+		name, ok := strings.CutPrefix(s.Name, prefix)
+		if !ok || strings.HasPrefix(name, "crew-") {
+			continue
+		}
+		switch name {
+		case "witness", "refinery", "mayor", "deacon":
+			continue
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(polecats)
+			index[name] = i
+			polecats = append(polecats, PolecatStatus{Rig: rig, Name: name})
+		}
+		polecats[i].HasSession = true
+		polecats[i].Activity = s.Activity
+	}
+	sort.Slice(polecats, func(i, j int) bool { return polecats[i].Name < polecats[j].Name })
+	return polecats
+}
+
 // FetchWitnesses detects witness sessions from tmux, computes heartbeat
 // status, and counts managed polecats per rig.
 func (f *Fetcher) FetchWitnesses() ([]WitnessDetail, error) {
@@ -729,7 +1110,7 @@ func (f *Fetcher) FetchWitnesses() ([]WitnessDetail, error) {
 
 		if name == "witness" {
 			witnessSessions[rig] = s
-		} else if name != "refinery" && name != "mayor" {
+		} else if name != "refinery" && name != "mayor" && name != "deacon" {
 			polecatCounts[rig]++
 		}
 	}
@@ -747,15 +1128,7 @@ func (f *Fetcher) FetchWitnesses() ([]WitnessDetail, error) {
 			wd.SessionCreated = ws.Created
 
 			if ws.Activity > 0 {
-				age := now.Sub(time.Unix(ws.Activity, 0))
-				switch {
-				case age < 5*time.Minute:
-					wd.Status = "alive"
-				case age < 15*time.Minute:
-					wd.Status = "stale"
-				default:
-					wd.Status = "dead"
-				}
+				wd.Status = f.Thresholds.orDefault().WitnessStatus(now.Sub(time.Unix(ws.Activity, 0)))
 			} else {
 				wd.Status = "dead"
 			}
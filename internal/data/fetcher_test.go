@@ -1,6 +1,7 @@
 package data
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -64,3 +65,133 @@ func containsAt(s, sub string) bool {
 	}
 	return false
 }
+
+func TestParseServiceStatus(t *testing.T) {
+	tests := []struct {
+		out            string
+		line           string
+		running, known bool
+	}{
+		{"\n  Mayor: running (pid 4242)\n  session: hq-mayor\n", "Mayor: running (pid 4242)", true, true},
+		{"Deacon is not running\n", "Deacon is not running", false, true},
+		{"Mayor stopped\n", "Mayor stopped", false, true},
+		{"mayor: ?\n", "mayor: ?", false, false},
+	}
+	for _, tt := range tests {
+		line, running, known := parseServiceStatus(tt.out)
+		if line != tt.line || running != tt.running || known != tt.known {
+			t.Errorf("parseServiceStatus(%q) = %q, %v, %v; want %q, %v, %v",
+				tt.out, line, running, known, tt.line, tt.running, tt.known)
+		}
+	}
+}
+
+func TestServiceSession(t *testing.T) {
+	lines := []string{"gt-kt-quartz|1|1", "gt-kt-deacon|2|2", "hq-mayor|3|3"}
+	if line, ok := serviceSession(lines, "mayor"); !ok || line != "hq-mayor|3|3" {
+		t.Errorf("mayor session = %q, %v", line, ok)
+	}
+	if line, ok := serviceSession(lines, "deacon"); !ok || line != "gt-kt-deacon|2|2" {
+		t.Errorf("deacon session = %q, %v", line, ok)
+	}
+	if _, ok := serviceSession([]string{"gt-kt-crew-mayor|1|1"}, "mayor"); ok {
+		t.Error("a crew member named mayor is not the Mayor")
+	}
+}
+
+func TestParseMRBead(t *testing.T) {
+	mr := parseMRBead(mrBead{
+		ID:     "kt-mr1",
+		Title:  "Merge kt-abc",
+		Status: "closed",
+		Description: "branch: polecat/quartz/kt-abc\nsource_issue: kt-abc\nrig: kestral_tui\n" +
+			"merge_commit: 1a2b3c\nOpened https://github.com/t/k/pull/42 for review",
+		CreatedAt: "2026-02-07T10:00:00Z",
+		ClosedAt:  "2026-02-07T10:15:00Z",
+	})
+	if mr.rig != "kestral_tui" || mr.BeadID != "kt-abc" || mr.Branch != "polecat/quartz/kt-abc" {
+		t.Errorf("rig/bead/branch = %q %q %q", mr.rig, mr.BeadID, mr.Branch)
+	}
+	if mr.Worker != "quartz" {
+		t.Errorf("worker from branch = %q, want quartz", mr.Worker)
+	}
+	if mr.Status != "merged" || mr.PRURL != "https://github.com/t/k/pull/42" {
+		t.Errorf("status = %q, PR URL = %q", mr.Status, mr.PRURL)
+	}
+	if mr.QueuedAt != "2026-02-07T10:00:00Z" || mr.MergedAt != "2026-02-07T10:15:00Z" {
+		t.Errorf("queued/merged = %q %q", mr.QueuedAt, mr.MergedAt)
+	}
+
+	failed := parseMRBead(mrBead{ID: "kt-mr2", Status: "closed", CloseReason: "Merge conflict in app.go"})
+	if failed.rig != "kt" || failed.BeadID != "kt-mr2" || failed.Status != "failed" {
+		t.Errorf("failed MR = %+v", failed)
+	}
+	if queued := parseMRBead(mrBead{ID: "kt-mr3", Status: "open"}); queued.Status != "queued" {
+		t.Errorf("open MR status = %q, want queued", queued.Status)
+	}
+}
+
+func TestSummarizeMRHistory(t *testing.T) {
+	at := func(min int) string {
+		return time.Date(2026, 2, 7, 10, min, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+	var closed []MergeRequest
+	// Ten merges taking 1..10 minutes, then two failures.
+	for i := 1; i <= 10; i++ {
+		closed = append(closed, MergeRequest{ID: fmt.Sprint("m", i), Status: "merged", QueuedAt: at(0), MergedAt: at(i)})
+	}
+	closed = append(closed,
+		MergeRequest{ID: "f1", Status: "failed", QueuedAt: at(0), MergedAt: at(20)},
+		MergeRequest{ID: "f2", Status: "failed", QueuedAt: at(0), MergedAt: at(30)},
+	)
+
+	var rs RefineryStatus
+	summarizeMRHistory(&rs, closed)
+	if rs.AvgMergeTime != 330 || rs.P90MergeTime != 540 {
+		t.Errorf("avg/p90 = %ds/%ds, want 330s/540s", rs.AvgMergeTime, rs.P90MergeTime)
+	}
+	if rs.FailStreak != 2 {
+		t.Errorf("FailStreak = %d, want 2", rs.FailStreak)
+	}
+	if len(rs.History) != maxMRHistory || rs.History[0].ID != "f2" || rs.History[2].ID != "m10" {
+		t.Errorf("history should be the newest %d, newest first: %+v", maxMRHistory, rs.History)
+	}
+	if rs.SuccessRate != 80 {
+		t.Errorf("SuccessRate = %.0f, want 80", rs.SuccessRate)
+	}
+}
+
+func TestJoinPolecatSessions(t *testing.T) {
+	polecats := []PolecatStatus{
+		{Rig: "gastown", Name: "toast", State: "working"},
+		{Rig: "gastown", Name: "ace", State: "done"},
+	}
+	sessions := []SessionInfo{
+		{Name: "gt-gastown-toast", Activity: 100},
+		{Name: "gt-gastown-nux", Activity: 200},
+		{Name: "gt-gastown-witness", Activity: 300},
+		{Name: "gt-gastown-crew-max", Activity: 400},
+		{Name: "gt-beads-toast", Activity: 500},
+	}
+
+	got := joinPolecatSessions("gastown", polecats, sessions)
+	if len(got) != 3 {
+		t.Fatalf("got %d polecats, want 3: %+v", len(got), got)
+	}
+	want := []struct {
+		name       string
+		state      string
+		hasSession bool
+		activity   int64
+	}{
+		{"ace", "done", false, 0},
+		{"nux", "", true, 200},
+		{"toast", "working", true, 100},
+	}
+	for i, w := range want {
+		p := got[i]
+		if p.Name != w.name || p.State != w.state || p.HasSession != w.hasSession || p.Activity != w.activity {
+			t.Errorf("polecat %d = %+v, want %+v", i, p, w)
+		}
+	}
+}
@@ -16,6 +16,9 @@ type ResourceTickMsg time.Time
 type WitnessTickMsg time.Time
 type PRTickMsg time.Time
 type HistoryTickMsg time.Time
+type TownsTickMsg time.Time
+type ReadyTickMsg time.Time
+type ServicesTickMsg time.Time
 
 // Result messages carry fetched data back to the model.
 type StatusUpdateMsg struct {
@@ -123,6 +126,27 @@ func ScheduleAgentDetailPoll(interval time.Duration) tea.Cmd {
 	})
 }
 
+// WitnessDetailTickMsg triggers a periodic fetch for witness detail data.
+type WitnessDetailTickMsg time.Time
+
+// ScheduleWitnessDetailPoll returns a tea.Tick command for the next witness detail poll.
+func ScheduleWitnessDetailPoll(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return WitnessDetailTickMsg(t)
+	})
+}
+
+// NudgeTickMsg triggers a capture of a nudged agent's output.
+type NudgeTickMsg time.Time
+
+// ScheduleNudgePoll returns a tea.Tick command for the next capture of a
+// nudged agent's output.
+func ScheduleNudgePoll(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return NudgeTickMsg(t)
+	})
+}
+
 // ScheduleResourcePoll returns a tea.Tick command for the next resource poll.
 func ScheduleResourcePoll(interval time.Duration) tea.Cmd {
 	return tea.Tick(interval, func(t time.Time) tea.Msg {
@@ -137,6 +161,21 @@ func ScheduleWitnessPoll(interval time.Duration) tea.Cmd {
 	})
 }
 
+// ScheduleReadyPoll returns a tea.Tick command for the next ready-issue poll.
+func ScheduleReadyPoll(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return ReadyTickMsg(t)
+	})
+}
+
+// ScheduleServicesPoll returns a tea.Tick command for the next poll of the
+// town services.
+func ScheduleServicesPoll(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return ServicesTickMsg(t)
+	})
+}
+
 // SchedulePRPoll returns a tea.Tick command for the next PR poll.
 func SchedulePRPoll(interval time.Duration) tea.Cmd {
 	return tea.Tick(interval, func(t time.Time) tea.Msg {
@@ -157,3 +196,11 @@ func FetchPRsCmd(fetcher *Fetcher) tea.Cmd {
 		return PRUpdateMsg{PRs: prs, Err: err}
 	}
 }
+
+// ScheduleTownsPoll returns a tea.Tick command for the next poll of every
+// configured town's health.
+func ScheduleTownsPoll(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return TownsTickMsg(t)
+	})
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the command latency
+// histogram.
+var LatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15}
+
+// CommandStat summarizes executions of one external command, e.g.
+// "gt status" or "tmux list-sessions".
+type CommandStat struct {
+	Command string
+	Count   uint64
+	Errors  uint64
+	Seconds float64  // total run time
+	Buckets []uint64 // cumulative counts per LatencyBuckets
+}
+
+var (
+	statsMu  sync.Mutex
+	cmdStats = make(map[string]*CommandStat)
+)
+
+// CommandStats returns a snapshot of per-command statistics, sorted by
+// command.
+func CommandStats() []CommandStat {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	out := make([]CommandStat, 0, len(cmdStats))
+	for _, s := range cmdStats {
+		c := *s
+		c.Buckets = append([]uint64(nil), s.Buckets...)
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Command < out[j].Command })
+	return out
+}
+
+// recordCmd adds one execution of name with args to the statistics.
+func recordCmd(name string, args []string, d time.Duration, err error) {
+	label := commandLabel(name, args)
+	secs := d.Seconds()
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s, ok := cmdStats[label]
+	if !ok {
+		s = &CommandStat{Command: label, Buckets: make([]uint64, len(LatencyBuckets))}
+		cmdStats[label] = s
+	}
+	s.Count++
+	s.Seconds += secs
+	if err != nil {
+		s.Errors++
+	}
+	for i, le := range LatencyBuckets {
+		if secs <= le {
+			s.Buckets[i]++
+		}
+	}
+}
+
+// commandLabel reduces a command line to a low-cardinality label: the
+// program and its subcommand, plus the verb for gt and gh, which use
+// "noun verb" subcommands. Flags and their values (git -C <dir>) are
+// skipped, and IDs or paths after the subcommand are dropped.
+func commandLabel(name string, args []string) string {
+	words := 1
+	if name == "gt" || name == "gh" {
+		words = 2
+	}
+	parts := []string{name}
+	for i := 0; i < len(args) && len(parts) <= words; i++ {
+		a := args[i]
+		if a == "-C" {
+			i++
+			continue
+		}
+		if strings.HasPrefix(a, "-") {
+			break
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
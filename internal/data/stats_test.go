@@ -0,0 +1,53 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCommandLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"gt", []string{"status", "--json"}, "gt status"},
+		{"gt", []string{"mail", "inbox", "--all", "--json"}, "gt mail inbox"},
+		{"gh", []string{"pr", "list", "--json", "number"}, "gh pr list"},
+		{"bd", []string{"show", "kt-1", "kt-2", "--json"}, "bd show"},
+		{"bd", []string{"list", "--type=mr"}, "bd list"},
+		{"tmux", []string{"list-sessions", "-F", "#{session_name}"}, "tmux list-sessions"},
+		{"git", []string{"-C", "/gt/kt/polecats/quartz", "branch", "--show-current"}, "git branch"},
+	}
+	for _, tt := range tests {
+		if got := commandLabel(tt.name, tt.args); got != tt.want {
+			t.Errorf("commandLabel(%s %v) = %q, want %q", tt.name, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRecordCmd(t *testing.T) {
+	recordCmd("test-cmd", []string{"run"}, 200*time.Millisecond, nil)
+	recordCmd("test-cmd", []string{"run"}, 3*time.Second, errors.New("exit status 1"))
+
+	var stat *CommandStat
+	for _, s := range CommandStats() {
+		if s.Command == "test-cmd run" {
+			stat = &s
+		}
+	}
+	if stat == nil {
+		t.Fatal("expected stats for test-cmd run")
+	}
+	if stat.Count != 2 || stat.Errors != 1 {
+		t.Errorf("count/errors = %d/%d, want 2/1", stat.Count, stat.Errors)
+	}
+	// Buckets: 0.05 0.1 0.25 0.5 1 2.5 5 10 15
+	want := []uint64{0, 0, 1, 1, 1, 1, 2, 2, 2}
+	for i, v := range want {
+		if stat.Buckets[i] != v {
+			t.Errorf("bucket %g = %d, want %d", LatencyBuckets[i], stat.Buckets[i], v)
+		}
+	}
+}
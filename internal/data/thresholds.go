@@ -0,0 +1,67 @@
+package data
+
+import "time"
+
+// Thresholds are the cutoffs that turn activity ages and CPU samples into
+// the statuses the panes show and the alert rules fire on. The config
+// derives them from its alert rules, so what a pane shows as stuck or hot
+// is what the rules alert on.
+type Thresholds struct {
+	AgentStale      time.Duration // inactivity before an agent is stale
+	AgentStuck      time.Duration // inactivity before an agent is stuck
+	WitnessStale    time.Duration // heartbeat age before a witness is stale
+	WitnessDead     time.Duration // heartbeat age before a witness is dead
+	CPUWarn         float64       // percent, sustained for CPUWarnSamples polls
+	CPUWarnSamples  int
+	CPUAlert        float64 // percent, sustained for CPUAlertSamples polls
+	CPUAlertSamples int
+}
+
+// DefaultThresholds returns the cutoffs used without alert rules to derive
+// them from.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		AgentStale:      5 * time.Minute,
+		AgentStuck:      30 * time.Minute,
+		WitnessStale:    5 * time.Minute,
+		WitnessDead:     15 * time.Minute,
+		CPUWarn:         80,
+		CPUWarnSamples:  4, // 4 * 30s = 2 min
+		CPUAlert:        95,
+		CPUAlertSamples: 10, // 10 * 30s = 5 min
+	}
+}
+
+// AgentStatus returns "working", "stale" or "stuck" for an agent last
+// active age ago.
+func (t Thresholds) AgentStatus(age time.Duration) string {
+	switch {
+	case age < t.AgentStale:
+		return "working"
+	case age < t.AgentStuck:
+		return "stale"
+	default:
+		return "stuck"
+	}
+}
+
+// WitnessStatus returns "alive", "stale" or "dead" for a witness whose
+// last heartbeat was age ago.
+func (t Thresholds) WitnessStatus(age time.Duration) string {
+	switch {
+	case age < t.WitnessStale:
+		return "alive"
+	case age < t.WitnessDead:
+		return "stale"
+	default:
+		return "dead"
+	}
+}
+
+// orDefault returns t, or the defaults if t was never set.
+func (t Thresholds) orDefault() Thresholds {
+	if t == (Thresholds{}) {
+		return DefaultThresholds()
+	}
+	return t
+}
@@ -6,6 +6,16 @@ type TownStatus struct {
 	Agents []AgentInfo `json:"agents"`
 }
 
+// Health is "degraded" while any agent is stopped, otherwise "healthy".
+func (s *TownStatus) Health() string {
+	for _, a := range s.Agents {
+		if !a.Running {
+			return "degraded"
+		}
+	}
+	return "healthy"
+}
+
 // AgentInfo represents a single agent in the town status.
 type AgentInfo struct {
 	Name    string `json:"name"`
@@ -21,6 +31,14 @@ type ConvoyInfo struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// StrandedConvoy is an open convoy from gt convoy stranded --json: one
+// with no active polecat or whose progress has stalled.
+type StrandedConvoy struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
 // TrackedDep represents a tracked dependency from bd dep list <id> -t tracks --json.
 type TrackedDep struct {
 	ID string `json:"id"`
@@ -73,6 +91,33 @@ type ClosedBeadInfo struct {
 	Assignee  string `json:"assignee"`
 	CreatedAt string `json:"created_at"`
 	ClosedAt  string `json:"closed_at"`
+	// CloseReason and Description tell a merged MR bead from a failed or
+	// skipped one; see ClosedMRStatus.
+	CloseReason string `json:"close_reason,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ReadyIssue represents an open issue with no blockers from bd ready --json.
+type ReadyIssue struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Priority  int    `json:"priority"` // 0 (critical) to 4 (backlog)
+	IssueType string `json:"issue_type"`
+	Assignee  string `json:"assignee"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ServiceStatus is the state of a town-level service, the Mayor or the
+// Deacon, from gt <service> status and its tmux session.
+type ServiceStatus struct {
+	Name      string `json:"name"` // mayor, deacon
+	Running   bool   `json:"running"`
+	Status    string `json:"status"`     // first line of gt <service> status
+	Session   string `json:"session"`    // tmux session, "" if none
+	Created   int64  `json:"created"`    // unix timestamp of session creation
+	Activity  int64  `json:"activity"`   // unix timestamp of last activity
+	StatusErr string `json:"status_err"` // why gt <service> status failed
 }
 
 // AllConvoyInfo represents a convoy from gt convoy list --all --json.
@@ -95,9 +140,11 @@ type MergeRequest struct {
 	BeadID   string `json:"bead_id"`
 	Title    string `json:"title"`
 	Branch   string `json:"branch"`
-	Status   string `json:"status"` // queued, testing, merged, failed, skipped
+	Worker   string `json:"worker"`   // polecat that did the work
+	Status   string `json:"status"`   // queued, testing, merged, failed, skipped
+	Priority int    `json:"priority"` // 0 (first) to 4
 	QueuedAt string `json:"queued_at"`
-	MergedAt string `json:"merged_at"`
+	MergedAt string `json:"merged_at"` // when it was closed, merged or not
 	PRURL    string `json:"pr_url"`
 }
 
@@ -110,7 +157,9 @@ type RefineryStatus struct {
 	Queue        []MergeRequest `json:"queue"`
 	History      []MergeRequest `json:"history"`
 	SuccessRate  float64        `json:"success_rate"`
-	AvgMergeTime int            `json:"avg_merge_time_sec"`
+	AvgMergeTime int            `json:"avg_merge_time_sec"` // queued to merged
+	P90MergeTime int            `json:"p90_merge_time_sec"`
+	FailStreak   int            `json:"fail_streak"` // failures since the last merge
 }
 
 // AgentDetail holds enriched agent info for the TUI agents pane.
@@ -122,6 +171,7 @@ type AgentDetail struct {
 	AgeSecs    int64  `json:"age_sec"` // seconds since last activity
 	IssueID    string `json:"issue_id"`
 	IssueTitle string `json:"issue_title"`
+	State      string `json:"state,omitempty"` // from session output: waiting, error_loop, rate_limited, done
 }
 
 // SessionResource holds aggregated resource usage for a single tmux session.
@@ -154,6 +204,18 @@ type CommitInfo struct {
 	Message string
 }
 
+// PolecatStatus is a polecat in a rig from gt polecat list --json, with
+// its tmux session's activity.
+type PolecatStatus struct {
+	Rig        string `json:"rig"`
+	Name       string `json:"name"`
+	State      string `json:"state"` // as gt reports it, e.g. working, done; "" if gt doesn't list it
+	Issue      string `json:"issue"` // hooked issue, "" if none
+	IssueTitle string `json:"issue_title"`
+	HasSession bool   `json:"has_session"`
+	Activity   int64  `json:"activity"` // unix timestamp of last session activity
+}
+
 // WitnessDetail holds witness heartbeat info for the TUI witness pane.
 type WitnessDetail struct {
 	Rig            string `json:"rig"`
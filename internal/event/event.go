@@ -0,0 +1,384 @@
+// Package event diffs successive data update messages into typed events
+// describing what changed between polls.
+package event
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// Differ remembers the previous snapshot of each data source and reports
+// the changes in each new one. The first snapshot of a source only sets the
+// baseline, so connecting does not replay the current state as events.
+type Differ struct {
+	agents    map[string]string      // rig/name -> status
+	witnesses map[string]string      // rig -> status
+	prs       map[int]prState        // number -> state
+	convoys   map[string]convoyState // convoy ID -> state
+	mrs       map[string]bool        // refinery history MR IDs
+	mail      map[string]bool        // message IDs
+	closed    map[string]bool        // closed bead IDs
+}
+
+type prState struct {
+	title  string
+	failed bool // at least one failing check
+	passed bool // all checks passed
+}
+
+type convoyState struct {
+	title    string
+	complete bool
+}
+
+// NewDiffer creates an empty Differ.
+func NewDiffer() *Differ {
+	return &Differ{}
+}
+
+// Diff compares msg against the previous snapshot of the same source and
+// returns the resulting events. Unrelated messages and failed fetches
+// return nil and leave the baseline untouched.
+func (d *Differ) Diff(msg tea.Msg, now time.Time) []pane.Event {
+	if d == nil {
+		return nil
+	}
+	var events []pane.Event
+	switch msg := msg.(type) {
+	case pane.AgentUpdateMsg:
+		if msg.Err == nil {
+			events = d.diffAgents(msg.Agents)
+		}
+	case pane.WitnessUpdateMsg:
+		if msg.Err == nil {
+			events = d.diffWitnesses(msg.Witnesses)
+		}
+	case pane.PRUpdateMsg:
+		if msg.Err == nil {
+			events = d.diffPRs(msg.PRs)
+		}
+	case pane.ConvoyUpdateMsg:
+		// A nil Progress map means the fetch failed.
+		if msg.Progress != nil {
+			events = d.diffConvoys(msg.Convoys, msg.Progress)
+		}
+	case pane.RefineryUpdateMsg:
+		if msg.Err == nil {
+			events = d.diffRefinery(msg.Statuses)
+		}
+	case pane.MRActionMsg:
+		events = []pane.Event{mrActionEvent(msg)}
+	case pane.MailUpdateMsg:
+		if msg.Err == nil {
+			events = d.diffMail(msg.Messages)
+		}
+	case pane.HistoryUpdateMsg:
+		if msg.Err == nil {
+			events = d.diffClosed(msg.ClosedBeads)
+		}
+	}
+	for i := range events {
+		events[i].At = now
+	}
+	return events
+}
+
+func (d *Differ) diffAgents(agents []pane.AgentInfo) []pane.Event {
+	next := make(map[string]string, len(agents))
+	for _, a := range agents {
+		next[a.Rig+"/"+a.Name] = a.Status
+	}
+	prev := d.agents
+	d.agents = next
+	if prev == nil {
+		return nil
+	}
+
+	var events []pane.Event
+	for _, key := range sortedKeys(next) {
+		status := next[key]
+		link := pane.EventLink{Pane: pane.PaneAgents, Key: key}
+		old, existed := prev[key]
+		switch {
+		case !existed:
+			events = append(events, pane.Event{
+				Kind: pane.EventAgentStarted, Subject: key, Link: link,
+				Message: fmt.Sprintf("%s started", key),
+			})
+		case old != status && status == "stuck":
+			events = append(events, pane.Event{
+				Kind: pane.EventAgentStuck, Subject: key, Link: link,
+				Message: fmt.Sprintf("%s went stuck (was %s)", key, old),
+			})
+		case old != status:
+			events = append(events, pane.Event{
+				Kind: pane.EventAgentStatus, Subject: key, Link: link,
+				Message: fmt.Sprintf("%s %s → %s", key, old, status),
+			})
+		}
+	}
+	for _, key := range sortedKeys(prev) {
+		if _, ok := next[key]; !ok {
+			events = append(events, pane.Event{
+				Kind: pane.EventAgentGone, Subject: key,
+				Link:    pane.EventLink{Pane: pane.PaneAgents, Key: key},
+				Message: fmt.Sprintf("%s session ended", key),
+			})
+		}
+	}
+	return events
+}
+
+func (d *Differ) diffWitnesses(witnesses []pane.WitnessInfo) []pane.Event {
+	next := make(map[string]string, len(witnesses))
+	for _, w := range witnesses {
+		next[w.Rig] = w.Status
+	}
+	prev := d.witnesses
+	d.witnesses = next
+	if prev == nil {
+		return nil
+	}
+
+	var events []pane.Event
+	for _, rig := range sortedKeys(next) {
+		status := next[rig]
+		old, existed := prev[rig]
+		if !existed || old == status {
+			continue
+		}
+		kind := pane.EventWitnessStatus
+		if status == "dead" {
+			kind = pane.EventWitnessDead
+		}
+		events = append(events, pane.Event{
+			Kind: kind, Subject: rig,
+			Link:    pane.EventLink{Pane: pane.PaneWitness, Key: rig},
+			Message: fmt.Sprintf("witness %s %s → %s", rig, old, status),
+		})
+	}
+	return events
+}
+
+func (d *Differ) diffPRs(prs []data.PRInfo) []pane.Event {
+	next := make(map[int]prState, len(prs))
+	for _, pr := range prs {
+		next[pr.Number] = prChecks(pr)
+	}
+	prev := d.prs
+	d.prs = next
+	if prev == nil {
+		return nil
+	}
+
+	numbers := make([]int, 0, len(next))
+	for n := range next {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	var events []pane.Event
+	for _, n := range numbers {
+		cur := next[n]
+		subject := "#" + strconv.Itoa(n)
+		link := pane.EventLink{Pane: pane.PanePRs, Key: strconv.Itoa(n)}
+		old, existed := prev[n]
+		switch {
+		case !existed:
+			events = append(events, pane.Event{
+				Kind: pane.EventPROpened, Subject: subject, Link: link,
+				Message: fmt.Sprintf("PR %s opened: %s", subject, cur.title),
+			})
+		case cur.failed && !old.failed:
+			events = append(events, pane.Event{
+				Kind: pane.EventCIFailed, Subject: subject, Link: link,
+				Message: fmt.Sprintf("PR %s checks failed: %s", subject, cur.title),
+			})
+		case cur.passed && !old.passed:
+			events = append(events, pane.Event{
+				Kind: pane.EventCIPassed, Subject: subject, Link: link,
+				Message: fmt.Sprintf("PR %s checks passed: %s", subject, cur.title),
+			})
+		}
+	}
+
+	closed := make([]int, 0)
+	for n := range prev {
+		if _, ok := next[n]; !ok {
+			closed = append(closed, n)
+		}
+	}
+	sort.Ints(closed)
+	for _, n := range closed {
+		subject := "#" + strconv.Itoa(n)
+		events = append(events, pane.Event{
+			Kind: pane.EventPRClosed, Subject: subject,
+			Message: fmt.Sprintf("PR %s merged or closed: %s", subject, prev[n].title),
+		})
+	}
+	return events
+}
+
+// prChecks summarizes a PR's status checks.
+func prChecks(pr data.PRInfo) prState {
+	s := prState{title: pr.Title, passed: len(pr.StatusChecks) > 0}
+	for _, c := range pr.StatusChecks {
+		switch c.Conclusion {
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+		case "FAILURE", "CANCELLED", "TIMED_OUT":
+			s.failed = true
+			s.passed = false
+		default:
+			s.passed = false
+		}
+	}
+	return s
+}
+
+func (d *Differ) diffConvoys(convoys []data.ConvoyInfo, progress map[string][2]int) []pane.Event {
+	next := make(map[string]convoyState, len(convoys))
+	for _, c := range convoys {
+		p := progress[c.ID]
+		next[c.ID] = convoyState{title: c.Title, complete: p[1] > 0 && p[0] >= p[1]}
+	}
+	prev := d.convoys
+	d.convoys = next
+	if prev == nil {
+		return nil
+	}
+
+	var events []pane.Event
+	for _, id := range sortedKeys(next) {
+		cur := next[id]
+		link := pane.EventLink{Pane: pane.PaneConvoys, Key: id}
+		old, existed := prev[id]
+		switch {
+		case !existed:
+			events = append(events, pane.Event{
+				Kind: pane.EventConvoyOpened, Subject: id, Link: link,
+				Message: fmt.Sprintf("convoy opened: %s", cur.title),
+			})
+		case cur.complete && !old.complete:
+			events = append(events, pane.Event{
+				Kind: pane.EventConvoyCompleted, Subject: id, Link: link,
+				Message: fmt.Sprintf("convoy completed: %s", cur.title),
+			})
+		}
+	}
+	return events
+}
+
+func (d *Differ) diffRefinery(statuses []data.RefineryStatus) []pane.Event {
+	next := make(map[string]bool)
+	type entry struct {
+		rig string
+		mr  data.MergeRequest
+	}
+	var fresh []entry
+	for _, s := range statuses {
+		for _, mr := range s.History {
+			next[mr.ID] = true
+			if d.mrs != nil && !d.mrs[mr.ID] {
+				fresh = append(fresh, entry{rig: s.Rig, mr: mr})
+			}
+		}
+	}
+	d.mrs = next
+
+	var events []pane.Event
+	for _, e := range fresh {
+		var kind pane.EventKind
+		var verb string
+		switch strings.ToLower(e.mr.Status) {
+		case "merged", "completed":
+			kind, verb = pane.EventMRMerged, "merged"
+		case "failed":
+			kind, verb = pane.EventMRFailed, "failed"
+		case "skipped":
+			kind, verb = pane.EventMRSkipped, "skipped"
+		default:
+			// Closed for a reason the refinery didn't record; nothing
+			// happened worth reporting.
+			continue
+		}
+		events = append(events, pane.Event{
+			Kind: kind, Subject: e.mr.ID,
+			Link:    pane.EventLink{Pane: pane.PaneRefinery, Key: e.rig},
+			Message: fmt.Sprintf("%s MR %s %s: %s", e.rig, e.mr.BeadID, verb, e.mr.Title),
+		})
+	}
+	return events
+}
+
+// mrActionEvent records a merge queue action taken from the Refinery pane,
+// whether or not it succeeded, so the Events log doubles as an audit trail.
+func mrActionEvent(msg pane.MRActionMsg) pane.Event {
+	a := msg.Action
+	text := fmt.Sprintf("%s: %s", a.Rig, a.Describe())
+	if msg.Err != nil {
+		text += " failed: " + msg.Err.Error()
+	}
+	if msg.AuditErr != nil {
+		text += " (not recorded on the MR: " + msg.AuditErr.Error() + ")"
+	}
+	return pane.Event{
+		Kind: pane.EventMRAction, Subject: a.MR,
+		Link:    pane.EventLink{Pane: pane.PaneRefinery, Key: a.Rig},
+		Message: text,
+	}
+}
+
+func (d *Differ) diffMail(messages []pane.MailInfo) []pane.Event {
+	next := make(map[string]bool, len(messages))
+	var events []pane.Event
+	for _, m := range messages {
+		next[m.ID] = true
+		if d.mail == nil || d.mail[m.ID] || m.Read {
+			continue
+		}
+		events = append(events, pane.Event{
+			Kind: pane.EventMailReceived, Subject: m.ID,
+			Link:    pane.EventLink{Pane: pane.PaneMail, Key: m.ID},
+			Message: fmt.Sprintf("mail from %s: %s", m.From, m.Subject),
+		})
+	}
+	d.mail = next
+	return events
+}
+
+func (d *Differ) diffClosed(beads []data.ClosedBeadInfo) []pane.Event {
+	next := make(map[string]bool, len(beads))
+	var events []pane.Event
+	for _, b := range beads {
+		next[b.ID] = true
+		if d.closed == nil || d.closed[b.ID] {
+			continue
+		}
+		events = append(events, pane.Event{
+			Kind: pane.EventIssueClosed, Subject: b.ID,
+			Link:    pane.EventLink{Pane: pane.PaneHistory, Key: b.ID},
+			Message: fmt.Sprintf("%s closed: %s", b.ID, b.Title),
+		})
+	}
+	d.closed = next
+	return events
+}
+
+// sortedKeys returns the keys of m in sorted order so events are emitted
+// deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,243 @@
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+var now = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func kinds(events []pane.Event) []pane.EventKind {
+	out := make([]pane.EventKind, len(events))
+	for i, e := range events {
+		out[i] = e.Kind
+	}
+	return out
+}
+
+func equalKinds(got []pane.Event, want ...pane.EventKind) bool {
+	k := kinds(got)
+	if len(k) != len(want) {
+		return false
+	}
+	for i := range k {
+		if k[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDiffAgents(t *testing.T) {
+	d := NewDiffer()
+	first := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{
+		{Rig: "kt", Name: "amber", Status: "working"},
+		{Rig: "kt", Name: "quartz", Status: "working"},
+		{Rig: "kt", Name: "slate", Status: "idle"},
+	}}
+	if events := d.Diff(first, now); len(events) != 0 {
+		t.Fatalf("first snapshot should be a baseline, got %v", kinds(events))
+	}
+
+	second := pane.AgentUpdateMsg{Agents: []pane.AgentInfo{
+		{Rig: "kt", Name: "amber", Status: "stale"},
+		{Rig: "kt", Name: "quartz", Status: "stuck"},
+		{Rig: "kt", Name: "topaz", Status: "working"},
+	}}
+	events := d.Diff(second, now)
+	if !equalKinds(events, pane.EventAgentStatus, pane.EventAgentStuck, pane.EventAgentStarted, pane.EventAgentGone) {
+		t.Fatalf("unexpected events %v", kinds(events))
+	}
+	stuck := events[1]
+	if stuck.Subject != "kt/quartz" || stuck.Message != "kt/quartz went stuck (was working)" {
+		t.Errorf("stuck event = %+v", stuck)
+	}
+	if stuck.Link != (pane.EventLink{Pane: pane.PaneAgents, Key: "kt/quartz"}) {
+		t.Errorf("stuck link = %+v", stuck.Link)
+	}
+	if !stuck.At.Equal(now) {
+		t.Errorf("At = %v, want %v", stuck.At, now)
+	}
+
+	if events := d.Diff(second, now); len(events) != 0 {
+		t.Errorf("unchanged snapshot should produce no events, got %v", kinds(events))
+	}
+}
+
+func TestDiffSkipsErrors(t *testing.T) {
+	d := NewDiffer()
+	d.Diff(pane.AgentUpdateMsg{Agents: []pane.AgentInfo{{Rig: "kt", Name: "amber", Status: "working"}}}, now)
+
+	// A failed fetch must not look like every agent disappeared.
+	if events := d.Diff(pane.AgentUpdateMsg{Err: errors.New("boom")}, now); len(events) != 0 {
+		t.Errorf("errored update should produce no events, got %v", kinds(events))
+	}
+	if events := d.Diff(pane.ConvoyUpdateMsg{}, now); len(events) != 0 {
+		t.Errorf("failed convoy fetch should produce no events, got %v", kinds(events))
+	}
+	events := d.Diff(pane.AgentUpdateMsg{Agents: []pane.AgentInfo{{Rig: "kt", Name: "amber", Status: "stuck"}}}, now)
+	if !equalKinds(events, pane.EventAgentStuck) {
+		t.Errorf("baseline should survive errors, got %v", kinds(events))
+	}
+}
+
+func TestDiffWitnesses(t *testing.T) {
+	d := NewDiffer()
+	d.Diff(pane.WitnessUpdateMsg{Witnesses: []pane.WitnessInfo{
+		{Rig: "kt", Status: "alive"},
+		{Rig: "gt", Status: "alive"},
+	}}, now)
+
+	events := d.Diff(pane.WitnessUpdateMsg{Witnesses: []pane.WitnessInfo{
+		{Rig: "kt", Status: "dead"},
+		{Rig: "gt", Status: "stale"},
+	}}, now)
+	if !equalKinds(events, pane.EventWitnessStatus, pane.EventWitnessDead) {
+		t.Fatalf("unexpected events %v", kinds(events))
+	}
+	if events[1].Link.Pane != pane.PaneWitness || events[1].Link.Key != "kt" {
+		t.Errorf("witness link = %+v", events[1].Link)
+	}
+}
+
+func TestDiffPRs(t *testing.T) {
+	pending := []data.PRStatusCheck{{Name: "test", Status: "IN_PROGRESS"}}
+	failed := []data.PRStatusCheck{{Name: "test", Status: "COMPLETED", Conclusion: "FAILURE"}}
+	passed := []data.PRStatusCheck{{Name: "test", Status: "COMPLETED", Conclusion: "SUCCESS"}}
+
+	d := NewDiffer()
+	d.Diff(pane.PRUpdateMsg{PRs: []data.PRInfo{
+		{Number: 1, Title: "one", StatusChecks: pending},
+		{Number: 2, Title: "two", StatusChecks: pending},
+		{Number: 3, Title: "three"},
+	}}, now)
+
+	events := d.Diff(pane.PRUpdateMsg{PRs: []data.PRInfo{
+		{Number: 1, Title: "one", StatusChecks: failed},
+		{Number: 2, Title: "two", StatusChecks: passed},
+		{Number: 4, Title: "four"},
+	}}, now)
+	if !equalKinds(events, pane.EventCIFailed, pane.EventCIPassed, pane.EventPROpened, pane.EventPRClosed) {
+		t.Fatalf("unexpected events %v", kinds(events))
+	}
+	if events[0].Link.Key != "1" || events[0].Subject != "#1" {
+		t.Errorf("CI failure event = %+v", events[0])
+	}
+	if events[3].Subject != "#3" {
+		t.Errorf("closed PR subject = %q, want #3", events[3].Subject)
+	}
+}
+
+func TestDiffConvoys(t *testing.T) {
+	d := NewDiffer()
+	d.Diff(pane.ConvoyUpdateMsg{
+		Convoys:  []data.ConvoyInfo{{ID: "c1", Title: "Auth"}},
+		Progress: map[string][2]int{"c1": {1, 2}},
+	}, now)
+
+	events := d.Diff(pane.ConvoyUpdateMsg{
+		Convoys:  []data.ConvoyInfo{{ID: "c1", Title: "Auth"}, {ID: "c2", Title: "Docs"}},
+		Progress: map[string][2]int{"c1": {2, 2}, "c2": {0, 3}},
+	}, now)
+	if !equalKinds(events, pane.EventConvoyCompleted, pane.EventConvoyOpened) {
+		t.Fatalf("unexpected events %v", kinds(events))
+	}
+	if events[0].Message != "convoy completed: Auth" {
+		t.Errorf("message = %q", events[0].Message)
+	}
+}
+
+func TestDiffRefinery(t *testing.T) {
+	d := NewDiffer()
+	d.Diff(pane.RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "kt", History: []data.MergeRequest{{ID: "mr-1", BeadID: "kt-a", Status: "merged"}}},
+	}}, now)
+
+	events := d.Diff(pane.RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "kt", History: []data.MergeRequest{
+			{ID: "mr-3", BeadID: "kt-c", Status: "failed"},
+			{ID: "mr-2", BeadID: "kt-b", Status: "merged"},
+			{ID: "mr-1", BeadID: "kt-a", Status: "merged"},
+		}},
+	}}, now)
+	if !equalKinds(events, pane.EventMRFailed, pane.EventMRMerged) {
+		t.Fatalf("unexpected events %v", kinds(events))
+	}
+	if events[0].Link != (pane.EventLink{Pane: pane.PaneRefinery, Key: "kt"}) {
+		t.Errorf("refinery link = %+v", events[0].Link)
+	}
+}
+
+func TestDiffRefineryByStatus(t *testing.T) {
+	d := NewDiffer()
+	d.Diff(pane.RefineryUpdateMsg{}, now)
+
+	events := d.Diff(pane.RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "kt", History: []data.MergeRequest{
+			{ID: "mr-1", BeadID: "kt-a", Status: "skipped", Title: "Superseded"},
+			{ID: "mr-2", BeadID: "kt-b", Status: "closed"},
+			{ID: "mr-3", BeadID: "kt-c", Status: "completed"},
+		}},
+	}}, now)
+	if !equalKinds(events, pane.EventMRSkipped, pane.EventMRMerged) {
+		t.Fatalf("unexpected events %v", kinds(events))
+	}
+	if events[0].Message != "kt MR kt-a skipped: Superseded" {
+		t.Errorf("message = %q", events[0].Message)
+	}
+}
+
+func TestDiffMRActionIsAudited(t *testing.T) {
+	d := NewDiffer()
+	action := pane.MRAction{Kind: pane.MRBump, Rig: "kt", MR: "kt-mr1", BeadID: "kt-a", Priority: 1}
+	events := d.Diff(pane.MRActionMsg{Action: action}, now)
+	if !equalKinds(events, pane.EventMRAction) || events[0].Message != "kt: bump kt-mr1 (kt-a) to P1" {
+		t.Fatalf("unexpected action events %+v", events)
+	}
+
+	events = d.Diff(pane.MRActionMsg{Action: action, Err: errors.New("exit status 1")}, now)
+	if len(events) != 1 || events[0].Message != "kt: bump kt-mr1 (kt-a) to P1 failed: exit status 1" {
+		t.Errorf("a failed action should be recorded too: %+v", events)
+	}
+
+	events = d.Diff(pane.MRActionMsg{Action: action, AuditErr: errors.New("exit status 2")}, now)
+	if len(events) != 1 || events[0].Message != "kt: bump kt-mr1 (kt-a) to P1 (not recorded on the MR: exit status 2)" {
+		t.Errorf("an action missing from the MR's comments should say so: %+v", events)
+	}
+}
+
+func TestDiffMailAndClosedBeads(t *testing.T) {
+	d := NewDiffer()
+	d.Diff(pane.MailUpdateMsg{Messages: []pane.MailInfo{{ID: "m1"}}}, now)
+	events := d.Diff(pane.MailUpdateMsg{Messages: []pane.MailInfo{
+		{ID: "m1"},
+		{ID: "m2", From: "mayor", Subject: "hi"},
+		{ID: "m3", Read: true},
+	}}, now)
+	if !equalKinds(events, pane.EventMailReceived) || events[0].Message != "mail from mayor: hi" {
+		t.Errorf("unexpected mail events %+v", events)
+	}
+
+	d.Diff(pane.HistoryUpdateMsg{ClosedBeads: []data.ClosedBeadInfo{{ID: "kt-a"}}}, now)
+	events = d.Diff(pane.HistoryUpdateMsg{ClosedBeads: []data.ClosedBeadInfo{
+		{ID: "kt-b", Title: "Fix login"},
+		{ID: "kt-a"},
+	}}, now)
+	if !equalKinds(events, pane.EventIssueClosed) || events[0].Link.Key != "kt-b" {
+		t.Errorf("unexpected closed bead events %+v", events)
+	}
+}
+
+func TestDiffNilAndUnrelated(t *testing.T) {
+	var d *Differ
+	if events := d.Diff(pane.AgentUpdateMsg{}, now); events != nil {
+		t.Error("nil differ should return nil")
+	}
+	if events := NewDiffer().Diff(pane.RigListMsg{}, now); events != nil {
+		t.Error("unrelated message should return nil")
+	}
+}
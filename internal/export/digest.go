@@ -0,0 +1,238 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// Inputs is the raw town data a digest is built from.
+type Inputs struct {
+	ClosedBeads []data.ClosedBeadInfo
+	Refinery    []data.RefineryStatus
+	Agents      []data.AgentDetail
+	Witnesses   []data.WitnessDetail
+	PRs         []data.PRInfo
+	Missing     []string // sources that could not be fetched
+}
+
+// Merge is a merged MR and the rig whose refinery landed it.
+type Merge struct {
+	Rig   string `json:"rig"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Digest summarizes one day: issues closed, convoys completed, PRs merged
+// and incidents.
+type Digest struct {
+	Day       time.Time             `json:"day"` // midnight at the start of the day
+	Closed    []data.ClosedBeadInfo `json:"closed"`
+	Convoys   []data.ClosedBeadInfo `json:"convoys_completed"`
+	Merged    []Merge               `json:"merged"`
+	Incidents []string              `json:"incidents"`
+	Missing   []string              `json:"missing,omitempty"`
+}
+
+// NewDigest picks the events of the day containing day out of in. Current
+// problems (stuck agents, dead witnesses, failing CI) are only incidents of
+// today, since there is no record of past ones.
+func NewDigest(day time.Time, in Inputs, now time.Time) Digest {
+	y, m, d := day.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+	within := func(s string) bool {
+		t := parseTime(s)
+		return !t.IsZero() && !t.Before(start) && t.Before(end)
+	}
+
+	dg := Digest{Day: start, Missing: in.Missing}
+	merged := make(map[string]bool)
+	for _, b := range in.ClosedBeads {
+		if !within(b.ClosedAt) {
+			continue
+		}
+		switch b.IssueType {
+		case "convoy":
+			dg.Convoys = append(dg.Convoys, b)
+		case "mr":
+			// MR beads also close when the refinery fails or skips them.
+			if data.ClosedMRStatus(b) != "merged" {
+				continue
+			}
+			merged[b.ID] = true
+			dg.Merged = append(dg.Merged, Merge{Rig: rigOf(b.ID), ID: b.ID, Title: b.Title})
+		default:
+			dg.Closed = append(dg.Closed, b)
+		}
+	}
+	byClosed := func(beads []data.ClosedBeadInfo) {
+		sort.SliceStable(beads, func(i, j int) bool {
+			return parseTime(beads[i].ClosedAt).Before(parseTime(beads[j].ClosedAt))
+		})
+	}
+	byClosed(dg.Closed)
+	byClosed(dg.Convoys)
+
+	for _, rs := range in.Refinery {
+		for _, mr := range rs.History {
+			switch strings.ToLower(mr.Status) {
+			case "merged", "completed":
+				if within(mr.MergedAt) && !merged[mr.ID] {
+					merged[mr.ID] = true
+					dg.Merged = append(dg.Merged, Merge{Rig: rs.Rig, ID: mr.ID, Title: mr.Title, URL: mr.PRURL})
+				}
+			case "failed":
+				// MergedAt is when any closed MR closed, failed ones too.
+				if within(mr.MergedAt) {
+					dg.Incidents = append(dg.Incidents,
+						fmt.Sprintf("Merge failed in %s: %s (`%s`)", rs.Rig, mr.Title, mr.ID))
+				}
+			}
+		}
+	}
+
+	if !now.Before(start) && now.Before(end) {
+		dg.Incidents = append(dg.Incidents, liveIncidents(in)...)
+	}
+	return dg
+}
+
+// liveIncidents lists problems that are ongoing right now.
+func liveIncidents(in Inputs) []string {
+	var out []string
+	for _, a := range in.Agents {
+		if a.Status == "stuck" {
+			line := fmt.Sprintf("Agent %s/%s is stuck", a.Rig, a.Name)
+			if a.IssueID != "" {
+				line += fmt.Sprintf(" on `%s`", a.IssueID)
+			}
+			out = append(out, line)
+		}
+	}
+	for _, w := range in.Witnesses {
+		if w.Status == "dead" {
+			out = append(out, fmt.Sprintf("Witness for %s is dead", w.Rig))
+		}
+	}
+	for _, pr := range in.PRs {
+		for _, c := range pr.StatusChecks {
+			if c.Conclusion == "FAILURE" {
+				out = append(out, fmt.Sprintf("CI failing on [#%d](%s) %s", pr.Number, pr.URL, pr.Title))
+				break
+			}
+		}
+	}
+	return out
+}
+
+// FetchDigest fetches what a digest needs from src and builds the digest
+// for day. Only a failure to list closed beads is an error; other failed
+// sources are listed in the digest as missing.
+func FetchDigest(src data.Source, day, now time.Time) (Digest, error) {
+	var in Inputs
+	beads, err := src.FetchClosedBeads()
+	if err != nil {
+		return Digest{}, err
+	}
+	in.ClosedBeads = beads
+
+	missing := func(name string, err error) {
+		if err != nil {
+			in.Missing = append(in.Missing, name)
+		}
+	}
+	in.Refinery, err = src.FetchRefineryStatus()
+	missing("refinery", err)
+	in.Agents, err = src.FetchAgents()
+	missing("agents", err)
+	in.Witnesses, err = src.FetchWitnesses()
+	missing("witnesses", err)
+	in.PRs, err = src.FetchPullRequests()
+	missing("pull requests", err)
+	return NewDigest(day, in, now), nil
+}
+
+// Doc renders the digest as Markdown.
+func (dg Digest) Doc() Doc {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Kestral digest — %s\n\n", dg.Day.Format("Monday 2 January 2006"))
+	fmt.Fprintf(&b, "**%s · %s · %s · %s**\n",
+		plural(len(dg.Closed), "issue", "issues")+" closed",
+		plural(len(dg.Convoys), "convoy", "convoys")+" completed",
+		plural(len(dg.Merged), "PR", "PRs")+" merged",
+		plural(len(dg.Incidents), "incident", "incidents"))
+
+	section := func(title string, lines []string) {
+		fmt.Fprintf(&b, "\n### %s\n\n", title)
+		if len(lines) == 0 {
+			b.WriteString("_None._\n")
+		}
+		for _, l := range lines {
+			fmt.Fprintf(&b, "- %s\n", l)
+		}
+	}
+
+	var lines []string
+	for _, c := range dg.Closed {
+		line := fmt.Sprintf("`%s` %s", c.ID, c.Title)
+		if who := shortName(c.Assignee); who != "" {
+			line += " — " + who
+		}
+		lines = append(lines, line)
+	}
+	section("Issues closed", lines)
+
+	lines = nil
+	for _, c := range dg.Convoys {
+		lines = append(lines, fmt.Sprintf("`%s` %s", c.ID, c.Title))
+	}
+	section("Convoys completed", lines)
+
+	lines = nil
+	for _, mr := range dg.Merged {
+		line := fmt.Sprintf("`%s` %s (%s)", mr.ID, mr.Title, mr.Rig)
+		if mr.URL != "" {
+			line += fmt.Sprintf(" — [PR](%s)", mr.URL)
+		}
+		lines = append(lines, line)
+	}
+	section("PRs merged", lines)
+
+	section("Incidents", dg.Incidents)
+
+	if len(dg.Missing) > 0 {
+		fmt.Fprintf(&b, "\n_Could not fetch %s; this digest may be incomplete._\n", strings.Join(dg.Missing, ", "))
+	}
+	return Doc{Name: "digest-" + dg.Day.Format("2006-01-02"), Markdown: b.String()}
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// rigOf returns the rig prefix of a bead ID such as "kt-12".
+func rigOf(id string) string {
+	if i := strings.Index(id, "-"); i > 0 {
+		return id[:i]
+	}
+	return "unknown"
+}
+
+// parseTime parses the timestamp formats bd and gt emit, returning the
+// zero time for anything else.
+func parseTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
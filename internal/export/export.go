@@ -0,0 +1,158 @@
+// Package export renders town data as Markdown for pasting into team chat:
+// the Dashboard, one convoy's issue breakdown, or a daily digest.
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// Doc is a rendered Markdown document.
+type Doc struct {
+	Name     string // short slug for file names, e.g. "dashboard"
+	Markdown string
+}
+
+// stampFormat is how documents date themselves.
+const stampFormat = "2006-01-02 15:04"
+
+// Dashboard renders the town summary the Dashboard pane shows.
+func Dashboard(status *data.TownStatus, sessions []data.SessionInfo, convoys []data.ConvoyInfo, progress map[string][2]int, now time.Time) Doc {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Kestral status — %s\n\n", now.Format(stampFormat))
+
+	running, health := 0, "unknown"
+	if status != nil {
+		health = status.Health()
+		for _, a := range status.Agents {
+			if a.Running {
+				running++
+			}
+		}
+	}
+	fmt.Fprintf(&b, "**Town:** %s · %d agents running · %d tmux sessions\n", health, running, len(sessions))
+
+	if status != nil && len(status.Agents) > 0 {
+		b.WriteString("\n### Agents\n\n| Agent | State |\n|---|---|\n")
+		for _, a := range status.Agents {
+			fmt.Fprintf(&b, "| %s | %s |\n", cell(a.Name), cell(agentState(a)))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n### Convoys (%d open)\n\n", len(convoys))
+	if len(convoys) == 0 {
+		b.WriteString("_None._\n")
+	} else {
+		b.WriteString("| Convoy | ID | Progress |\n|---|---|---|\n")
+		for _, c := range convoys {
+			p := progress[c.ID]
+			fmt.Fprintf(&b, "| %s | `%s` | %s |\n", cell(c.Title), c.ID, fraction(p[0], p[1]))
+		}
+	}
+	return Doc{Name: "dashboard", Markdown: b.String()}
+}
+
+// Convoy renders one convoy's tracked issues, with a count per status.
+func Convoy(c data.ConvoyInfo, issues []data.IssueDetail, now time.Time) Doc {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Convoy: %s (`%s`)\n\n", c.Title, c.ID)
+
+	done := 0
+	counts := make(map[string]int)
+	for _, iss := range issues {
+		if issueDone(iss.Status) {
+			done++
+		}
+		counts[strings.ToLower(iss.Status)]++
+	}
+	fmt.Fprintf(&b, "**Status:** %s · **Progress:** %s · _as of %s_\n",
+		strings.ToLower(c.Status), fraction(done, len(issues)), now.Format(stampFormat))
+
+	if len(issues) == 0 {
+		b.WriteString("\n_No tracked issues._\n")
+		return Doc{Name: "convoy-" + slug(c.ID), Markdown: b.String()}
+	}
+
+	statuses := make([]string, 0, len(counts))
+	for s := range counts {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	parts := make([]string, len(statuses))
+	for i, s := range statuses {
+		parts[i] = fmt.Sprintf("%s: %d", s, counts[s])
+	}
+	fmt.Fprintf(&b, "\n%s\n", strings.Join(parts, " · "))
+
+	b.WriteString("\n| Issue | Title | Status | Assignee |\n|---|---|---|---|\n")
+	for _, iss := range issues {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n",
+			iss.ID, cell(iss.Title), strings.ToLower(iss.Status), cell(shortName(iss.Assignee)))
+	}
+	return Doc{Name: "convoy-" + slug(c.ID), Markdown: b.String()}
+}
+
+// WriteFile saves doc under dir as <name>-<timestamp>.md, creating dir if
+// needed, and returns the file's path.
+func WriteFile(dir string, doc Doc, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating export dir: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.md", doc.Name, now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(doc.Markdown), 0o644); err != nil {
+		return "", fmt.Errorf("writing export: %w", err)
+	}
+	return path, nil
+}
+
+// agentState mirrors the Dashboard pane's state column.
+func agentState(a data.AgentInfo) string {
+	switch {
+	case a.State != "":
+		return a.State
+	case a.Running:
+		return "active"
+	default:
+		return "stopped"
+	}
+}
+
+// issueDone matches the statuses convoy progress counts as done.
+func issueDone(status string) bool {
+	return status == "COMPLETED" || status == "CLOSED"
+}
+
+func fraction(done, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", done, total, done*100/total)
+}
+
+// cell makes s safe inside a Markdown table cell.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// shortName reduces an assignee path like "rig/polecats/amber" to "amber".
+func shortName(assignee string) string {
+	if i := strings.LastIndex(assignee, "/"); i >= 0 {
+		return assignee[i+1:]
+	}
+	return assignee
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// slug makes an ID safe to use in a file name.
+func slug(s string) string {
+	return unsafeName.ReplaceAllString(s, "_")
+}
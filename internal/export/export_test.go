@@ -0,0 +1,142 @@
+package export
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+var now = time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)
+
+func TestDashboard(t *testing.T) {
+	doc := Dashboard(
+		&data.TownStatus{Agents: []data.AgentInfo{
+			{Name: "mayor", Running: true},
+			{Name: "kt/witness", Running: false},
+		}},
+		[]data.SessionInfo{{Name: "gt-kt-mayor"}},
+		[]data.ConvoyInfo{{ID: "kt-c1", Title: "Auth | login"}},
+		map[string][2]int{"kt-c1": {3, 4}},
+		now,
+	)
+	for _, want := range []string{
+		"**Town:** degraded · 1 agents running · 1 tmux sessions",
+		"| kt/witness | stopped |",
+		"| Auth \\| login | `kt-c1` | 3/4 (75%) |",
+	} {
+		if !strings.Contains(doc.Markdown, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, doc.Markdown)
+		}
+	}
+}
+
+func TestConvoyCountsStatuses(t *testing.T) {
+	doc := Convoy(data.ConvoyInfo{ID: "kt-c1", Title: "Auth", Status: "OPEN"}, []data.IssueDetail{
+		{ID: "kt-1", Title: "Login", Status: "CLOSED", Assignee: "kt/polecats/amber"},
+		{ID: "kt-2", Title: "Logout", Status: "IN_PROGRESS"},
+		{ID: "kt-3", Title: "Tokens", Status: "CLOSED"},
+	}, now)
+	if doc.Name != "convoy-kt-c1" {
+		t.Errorf("Name = %q", doc.Name)
+	}
+	for _, want := range []string{
+		"**Progress:** 2/3 (66%)",
+		"closed: 2 · in_progress: 1",
+		"| `kt-1` | Login | closed | amber |",
+	} {
+		if !strings.Contains(doc.Markdown, want) {
+			t.Errorf("convoy missing %q:\n%s", want, doc.Markdown)
+		}
+	}
+}
+
+func TestNewDigestPicksTheDay(t *testing.T) {
+	in := Inputs{
+		ClosedBeads: []data.ClosedBeadInfo{
+			{ID: "kt-2", Title: "Later", ClosedAt: "2026-03-14T15:00:00Z"},
+			{ID: "kt-1", Title: "Earlier", ClosedAt: "2026-03-14T09:00:00Z", Assignee: "kt/polecats/amber"},
+			{ID: "kt-0", Title: "Yesterday", ClosedAt: "2026-03-13T23:59:00Z"},
+			{ID: "kt-c1", Title: "Auth", IssueType: "convoy", ClosedAt: "2026-03-14T16:00:00Z"},
+			{ID: "kt-m1", Title: "Merge kt-1", IssueType: "mr", ClosedAt: "2026-03-14T10:00:00Z", CloseReason: "merged"},
+		},
+		Refinery: []data.RefineryStatus{{Rig: "kt", History: []data.MergeRequest{
+			{ID: "kt-m1", Status: "merged", MergedAt: "2026-03-14T10:00:00Z"},
+			{ID: "kt-m2", Title: "Merge kt-2", Status: "merged", MergedAt: "2026-03-14T15:30:00Z", PRURL: "https://example.com/pr/2"},
+			{ID: "kt-m3", Title: "Merge kt-3", Status: "failed", QueuedAt: "2026-03-13T22:00:00Z", MergedAt: "2026-03-14T12:00:00Z"},
+			{ID: "kt-m4", Title: "Merge kt-4", Status: "failed", QueuedAt: "2026-03-14T20:00:00Z", MergedAt: "2026-03-15T01:00:00Z"},
+		}}},
+		Agents:    []data.AgentDetail{{Name: "quartz", Rig: "kt", Status: "stuck", IssueID: "kt-9"}},
+		Witnesses: []data.WitnessDetail{{Rig: "kt", Status: "alive"}},
+	}
+
+	dg := NewDigest(now, in, now)
+	if len(dg.Closed) != 2 || dg.Closed[0].ID != "kt-1" || dg.Closed[1].ID != "kt-2" {
+		t.Errorf("Closed = %+v, want kt-1 then kt-2", dg.Closed)
+	}
+	if len(dg.Convoys) != 1 || dg.Convoys[0].ID != "kt-c1" {
+		t.Errorf("Convoys = %+v", dg.Convoys)
+	}
+	if len(dg.Merged) != 2 {
+		t.Errorf("Merged = %+v, want the MR bead and kt-m2 once each", dg.Merged)
+	}
+	if len(dg.Incidents) != 2 || !strings.Contains(dg.Incidents[0], "kt-m3") {
+		t.Errorf("Incidents = %v, want kt-m3, which failed today, and the stuck agent", dg.Incidents)
+	}
+
+	md := dg.Doc().Markdown
+	for _, want := range []string{
+		"## Kestral digest — Saturday 14 March 2026",
+		"**2 issues closed · 1 convoy completed · 2 PRs merged · 2 incidents**",
+		"- `kt-1` Earlier — amber",
+		"[PR](https://example.com/pr/2)",
+		"- Agent kt/quartz is stuck on `kt-9`",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("digest missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestDigestOnlyCountsMergedMRBeads(t *testing.T) {
+	in := Inputs{ClosedBeads: []data.ClosedBeadInfo{
+		{ID: "kt-m1", Title: "Merge kt-1", IssueType: "mr", ClosedAt: "2026-03-14T10:00:00Z", CloseReason: "merge failed: conflict"},
+		{ID: "kt-m2", Title: "Merge kt-2", IssueType: "mr", ClosedAt: "2026-03-14T11:00:00Z", CloseReason: "skipped"},
+		{ID: "kt-m3", Title: "Merge kt-3", IssueType: "mr", ClosedAt: "2026-03-14T12:00:00Z",
+			Description: "branch: polecat/amber/kt-3\nmerge_commit: abc123"},
+	}}
+	dg := NewDigest(now, in, now)
+	if len(dg.Merged) != 1 || dg.Merged[0].ID != "kt-m3" {
+		t.Errorf("Merged = %+v, want only kt-m3", dg.Merged)
+	}
+	if len(dg.Closed) != 0 {
+		t.Errorf("MR beads should not count as closed issues: %+v", dg.Closed)
+	}
+}
+
+func TestPastDigestHasNoLiveIncidents(t *testing.T) {
+	in := Inputs{Agents: []data.AgentDetail{{Name: "quartz", Rig: "kt", Status: "stuck"}}}
+	dg := NewDigest(now.AddDate(0, 0, -1), in, now)
+	if len(dg.Incidents) != 0 {
+		t.Errorf("yesterday's digest should not report today's stuck agent: %v", dg.Incidents)
+	}
+	if !strings.Contains(dg.Doc().Markdown, "### Incidents\n\n_None._") {
+		t.Errorf("empty sections should say so:\n%s", dg.Doc().Markdown)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir() + "/exports"
+	path, err := WriteFile(dir, Doc{Name: "dashboard", Markdown: "# hi\n"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := dir + "/dashboard-20260314-180000.md"; path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+	if body, _ := os.ReadFile(path); string(body) != "# hi\n" {
+		t.Errorf("file holds %q", body)
+	}
+}
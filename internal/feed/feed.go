@@ -0,0 +1,170 @@
+// Package feed fetches town data as the pane update messages the TUI and
+// the event differ consume, and polls those feeds outside of a TUI session.
+package feed
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// Agents fetches enriched agent details and returns a pane.AgentUpdateMsg.
+func Agents(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		details, err := f.FetchAgents()
+		agents := make([]pane.AgentInfo, len(details))
+		for i, d := range details {
+			agents[i] = pane.AgentInfo{
+				Name:       d.Name,
+				Rig:        d.Rig,
+				Role:       d.Role,
+				Status:     d.Status,
+				Age:        time.Duration(d.AgeSecs) * time.Second,
+				IssueID:    d.IssueID,
+				IssueTitle: d.IssueTitle,
+				State:      d.State,
+			}
+		}
+		return pane.AgentUpdateMsg{Agents: agents, Err: err}
+	}
+}
+
+// Convoys fetches convoy data with progress and returns a pane.ConvoyUpdateMsg.
+func Convoys(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		msg, _ := FetchConvoys(f)
+		return msg
+	}
+}
+
+// FetchConvoys fetches the open convoys and their tracked issues, and
+// counts each convoy's progress the way the Convoys pane shows it. A failed
+// convoy list returns an empty message, whose nil Progress marks the
+// failure for the panes.
+func FetchConvoys(f data.Source) (pane.ConvoyUpdateMsg, error) {
+	convoys, err := f.FetchConvoys()
+	if err != nil {
+		return pane.ConvoyUpdateMsg{Err: err}, err
+	}
+	progress := make(map[string][2]int)
+	issueMap := make(map[string][]data.IssueDetail)
+	for _, c := range convoys {
+		issues, err := f.FetchTrackedIssues(c.ID)
+		if err != nil {
+			continue
+		}
+		done := 0
+		for _, iss := range issues {
+			if iss.Status == "COMPLETED" || iss.Status == "CLOSED" {
+				done++
+			}
+		}
+		progress[c.ID] = [2]int{done, len(issues)}
+		issueMap[c.ID] = issues
+	}
+	// Stranded convoys are a hint on top of the list; without them every
+	// convoy just shows as open.
+	var stranded map[string]string
+	if list, err := f.FetchStrandedConvoys(); err == nil {
+		stranded = make(map[string]string, len(list))
+		for _, s := range list {
+			stranded[s.ID] = s.Reason
+		}
+	}
+	return pane.ConvoyUpdateMsg{
+		Convoys:  convoys,
+		Progress: progress,
+		Issues:   issueMap,
+		Stranded: stranded,
+	}, nil
+}
+
+// Mail fetches mail messages and returns a pane.MailUpdateMsg.
+func Mail(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		messages, err := f.FetchMail()
+		infos := make([]pane.MailInfo, len(messages))
+		for i, m := range messages {
+			ts, _ := time.Parse(time.RFC3339Nano, m.Timestamp)
+			infos[i] = pane.MailInfo{
+				ID:        m.ID,
+				From:      m.From,
+				To:        m.To,
+				Subject:   m.Subject,
+				Body:      m.Body,
+				Timestamp: ts,
+				Read:      m.Read,
+				Priority:  m.Priority,
+				Type:      m.Type,
+				ThreadID:  m.ThreadID,
+			}
+		}
+		return pane.MailUpdateMsg{Messages: infos, Err: err}
+	}
+}
+
+// Refinery fetches refinery merge queue status and returns a pane.RefineryUpdateMsg.
+func Refinery(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		statuses, err := f.FetchRefineryStatus()
+		return pane.RefineryUpdateMsg{Statuses: statuses, Err: err}
+	}
+}
+
+// Witnesses fetches witness heartbeat data and returns a pane.WitnessUpdateMsg.
+func Witnesses(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		details, err := f.FetchWitnesses()
+		now := time.Now()
+		witnesses := make([]pane.WitnessInfo, len(details))
+		for i, d := range details {
+			var lastHeartbeat time.Duration
+			if d.LastHeartbeat > 0 {
+				lastHeartbeat = now.Sub(time.Unix(d.LastHeartbeat, 0))
+			}
+			var uptime time.Duration
+			if d.SessionCreated > 0 {
+				uptime = now.Sub(time.Unix(d.SessionCreated, 0))
+			}
+			witnesses[i] = pane.WitnessInfo{
+				Rig:           d.Rig,
+				Status:        d.Status,
+				LastHeartbeat: lastHeartbeat,
+				PolecatCount:  d.PolecatCount,
+				Uptime:        uptime,
+				HasSession:    d.HasSession,
+			}
+		}
+		return pane.WitnessUpdateMsg{Witnesses: witnesses, Err: err}
+	}
+}
+
+// PRs fetches open PRs and returns a pane.PRUpdateMsg.
+func PRs(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := f.FetchPullRequests()
+		return pane.PRUpdateMsg{PRs: prs, Err: err}
+	}
+}
+
+// History fetches closed beads and all convoys for the history pane.
+func History(f data.Source) tea.Cmd {
+	return func() tea.Msg {
+		beads, beadErr := f.FetchClosedBeads()
+		convoys, convoyErr := f.FetchAllConvoys()
+		var err error
+		if beadErr != nil {
+			err = beadErr
+		} else if convoyErr != nil {
+			err = convoyErr
+		}
+		return pane.HistoryUpdateMsg{
+			ClosedBeads: beads,
+			Convoys:     convoys,
+			Err:         err,
+		}
+	}
+}
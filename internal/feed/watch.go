@@ -0,0 +1,62 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/event"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// Watch polls src on the configured intervals, independently of any TUI
+// session, and passes the events detected between polls to handle. It
+// blocks until ctx is cancelled.
+func Watch(ctx context.Context, src data.Source, cfg config.Config, handle func([]pane.Event)) {
+	sources := []struct {
+		fetch    tea.Cmd
+		interval int
+	}{
+		{Agents(src), cfg.PollInterval.Agents},
+		{Witnesses(src), cfg.PollInterval.Witnesses},
+		{PRs(src), cfg.PollInterval.PRs},
+		{Convoys(src), cfg.PollInterval.Convoys},
+		{Refinery(src), cfg.PollInterval.Refinery},
+		{Mail(src), cfg.PollInterval.Mail},
+		{History(src), cfg.PollInterval.Convoys},
+	}
+
+	msgs := make(chan tea.Msg)
+	for _, s := range sources {
+		go func(fetch tea.Cmd, interval time.Duration) {
+			for {
+				msg := fetch()
+				select {
+				case msgs <- msg:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case <-time.After(interval):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(s.fetch, time.Duration(s.interval)*time.Second)
+	}
+
+	differ := event.NewDiffer()
+	for {
+		select {
+		case msg := <-msgs:
+			if events := differ.Diff(msg, time.Now()); len(events) > 0 {
+				handle(events)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
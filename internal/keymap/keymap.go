@@ -0,0 +1,289 @@
+// Package keymap defines every remappable key action with its default keys,
+// and merges and checks the overrides from the keybindings config section.
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Global is the scope of app-wide actions. Every other scope is a pane.
+const Global = "global"
+
+// Action is one remappable key action and its defaults.
+type Action struct {
+	Name string
+	Keys []string
+	Help string
+}
+
+// Scopes lists the actions of each scope, in help order.
+var Scopes = map[string][]Action{
+	Global: {
+		{"quit", []string{"q", "ctrl+c"}, "quit"},
+		{"next_pane", []string{"tab"}, "next pane"},
+		{"prev_pane", []string{"shift+tab"}, "prev pane"},
+		{"pane_1", []string{"1"}, "pane 1"},
+		{"pane_2", []string{"2"}, "pane 2"},
+		{"pane_3", []string{"3"}, "pane 3"},
+		{"pane_4", []string{"4"}, "pane 4"},
+		{"pane_5", []string{"5"}, "pane 5"},
+		{"pane_6", []string{"6"}, "pane 6"},
+		{"pane_7", []string{"7"}, "pane 7"},
+		{"pane_8", []string{"8"}, "pane 8"},
+		{"pane_9", []string{"9"}, "pane 9"},
+		{"pane_0", []string{"0"}, "pane 10"},
+		{"pane_picker", []string{" "}, "pane picker"},
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "select"},
+		{"back", []string{"esc"}, "back"},
+		{"refresh", []string{"r"}, "refresh"},
+		{"help", []string{"?"}, "help"},
+		{"focus", []string{"w"}, "next region"},
+		{"layout", []string{"L"}, "layout"},
+		{"theme", []string{"T"}, "theme"},
+		{"yank", []string{"y"}, "copy ID"},
+		{"export", []string{"e"}, "copy as markdown"},
+		{"export_file", []string{"E"}, "save as markdown"},
+		{"town", []string{"g"}, "town"},
+	},
+	"agents": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "detail"},
+		{"back", []string{"esc"}, "back"},
+		{"nudge", []string{"n"}, "nudge"},
+	},
+	"convoys": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "expand"},
+		{"back", []string{"esc", "backspace"}, "back"},
+		{"check", []string{"c"}, "close finished"},
+	},
+	"dispatch": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "sling"},
+		{"back", []string{"esc"}, "back"},
+		{"dispatch", []string{"d"}, "dispatch to idle"},
+	},
+	"events": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "open"},
+	},
+	"history": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"filter_date", []string{"f"}, "date filter"},
+		{"filter_agent", []string{"a"}, "agent filter"},
+		{"filter_type", []string{"t"}, "type filter"},
+	},
+	"mail": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "read"},
+		{"back", []string{"esc"}, "back"},
+	},
+	"new_issue": {
+		{"up", []string{"up"}, "prev field"},
+		{"down", []string{"down"}, "next field"},
+		{"left", []string{"left", "h"}, "toggle"},
+		{"right", []string{"right", "l"}, "toggle"},
+		{"submit", []string{"ctrl+s"}, "submit"},
+		{"cancel", []string{"esc"}, "cancel"},
+	},
+	"nudge": {
+		{"send", []string{"enter"}, "send nudge"},
+		{"cancel", []string{"esc"}, "cancel"},
+	},
+	"prs": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"select", []string{"enter"}, "detail"},
+		{"back", []string{"esc"}, "back"},
+	},
+	"refinery": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"prev_rig", []string{"h", "left"}, "prev rig"},
+		{"next_rig", []string{"l", "right"}, "next rig"},
+		{"retry", []string{"R"}, "retry MR"},
+		{"skip", []string{"s"}, "skip MR"},
+		{"front", []string{"f"}, "move MR to front"},
+		{"bump", []string{"b"}, "bump MR priority"},
+		{"select", []string{"enter"}, "MR details / confirm"},
+		{"polecat", []string{"a"}, "open MR's polecat"},
+		{"issue", []string{"i"}, "open MR's issue"},
+		{"back", []string{"esc"}, "cancel"},
+	},
+	"resources": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"sort", []string{"s"}, "sort"},
+	},
+	"services": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"start", []string{"s"}, "start"},
+		{"stop", []string{"x"}, "stop"},
+		{"restart", []string{"R"}, "restart"},
+		{"start_all", []string{"A"}, "start everything"},
+		{"stop_all", []string{"X"}, "stop everything"},
+		{"confirm", []string{"enter"}, "run"},
+		{"back", []string{"esc"}, "cancel"},
+	},
+	"witness": {
+		{"up", []string{"k", "up"}, "up"},
+		{"down", []string{"j", "down"}, "down"},
+		{"nudge", []string{"n"}, "nudge"},
+		{"select", []string{"enter"}, "polecats / open polecat"},
+		{"back", []string{"esc"}, "back"},
+	},
+}
+
+// pickerOnly global actions are only read while the pane picker is open, so
+// panes may reuse their keys.
+var pickerOnly = map[string]bool{"up": true, "down": true, "select": true, "back": true}
+
+// inputScopes capture every key but ctrl+c while focused, so global keys
+// cannot shadow them.
+var inputScopes = map[string]bool{"new_issue": true, "nudge": true}
+
+// Map holds the keys bound to each action, by scope then action name.
+type Map map[string]map[string][]string
+
+// Default returns the built-in bindings.
+func Default() Map {
+	m, _ := New(nil)
+	return m
+}
+
+// New merges overrides into the defaults. It rejects unknown scopes and
+// actions, empty key lists, and keys that would trigger two actions.
+func New(overrides map[string]map[string][]string) (Map, error) {
+	m := make(Map, len(Scopes))
+	for scope, actions := range Scopes {
+		m[scope] = make(map[string][]string, len(actions))
+		for _, a := range actions {
+			m[scope][a.Name] = a.Keys
+		}
+	}
+
+	for _, scope := range sortedKeys(overrides) {
+		if _, ok := Scopes[scope]; !ok {
+			return nil, fmt.Errorf("keybindings: unknown scope %q", scope)
+		}
+		for _, action := range sortedKeys(overrides[scope]) {
+			if _, ok := m[scope][action]; !ok {
+				return nil, fmt.Errorf("keybindings.%s: unknown action %q", scope, action)
+			}
+			keys := overrides[scope][action]
+			if len(keys) == 0 {
+				return nil, fmt.Errorf("keybindings.%s.%s: at least one key is required", scope, action)
+			}
+			m[scope][action] = keys
+		}
+	}
+
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// check reports a key bound to two actions in one scope, or a pane key that
+// a global key would intercept before the pane sees it.
+func (m Map) check() error {
+	global := make(map[string]string)
+	for _, a := range Scopes[Global] {
+		if pickerOnly[a.Name] {
+			continue
+		}
+		for _, k := range m[Global][a.Name] {
+			global[k] = a.Name
+		}
+	}
+
+	for _, scope := range sortedKeys(Scopes) {
+		seen := make(map[string]string)
+		for _, a := range Scopes[scope] {
+			for _, k := range m[scope][a.Name] {
+				if other, ok := seen[k]; ok {
+					return fmt.Errorf("keybindings.%s: %q is bound to both %s and %s", scope, k, other, a.Name)
+				}
+				seen[k] = a.Name
+				if scope == Global || inputScopes[scope] {
+					continue
+				}
+				if g, ok := global[k]; ok {
+					return fmt.Errorf("keybindings.%s.%s: %q is already the global %s key", scope, a.Name, k, g)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Binding returns a key.Binding for action in scope whose help shows its
+// current keys.
+func (m Map) Binding(scope, action string) key.Binding {
+	keys := m[scope][action]
+	desc := action
+	for _, a := range Scopes[scope] {
+		if a.Name == action {
+			desc = a.Help
+		}
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(HelpKeys(keys), desc))
+}
+
+// HelpKeys formats keys for the help view, e.g. "k/↑".
+func HelpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = Display(k)
+	}
+	return strings.Join(names, "/")
+}
+
+// Label returns the display name of b's first key, for footer hints.
+func Label(b key.Binding) string {
+	keys := b.Keys()
+	if len(keys) == 0 {
+		return ""
+	}
+	return Display(keys[0])
+}
+
+// Display returns the display name of a single key.
+func Display(k string) string {
+	switch k {
+	case " ":
+		return "space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	default:
+		return k
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
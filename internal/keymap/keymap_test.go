@@ -0,0 +1,82 @@
+package keymap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultHasNoConflicts(t *testing.T) {
+	if _, err := New(nil); err != nil {
+		t.Fatalf("defaults should be valid: %v", err)
+	}
+}
+
+func TestNewAppliesOverrides(t *testing.T) {
+	m, err := New(map[string]map[string][]string{
+		Global:    {"help": {"H"}},
+		"history": {"filter_date": {"d"}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	help := m.Binding(Global, "help")
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")}, help) {
+		t.Error("remapped help should match H")
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}, help) {
+		t.Error("remapping replaces the default keys")
+	}
+	if h := help.Help(); h.Key != "H" || h.Desc != "help" {
+		t.Errorf("help text = %+v", h)
+	}
+	if got := m[Global]["quit"]; len(got) != 2 {
+		t.Errorf("unmapped actions keep their defaults, got %v", got)
+	}
+}
+
+func TestNewRejectsConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]map[string][]string
+		want      string
+	}{
+		{"same scope", map[string]map[string][]string{"agents": {"select": {"j"}}}, "bound to both"},
+		{"shadowed by global", map[string]map[string][]string{"resources": {"sort": {"q"}}}, "global quit"},
+		{"unknown scope", map[string]map[string][]string{"nope": {"up": {"x"}}}, "unknown scope"},
+		{"unknown action", map[string]map[string][]string{Global: {"fly": {"x"}}}, "unknown action"},
+		{"empty", map[string]map[string][]string{Global: {"help": {}}}, "at least one key"},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.overrides)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestPickerKeysMayBeReusedByPanes(t *testing.T) {
+	// Global up/down/select/back only act in the picker, so the default
+	// pane bindings that share them are not conflicts.
+	if _, err := New(map[string]map[string][]string{"witness": {"up": {"k", "up"}}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInputScopeIgnoresGlobalKeys(t *testing.T) {
+	if _, err := New(map[string]map[string][]string{"new_issue": {"submit": {"r"}}}); err != nil {
+		t.Errorf("the New Issue form captures keys, so r is free: %v", err)
+	}
+}
+
+func TestHelpKeys(t *testing.T) {
+	if got := HelpKeys([]string{"k", "up"}); got != "k/↑" {
+		t.Errorf("HelpKeys = %q, want k/↑", got)
+	}
+	if got := Label(key.NewBinding(key.WithKeys(" "))); got != "space" {
+		t.Errorf("Label = %q, want space", got)
+	}
+}
@@ -0,0 +1,246 @@
+// Package metrics exports town health in the Prometheus text exposition
+// format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// NewHandler returns an http.Handler serving /metrics from src. Data is
+// fetched on each scrape, so src should be a data.Cache.
+func NewHandler(src data.Source) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		Write(bw, src, time.Now())
+		bw.Flush()
+	})
+	return mux
+}
+
+// Write fetches town data from src and writes every metric family to w.
+// A source that fails is reported through kestral_source_up and its
+// families are omitted.
+func Write(w *bufio.Writer, src data.Source, now time.Time) {
+	up := map[string]bool{}
+
+	agents, err := src.FetchAgents()
+	up["agents"] = err == nil
+	if err == nil {
+		writeAgents(w, agents)
+	}
+
+	resources, err := src.FetchResources()
+	up["resources"] = err == nil
+	if err == nil {
+		writeResources(w, resources)
+	}
+
+	refinery, err := src.FetchRefineryStatus()
+	up["refinery"] = err == nil
+	if err == nil {
+		writeRefinery(w, refinery)
+	}
+
+	witnesses, err := src.FetchWitnesses()
+	up["witnesses"] = err == nil
+	if err == nil {
+		writeWitnesses(w, witnesses, now)
+	}
+
+	prs, err := src.FetchPullRequests()
+	up["prs"] = err == nil
+	if err == nil {
+		family(w, "kestral_open_prs", "gauge", "Open pull requests.")
+		sample(w, "kestral_open_prs", nil, float64(len(prs)))
+	}
+
+	family(w, "kestral_source_up", "gauge", "Whether the last fetch of each data source succeeded.")
+	for _, name := range sortedKeys(up) {
+		sample(w, "kestral_source_up", labels{"source", name}, boolValue(up[name]))
+	}
+
+	writeCommandStats(w, data.CommandStats())
+}
+
+func writeAgents(w *bufio.Writer, agents []data.AgentDetail) {
+	type group struct{ rig, role, status string }
+	counts := map[group]int{}
+	for _, a := range agents {
+		counts[group{a.Rig, a.Role, a.Status}]++
+	}
+	groups := make([]group, 0, len(counts))
+	for g := range counts {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.rig != b.rig {
+			return a.rig < b.rig
+		}
+		if a.role != b.role {
+			return a.role < b.role
+		}
+		return a.status < b.status
+	})
+
+	family(w, "kestral_agents", "gauge", "Agents by rig, role and status.")
+	for _, g := range groups {
+		sample(w, "kestral_agents", labels{"rig", g.rig, "role", g.role, "status", g.status}, float64(counts[g]))
+	}
+
+	family(w, "kestral_agent_idle_seconds", "gauge", "Seconds since each agent's tmux session was last active.")
+	for _, a := range agents {
+		sample(w, "kestral_agent_idle_seconds", labels{"rig", a.Rig, "agent", a.Name, "role", a.Role}, float64(a.AgeSecs))
+	}
+}
+
+func writeResources(w *bufio.Writer, sessions []data.SessionResource) {
+	family(w, "kestral_session_cpu_percent", "gauge", "CPU usage of all processes in a tmux session.")
+	for _, s := range sessions {
+		sample(w, "kestral_session_cpu_percent", sessionLabels(s.Name), s.CPUPercent)
+	}
+	family(w, "kestral_session_memory_rss_bytes", "gauge", "Resident memory of all processes in a tmux session.")
+	for _, s := range sessions {
+		sample(w, "kestral_session_memory_rss_bytes", sessionLabels(s.Name), float64(s.MemRSS))
+	}
+	family(w, "kestral_session_processes", "gauge", "Processes in a tmux session.")
+	for _, s := range sessions {
+		sample(w, "kestral_session_processes", sessionLabels(s.Name), float64(s.ProcessCount))
+	}
+}
+
+func writeRefinery(w *bufio.Writer, statuses []data.RefineryStatus) {
+	family(w, "kestral_refinery_queue_depth", "gauge", "Merge requests waiting in each rig's refinery queue.")
+	for _, s := range statuses {
+		sample(w, "kestral_refinery_queue_depth", labels{"rig", s.Rig}, float64(s.QueueDepth))
+	}
+	family(w, "kestral_refinery_success_ratio", "gauge", "Fraction of recent merge requests that merged (0-1).")
+	for _, s := range statuses {
+		if len(s.History) == 0 {
+			continue
+		}
+		sample(w, "kestral_refinery_success_ratio", labels{"rig", s.Rig}, s.SuccessRate/100)
+	}
+	family(w, "kestral_refinery_running", "gauge", "Whether the rig's refinery tmux session is up.")
+	for _, s := range statuses {
+		sample(w, "kestral_refinery_running", labels{"rig", s.Rig}, boolValue(s.Running))
+	}
+}
+
+func writeWitnesses(w *bufio.Writer, witnesses []data.WitnessDetail, now time.Time) {
+	family(w, "kestral_witness_heartbeat_age_seconds", "gauge", "Seconds since each witness session was last active.")
+	for _, wd := range witnesses {
+		if wd.LastHeartbeat == 0 {
+			continue
+		}
+		age := now.Sub(time.Unix(wd.LastHeartbeat, 0)).Seconds()
+		sample(w, "kestral_witness_heartbeat_age_seconds", labels{"rig", wd.Rig}, age)
+	}
+	family(w, "kestral_witness_up", "gauge", "Whether the witness has a tmux session.")
+	for _, wd := range witnesses {
+		sample(w, "kestral_witness_up", labels{"rig", wd.Rig}, boolValue(wd.HasSession))
+	}
+	family(w, "kestral_witness_polecats", "gauge", "Polecats managed by each witness.")
+	for _, wd := range witnesses {
+		sample(w, "kestral_witness_polecats", labels{"rig", wd.Rig}, float64(wd.PolecatCount))
+	}
+}
+
+func writeCommandStats(w *bufio.Writer, stats []data.CommandStat) {
+	family(w, "kestral_fetch_duration_seconds", "histogram", "Run time of gt/bd/gh/tmux commands.")
+	for _, s := range stats {
+		for i, le := range data.LatencyBuckets {
+			sample(w, "kestral_fetch_duration_seconds_bucket",
+				labels{"command", s.Command, "le", formatFloat(le)}, float64(s.Buckets[i]))
+		}
+		sample(w, "kestral_fetch_duration_seconds_bucket", labels{"command", s.Command, "le", "+Inf"}, float64(s.Count))
+		sample(w, "kestral_fetch_duration_seconds_sum", labels{"command", s.Command}, s.Seconds)
+		sample(w, "kestral_fetch_duration_seconds_count", labels{"command", s.Command}, float64(s.Count))
+	}
+	family(w, "kestral_fetch_errors_total", "counter", "Failed gt/bd/gh/tmux commands.")
+	for _, s := range stats {
+		sample(w, "kestral_fetch_errors_total", labels{"command", s.Command}, float64(s.Errors))
+	}
+}
+
+// labels is a flat list of alternating label names and values.
+type labels []string
+
+// sessionLabels splits a gt-{rig}-{name} session name into rig, agent and
+// role labels. Other sessions carry only the session label.
+func sessionLabels(session string) labels {
+	l := labels{"session", session}
+	parts := strings.SplitN(session, "-", 3)
+	if len(parts) != 3 || parts[0] != "gt" {
+		return l
+	}
+	// Session names follow FetchAgents: crew workspaces run as
+	// gt-<rig>-crew-<name>.
+	agent, role := parts[2], "polecat"
+	switch agent {
+	case "witness", "refinery", "mayor", "deacon":
+		role = agent
+	default:
+		if crew, ok := strings.CutPrefix(agent, "crew-"); ok {
+			agent, role = crew, "crew"
+		}
+	}
+	return append(l, "rig", parts[1], "agent", agent, "role", role)
+}
+
+func family(w *bufio.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func sample(w *bufio.Writer, name string, l labels, v float64) {
+	w.WriteString(name)
+	if len(l) > 0 {
+		w.WriteByte('{')
+		for i := 0; i+1 < len(l); i += 2 {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", l[i], escape(l[i+1]))
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// fakeSource serves canned data. Unused Source methods are left to the
+// embedded nil interface and panic if called.
+type fakeSource struct {
+	data.Source
+	prErr error
+}
+
+func (fakeSource) FetchAgents() ([]data.AgentDetail, error) {
+	return []data.AgentDetail{
+		{Name: "quartz", Rig: "kt", Role: "polecat", Status: "working", AgeSecs: 30},
+		{Name: "amber", Rig: "kt", Role: "polecat", Status: "working", AgeSecs: 60},
+		{Name: "witness", Rig: "kt", Role: "witness", Status: "stuck", AgeSecs: 3600},
+	}, nil
+}
+
+func (fakeSource) FetchResources() ([]data.SessionResource, error) {
+	return []data.SessionResource{
+		{Name: "gt-kt-quartz", CPUPercent: 12.5, MemRSS: 1024, ProcessCount: 3},
+		{Name: "scratch", CPUPercent: 1},
+	}, nil
+}
+
+func (fakeSource) FetchRefineryStatus() ([]data.RefineryStatus, error) {
+	return []data.RefineryStatus{
+		{Rig: "kt", Running: true, QueueDepth: 4, SuccessRate: 75, History: make([]data.MergeRequest, 4)},
+		{Rig: "empty"},
+	}, nil
+}
+
+func (fakeSource) FetchWitnesses() ([]data.WitnessDetail, error) {
+	return []data.WitnessDetail{
+		{Rig: "kt", LastHeartbeat: testNow.Add(-90 * time.Second).Unix(), HasSession: true, PolecatCount: 2},
+	}, nil
+}
+
+func (f fakeSource) FetchPullRequests() ([]data.PRInfo, error) {
+	if f.prErr != nil {
+		return nil, f.prErr
+	}
+	return []data.PRInfo{{Number: 1}, {Number: 2}}, nil
+}
+
+var testNow = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func render(src data.Source) string {
+	var b strings.Builder
+	w := bufio.NewWriter(&b)
+	Write(w, src, testNow)
+	w.Flush()
+	return b.String()
+}
+
+func TestWriteGauges(t *testing.T) {
+	out := render(fakeSource{})
+	for _, want := range []string{
+		`kestral_agents{rig="kt",role="polecat",status="working"} 2`,
+		`kestral_agents{rig="kt",role="witness",status="stuck"} 1`,
+		`kestral_agent_idle_seconds{rig="kt",agent="quartz",role="polecat"} 30`,
+		`kestral_session_cpu_percent{session="gt-kt-quartz",rig="kt",agent="quartz",role="polecat"} 12.5`,
+		`kestral_session_memory_rss_bytes{session="gt-kt-quartz",rig="kt",agent="quartz",role="polecat"} 1024`,
+		`kestral_session_cpu_percent{session="scratch"} 1`,
+		`kestral_refinery_queue_depth{rig="kt"} 4`,
+		`kestral_refinery_success_ratio{rig="kt"} 0.75`,
+		`kestral_refinery_running{rig="kt"} 1`,
+		`kestral_witness_heartbeat_age_seconds{rig="kt"} 90`,
+		`kestral_witness_polecats{rig="kt"} 2`,
+		`kestral_open_prs 2`,
+		`kestral_source_up{source="prs"} 1`,
+		"# TYPE kestral_agents gauge",
+		"# TYPE kestral_fetch_duration_seconds histogram",
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	if strings.Contains(out, `kestral_refinery_success_ratio{rig="empty"}`) {
+		t.Error("success ratio should be omitted for rigs without history")
+	}
+}
+
+func TestWriteSourceDown(t *testing.T) {
+	out := render(fakeSource{prErr: errors.New("gh not authenticated")})
+	if !strings.Contains(out, `kestral_source_up{source="prs"} 0`) {
+		t.Error("failed source should report up=0")
+	}
+	if strings.Contains(out, "kestral_open_prs ") {
+		t.Error("failed source should not export stale gauges")
+	}
+}
+
+func TestWriteCommandStats(t *testing.T) {
+	var b strings.Builder
+	w := bufio.NewWriter(&b)
+	writeCommandStats(w, []data.CommandStat{{
+		Command: "gt status",
+		Count:   3,
+		Errors:  1,
+		Seconds: 1.5,
+		Buckets: []uint64{0, 1, 1, 2, 3, 3, 3, 3, 3},
+	}})
+	w.Flush()
+	out := b.String()
+	for _, want := range []string{
+		`kestral_fetch_duration_seconds_bucket{command="gt status",le="0.1"} 1`,
+		`kestral_fetch_duration_seconds_bucket{command="gt status",le="+Inf"} 3`,
+		`kestral_fetch_duration_seconds_sum{command="gt status"} 1.5`,
+		`kestral_fetch_duration_seconds_count{command="gt status"} 3`,
+		`kestral_fetch_errors_total{command="gt status"} 1`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if got := escape("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("escape = %q", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(fakeSource{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "kestral_open_prs 2") {
+		t.Error("body should contain metrics")
+	}
+}
+
+func TestSessionLabelsRoles(t *testing.T) {
+	tests := []struct {
+		session string
+		want    labels
+	}{
+		{"gt-kt-quartz", labels{"session", "gt-kt-quartz", "rig", "kt", "agent", "quartz", "role", "polecat"}},
+		{"gt-kt-deacon", labels{"session", "gt-kt-deacon", "rig", "kt", "agent", "deacon", "role", "deacon"}},
+		{"gt-kt-crew-max", labels{"session", "gt-kt-crew-max", "rig", "kt", "agent", "max", "role", "crew"}},
+		{"scratch", labels{"session", "scratch"}},
+	}
+	for _, tt := range tests {
+		if got := sessionLabels(tt.session); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sessionLabels(%q) = %v, want %v", tt.session, got, tt.want)
+		}
+	}
+}
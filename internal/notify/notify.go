@@ -0,0 +1,262 @@
+// Package notify delivers events to outbound HTTP webhooks such as chat
+// integrations or ntfy topics.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// DefaultEvents are delivered by webhooks that do not list their own.
+var DefaultEvents = []pane.EventKind{
+	pane.EventAgentStuck,
+	pane.EventWitnessDead,
+	pane.EventCIFailed,
+	pane.EventConvoyCompleted,
+}
+
+const (
+	queueSize   = 100              // pending deliveries per webhook
+	baseBackoff = time.Second      // delay before the first retry, doubled per attempt
+	rateWindow  = time.Minute      // window for rate_limit
+	httpTimeout = 10 * time.Second // per request
+)
+
+// Payload is the data passed to body templates and, by default, sent as
+// the JSON request body.
+type Payload struct {
+	Kind    string    `json:"kind"`
+	Subject string    `json:"subject"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
+
+// Notifier fans events out to the configured webhooks. Each webhook has its
+// own queue and worker, so a slow endpoint does not delay the others.
+type Notifier struct {
+	hooks  []*hook
+	wg     sync.WaitGroup
+	mu     sync.Mutex // guards closed and sends on hook queues
+	closed bool
+}
+
+// hook is a single webhook with its delivery state.
+type hook struct {
+	cfg     config.Webhook
+	kinds   map[pane.EventKind]bool
+	tmpl    *template.Template // nil sends the default JSON payload
+	client  *http.Client
+	backoff time.Duration
+	now     func() time.Time
+	sent    []time.Time // delivery times within the rate window
+	queue   chan Payload
+}
+
+// templateFuncs are available in webhook body templates.
+var templateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {"text": {{json .Message}}}.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// New creates a Notifier for cfg and starts its delivery workers. It
+// returns an error for unknown event kinds or invalid body templates.
+func New(cfg config.Notify) (*Notifier, error) {
+	known := make(map[pane.EventKind]bool, len(pane.EventKinds))
+	for _, k := range pane.EventKinds {
+		known[k] = true
+	}
+
+	n := &Notifier{}
+	for i, w := range cfg.Webhooks {
+		h := &hook{
+			cfg:     w,
+			kinds:   make(map[pane.EventKind]bool),
+			client:  &http.Client{Timeout: httpTimeout},
+			backoff: baseBackoff,
+			now:     time.Now,
+			queue:   make(chan Payload, queueSize),
+		}
+		kinds := DefaultEvents
+		if len(w.Events) > 0 {
+			kinds = make([]pane.EventKind, len(w.Events))
+			for j, e := range w.Events {
+				kinds[j] = pane.EventKind(e)
+			}
+		}
+		for _, k := range kinds {
+			if !known[k] {
+				return nil, fmt.Errorf("notify.webhooks[%d]: unknown event kind %q", i, k)
+			}
+			h.kinds[k] = true
+		}
+		if w.Template != "" {
+			tmpl, err := template.New(w.Name).Funcs(templateFuncs).Parse(w.Template)
+			if err != nil {
+				return nil, fmt.Errorf("notify.webhooks[%d]: template: %w", i, err)
+			}
+			h.tmpl = tmpl
+		}
+		n.hooks = append(n.hooks, h)
+	}
+
+	for _, h := range n.hooks {
+		n.wg.Add(1)
+		go func(h *hook) {
+			defer n.wg.Done()
+			h.run()
+		}(h)
+	}
+	return n, nil
+}
+
+// Notify queues events for every webhook subscribed to their kind. It never
+// blocks; events are dropped when a webhook's queue is full or the Notifier
+// is closed.
+func (n *Notifier) Notify(events []pane.Event) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return
+	}
+	for _, e := range events {
+		p := Payload{Kind: string(e.Kind), Subject: e.Subject, Message: e.Message, At: e.At}
+		for _, h := range n.hooks {
+			if !h.kinds[e.Kind] {
+				continue
+			}
+			select {
+			case h.queue <- p:
+			default:
+				log.Printf("notify %s: queue full, dropping %s event", h.cfg.Name, e.Kind)
+			}
+		}
+	}
+}
+
+// Close stops accepting events and waits for queued deliveries to finish.
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		for _, h := range n.hooks {
+			close(h.queue)
+		}
+	}
+	n.mu.Unlock()
+	n.wg.Wait()
+}
+
+// run delivers queued payloads until the queue is closed.
+func (h *hook) run() {
+	for p := range h.queue {
+		if !h.allow() {
+			log.Printf("notify %s: rate limit reached, dropping %s event", h.cfg.Name, p.Kind)
+			continue
+		}
+		if err := h.deliver(p); err != nil {
+			log.Printf("notify %s: %v", h.cfg.Name, err)
+		}
+	}
+}
+
+// allow reports whether another delivery fits within the rate limit and,
+// if so, records it.
+func (h *hook) allow() bool {
+	if h.cfg.RateLimit == 0 {
+		return true
+	}
+	now := h.now()
+	kept := h.sent[:0]
+	for _, t := range h.sent {
+		if now.Sub(t) < rateWindow {
+			kept = append(kept, t)
+		}
+	}
+	h.sent = kept
+	if len(h.sent) >= h.cfg.RateLimit {
+		return false
+	}
+	h.sent = append(h.sent, now)
+	return true
+}
+
+// deliver sends p, retrying with exponential backoff on network errors,
+// 429 and 5xx responses.
+func (h *hook) deliver(p Payload) error {
+	body, err := h.render(p)
+	if err != nil {
+		return fmt.Errorf("rendering body: %w", err)
+	}
+
+	delay := h.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := h.post(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= h.cfg.Retries {
+			return fmt.Errorf("delivering %s event: %w", p.Kind, err)
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post makes a single delivery attempt. The boolean reports whether a
+// failure is worth retrying.
+func (h *hook) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, h.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kestral")
+	for k, v := range h.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("HTTP %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+}
+
+// render builds the request body for p.
+func (h *hook) render(p Payload) ([]byte, error) {
+	if h.tmpl == nil {
+		return json.Marshal(p)
+	}
+	var b bytes.Buffer
+	if err := h.tmpl.Execute(&b, p); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// recorder is a local webhook stand-in that records request bodies and
+// answers with the queued status codes, then 200.
+type recorder struct {
+	mu       sync.Mutex
+	bodies   []string
+	headers  []http.Header
+	statuses []int
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, string(body))
+	r.headers = append(r.headers, req.Header.Clone())
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (r *recorder) requests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.bodies...)
+}
+
+func newTestNotifier(t *testing.T, hooks ...config.Webhook) *Notifier {
+	t.Helper()
+	n, err := New(config.Notify{Webhooks: hooks})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, h := range n.hooks {
+		h.backoff = time.Millisecond
+	}
+	return n
+}
+
+var at = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func stuckEvent() pane.Event {
+	return pane.Event{Kind: pane.EventAgentStuck, Subject: "kt/quartz", Message: "kt/quartz went stuck", At: at}
+}
+
+func TestNotifyDefaultPayload(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{Name: "ops", URL: srv.URL})
+	n.Notify([]pane.Event{
+		stuckEvent(),
+		{Kind: pane.EventAgentStarted, Subject: "kt/amber", At: at}, // not subscribed by default
+	})
+	n.Close()
+
+	bodies := rec.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(bodies))
+	}
+	var p Payload
+	if err := json.Unmarshal([]byte(bodies[0]), &p); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if p.Kind != "agent_stuck" || p.Subject != "kt/quartz" || !p.At.Equal(at) {
+		t.Errorf("unexpected payload %+v", p)
+	}
+	if ct := rec.headers[0].Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
+
+func TestNotifyTemplateAndHeaders(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{
+		Name:     "ntfy",
+		URL:      srv.URL,
+		Events:   []string{"agent_stuck"},
+		Template: `{"text": {{json .Message}}, "at": "{{.At.Format "15:04"}}"}`,
+		Headers:  map[string]string{"Title": "Kestral", "Content-Type": "text/plain"},
+	})
+	n.Notify([]pane.Event{stuckEvent()})
+	n.Close()
+
+	bodies := rec.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(bodies))
+	}
+	if want := `{"text": "kt/quartz went stuck", "at": "15:04"}`; bodies[0] != want {
+		t.Errorf("body = %s, want %s", bodies[0], want)
+	}
+	if rec.headers[0].Get("Title") != "Kestral" {
+		t.Error("configured header should be sent")
+	}
+	if ct := rec.headers[0].Get("Content-Type"); ct != "text/plain" {
+		t.Errorf("configured Content-Type should win, got %q", ct)
+	}
+}
+
+func TestNotifyRetriesWithBackoff(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{Name: "ops", URL: srv.URL, Retries: 2})
+	n.Notify([]pane.Event{stuckEvent()})
+	n.Close()
+
+	if got := len(rec.requests()); got != 3 {
+		t.Errorf("expected 3 attempts (2 retries), got %d", got)
+	}
+}
+
+func TestNotifyGivesUpAfterRetries(t *testing.T) {
+	rec := &recorder{statuses: []int{500, 500, 500, 500}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{Name: "ops", URL: srv.URL, Retries: 1})
+	n.Notify([]pane.Event{stuckEvent()})
+	n.Close()
+
+	if got := len(rec.requests()); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestNotifyNoRetryOnClientError(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusBadRequest}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{Name: "ops", URL: srv.URL, Retries: 3})
+	n.Notify([]pane.Event{stuckEvent()})
+	n.Close()
+
+	if got := len(rec.requests()); got != 1 {
+		t.Errorf("4xx should not be retried, got %d attempts", got)
+	}
+}
+
+func TestNotifyRateLimit(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{Name: "ops", URL: srv.URL, RateLimit: 2})
+	clock := at
+	n.hooks[0].now = func() time.Time { return clock }
+
+	n.Notify([]pane.Event{stuckEvent(), stuckEvent(), stuckEvent()})
+	n.Close()
+
+	if got := len(rec.requests()); got != 2 {
+		t.Errorf("expected 2 deliveries within the rate limit, got %d", got)
+	}
+
+	// The window slides: a minute later there is room again.
+	h := n.hooks[0]
+	clock = at.Add(rateWindow)
+	if !h.allow() {
+		t.Error("delivery should be allowed once the window has passed")
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	if _, err := New(config.Notify{Webhooks: []config.Webhook{
+		{Name: "ops", URL: "http://localhost", Events: []string{"agent_exploded"}},
+	}}); err == nil {
+		t.Error("unknown event kind should be rejected")
+	}
+	if _, err := New(config.Notify{Webhooks: []config.Webhook{
+		{Name: "ops", URL: "http://localhost", Template: "{{.Message"},
+	}}); err == nil {
+		t.Error("invalid template should be rejected")
+	}
+}
+
+func TestNotifyAfterCloseIsIgnored(t *testing.T) {
+	n := newTestNotifier(t, config.Webhook{Name: "ops", URL: "http://127.0.0.1:1"})
+	n.Close()
+	n.Notify([]pane.Event{stuckEvent()}) // must not panic
+	n.Close()
+
+	var nilNotifier *Notifier
+	nilNotifier.Notify([]pane.Event{stuckEvent()})
+	nilNotifier.Close()
+}
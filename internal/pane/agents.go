@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -22,6 +23,7 @@ type AgentInfo struct {
 	Age        time.Duration
 	IssueID    string
 	IssueTitle string
+	State      string // read from its output: waiting, error_loop, rate_limited, done
 }
 
 // AgentUpdateMsg carries fresh agent data to the pane.
@@ -64,10 +66,12 @@ type AgentsPane struct {
 	height        int
 	err           error
 	keys          agentKeys
+	th            *theme.Theme
 	detailMode    bool
 	selectedAgent AgentInfo
 	detailData    *detailViewData
 	detailVP      viewport.Model
+	nudge         nudgeConsole
 }
 
 type agentKeys struct {
@@ -75,29 +79,50 @@ type agentKeys struct {
 	Down   key.Binding
 	Select key.Binding
 	Back   key.Binding
+	Nudge  key.Binding
 }
 
 // NewAgentsPane creates a new Agents pane.
 func NewAgentsPane() *AgentsPane {
 	return &AgentsPane{
 		detailVP: viewport.New(0, 0),
-		keys: agentKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-			Select: key.NewBinding(
-				key.WithKeys("enter"),
-			),
-			Back: key.NewBinding(
-				key.WithKeys("esc"),
-			),
-		},
+		nudge:    newNudgeConsole(),
+		keys:     newAgentKeys(keymap.Default()),
+		th:       theme.Default(),
 	}
 }
 
+func newAgentKeys(km keymap.Map) agentKeys {
+	return agentKeys{
+		Up:     km.Binding("agents", "up"),
+		Down:   km.Binding("agents", "down"),
+		Select: km.Binding("agents", "select"),
+		Back:   km.Binding("agents", "back"),
+		Nudge:  km.Binding("agents", "nudge"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *AgentsPane) SetKeyMap(km keymap.Map) {
+	p.keys = newAgentKeys(km)
+	p.nudge.keys = newNudgeKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *AgentsPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Select, p.keys.Back, p.keys.Nudge}
+}
+
+// CapturesInput reports whether the nudge composer is taking keys.
+func (p *AgentsPane) CapturesInput() bool {
+	return p.detailMode && p.nudge.composing
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *AgentsPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *AgentsPane) ID() PaneID        { return PaneAgents }
 func (p *AgentsPane) Title() string      { return "Agents" }
 func (p *AgentsPane) ShortTitle() string { return "🤖" }
@@ -157,6 +182,11 @@ func (p *AgentsPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			p.detailVP.SetContent(p.renderDetailContent())
 		}
 
+	case NudgeSentMsg, NudgeOutputMsg:
+		if p.nudge.update(msg) && p.detailMode {
+			p.detailVP.SetContent(p.renderDetailContent())
+		}
+
 	case tea.KeyMsg:
 		if p.detailMode {
 			return p.updateDetail(msg)
@@ -180,23 +210,62 @@ func (p *AgentsPane) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case key.Matches(msg, p.keys.Select):
 		if len(p.agents) > 0 && p.cursor < len(p.agents) {
-			p.detailMode = true
-			p.selectedAgent = p.agents[p.cursor]
-			p.detailData = nil
-			p.detailVP.GotoTop()
-			p.detailVP.SetContent(p.renderDetailContent())
-			return p, func() tea.Msg {
-				return AgentSelectedMsg{Agent: p.selectedAgent}
-			}
+			return p, p.openDetail()
+		}
+	case key.Matches(msg, p.keys.Nudge):
+		if len(p.agents) > 0 && p.cursor < len(p.agents) {
+			return p, tea.Batch(p.openDetail(), p.openNudge())
 		}
 	}
 	return p, nil
 }
 
+// openDetail switches to the detail view for the agent under the cursor
+// and returns a command announcing the selection.
+func (p *AgentsPane) openDetail() tea.Cmd {
+	p.detailMode = true
+	p.selectedAgent = p.agents[p.cursor]
+	p.detailData = nil
+	if p.nudge.target != p.selectedAgent.NudgeTarget() {
+		p.nudge.reset()
+	}
+	p.detailVP.GotoTop()
+	p.detailVP.SetContent(p.renderDetailContent())
+	return func() tea.Msg {
+		return AgentSelectedMsg{Agent: p.selectedAgent}
+	}
+}
+
+// openNudge opens the nudge composer for the agent in the detail view.
+func (p *AgentsPane) openNudge() tea.Cmd {
+	cmd := p.nudge.open(p.selectedAgent.NudgeTarget())
+	p.detailVP.SetContent(p.renderDetailContent())
+	return cmd
+}
+
+// OpenNudge opens the detail view and nudge composer for the agent
+// identified by "rig/name". It reports false if no such agent is listed.
+func (p *AgentsPane) OpenNudge(agent string) (tea.Cmd, bool) {
+	if !p.Focus(agent) {
+		return nil, false
+	}
+	return tea.Batch(p.openDetail(), p.openNudge()), true
+}
+
 func (p *AgentsPane) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if p.nudge.composing {
+		a := p.selectedAgent
+		cmd := p.nudge.handleKey(msg, NudgeRequestMsg{Rig: a.Rig, Session: a.Session()})
+		p.detailVP.SetContent(p.renderDetailContent())
+		return p, cmd
+	}
+	if key.Matches(msg, p.keys.Nudge) {
+		return p, p.openNudge()
+	}
 	if key.Matches(msg, p.keys.Back) {
 		p.detailMode = false
 		p.detailData = nil
+		p.nudge.watching = false // the app stops watching with the detail view
 		return p, func() tea.Msg {
 			return AgentDeselectedMsg{}
 		}
@@ -218,7 +287,13 @@ func (p *AgentsPane) View() string {
 }
 
 func (p *AgentsPane) viewDetail() string {
-	footer := theme.MutedStyle.Render("esc to go back  j/k to scroll")
+	if p.nudge.composing {
+		p.detailVP.Height = p.height - 2
+		return p.detailVP.View() + "\n" + p.nudge.view(p.th, p.width)
+	}
+	p.detailVP.Height = p.height - 1
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s to go back  %s to scroll  %s to nudge",
+		keymap.Label(p.keys.Back), scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Nudge)))
 	return p.detailVP.View() + "\n" + TruncateWithEllipsis(footer, p.width)
 }
 
@@ -228,16 +303,19 @@ func (p *AgentsPane) viewList() string {
 	// Header line
 	running := p.Badge()
 	header := fmt.Sprintf("─── AGENTS (%d running) ───", running)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	if n := countNeedsAttention(p.agents); n > 0 {
+		header = fmt.Sprintf("─── AGENTS (%d running, %d need attention) ───", running, n)
+	}
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.agents) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No agents running"))
+		b.WriteString(p.th.MutedStyle.Render("  No agents running"))
 		return b.String()
 	}
 
@@ -265,7 +343,8 @@ func (p *AgentsPane) viewList() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("j/k to scroll  enter for detail")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s to scroll  %s for detail  %s to nudge",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Select), keymap.Label(p.keys.Nudge)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -277,19 +356,24 @@ func (p *AgentsPane) renderDetailContent() string {
 	var b strings.Builder
 
 	// Header
-	icon := statusIcon(a.Status)
+	icon := agentIconFor(p.th, a)
 	header := fmt.Sprintf("─── AGENT: %s ───", a.Name)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	// Status line
 	roleIcon := RoleIcon(a.Role)
 	statusLine := fmt.Sprintf("  %s %s  %s %s  %s", icon, a.Name, roleIcon, a.Role, FormatAge(a.Age))
 	b.WriteString(statusLine)
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if a.State != "" {
+		b.WriteString(agentStateStyle(p.th, a.State).Render("  " + AgentStateLabel(a.State)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Hooked Issue
-	b.WriteString(theme.AccentStyle.Render("Hooked Issue"))
+	b.WriteString(p.th.AccentStyle.Render("Hooked Issue"))
 	b.WriteString("\n")
 	if a.IssueID != "" {
 		maxTitle := p.width - len(a.IssueID) - 4
@@ -298,27 +382,31 @@ func (p *AgentsPane) renderDetailContent() string {
 		}
 		b.WriteString(fmt.Sprintf("  %s: %s", a.IssueID, TruncateWithEllipsis(a.IssueTitle, maxTitle)))
 	} else {
-		b.WriteString(theme.MutedStyle.Render("  (none)"))
+		b.WriteString(p.th.MutedStyle.Render("  (none)"))
 	}
 	b.WriteString("\n\n")
 
+	// The nudge exchange goes above the fetched sections so a reply is
+	// visible without scrolling.
+	b.WriteString(p.nudge.render(p.th, p.width))
+
 	if p.detailData == nil {
-		b.WriteString(theme.MutedStyle.Render("  Loading..."))
+		b.WriteString(p.th.MutedStyle.Render("  Loading..."))
 		return b.String()
 	}
 
 	// Git Branch
-	b.WriteString(theme.AccentStyle.Render("Git Branch"))
+	b.WriteString(p.th.AccentStyle.Render("Git Branch"))
 	b.WriteString("\n")
 	if p.detailData.Branch != "" {
 		b.WriteString("  " + p.detailData.Branch)
 	} else {
-		b.WriteString(theme.MutedStyle.Render("  (unavailable)"))
+		b.WriteString(p.th.MutedStyle.Render("  (unavailable)"))
 	}
 	b.WriteString("\n\n")
 
 	// Recent Commits
-	b.WriteString(theme.AccentStyle.Render("Recent Commits"))
+	b.WriteString(p.th.AccentStyle.Render("Recent Commits"))
 	b.WriteString("\n")
 	if len(p.detailData.Commits) > 0 {
 		for _, c := range p.detailData.Commits {
@@ -327,19 +415,19 @@ func (p *AgentsPane) renderDetailContent() string {
 				maxMsg = 0
 			}
 			line := fmt.Sprintf("  %s %s",
-				theme.MutedStyle.Render(c.Hash),
+				p.th.MutedStyle.Render(c.Hash),
 				TruncateWithEllipsis(c.Message, maxMsg))
 			b.WriteString(line)
 			b.WriteString("\n")
 		}
 	} else {
-		b.WriteString(theme.MutedStyle.Render("  (no commits)"))
+		b.WriteString(p.th.MutedStyle.Render("  (no commits)"))
 		b.WriteString("\n")
 	}
 	b.WriteString("\n")
 
 	// Session Output
-	b.WriteString(theme.AccentStyle.Render("Session Output"))
+	b.WriteString(p.th.AccentStyle.Render("Session Output"))
 	b.WriteString("\n")
 	if p.detailData.Output != "" {
 		lines := strings.Split(strings.TrimRight(p.detailData.Output, "\n"), "\n")
@@ -352,7 +440,7 @@ func (p *AgentsPane) renderDetailContent() string {
 			b.WriteString("\n")
 		}
 	} else {
-		b.WriteString(theme.MutedStyle.Render("  (no output)"))
+		b.WriteString(p.th.MutedStyle.Render("  (no output)"))
 		b.WriteString("\n")
 	}
 
@@ -363,26 +451,33 @@ func (p *AgentsPane) renderDetailContent() string {
 func (p *AgentsPane) renderRows() []string {
 	var rows []string
 	for i, a := range p.agents {
-		icon := statusIcon(a.Status)
+		icon := agentIconFor(p.th, a)
 		name := a.Name
 		role := a.Role
 		age := FormatAge(a.Age)
 
-		// Build the main line: "  ● name     role    age"
+		// Build the main line: "  ● name     role    age    state"
 		selected := i == p.cursor
-		line := formatAgentRow(icon, name, role, age, p.width, selected)
+		line := formatAgentRow(p.th, icon, name, role, age, p.width, selected)
+		if room := p.width - agentRowWidth; a.State != "" && room > 0 {
+			label := TruncateWithEllipsis(AgentStateLabel(a.State), room)
+			line += agentStateStyle(p.th, a.State).Render(label)
+		}
 		rows = append(rows, line)
 
 		// If agent has current work, show it on a second line
 		if a.IssueID != "" {
-			workLine := formatWorkLine(a.IssueID, a.IssueTitle, p.width, selected)
+			workLine := formatWorkLine(p.th, a.IssueID, a.IssueTitle, p.width, selected)
 			rows = append(rows, workLine)
 		}
 	}
 	return rows
 }
 
-func formatAgentRow(icon, name, role, age string, width int, selected bool) string {
+// agentRowWidth is the width of a row from formatAgentRow.
+const agentRowWidth = 2 + 1 + 1 + 12 + 10 + 8
+
+func formatAgentRow(th *theme.Theme, icon, name, role, age string, width int, selected bool) string {
 	// Layout: "  <icon> <name>  <role>  <age>"
 	// Minimum: 2 + icon(1-3) + 1 + name + 2 + role + 2 + age
 	nameCol := 12
@@ -396,12 +491,12 @@ func formatAgentRow(icon, name, role, age string, width int, selected bool) stri
 	line := fmt.Sprintf("  %s %s%s%s", icon, nameStr, roleStr, ageStr)
 
 	if selected {
-		return theme.AccentStyle.Bold(true).Render(line)
+		return th.AccentStyle.Bold(true).Render(line)
 	}
 	return line
 }
 
-func formatWorkLine(issueID, issueTitle string, width int, selected bool) string {
+func formatWorkLine(th *theme.Theme, issueID, issueTitle string, width int, selected bool) string {
 	// Indent to align with name column: "      <issueID>: <title>"
 	maxTitleLen := width - 8 - len(issueID) - 2
 	if maxTitleLen < 0 {
@@ -410,23 +505,23 @@ func formatWorkLine(issueID, issueTitle string, width int, selected bool) string
 	title := TruncateWithEllipsis(issueTitle, maxTitleLen)
 	line := fmt.Sprintf("      %s: %s", issueID, title)
 
-	style := theme.MutedStyle
+	style := th.MutedStyle
 	if selected {
-		style = theme.AccentStyle
+		style = th.AccentStyle
 	}
 	return style.Render(line)
 }
 
-func statusIcon(status string) string {
+func statusIcon(th *theme.Theme, status string) string {
 	switch status {
 	case "working":
-		return theme.IconWorking
+		return th.IconWorking
 	case "stale":
-		return theme.IconStale
+		return th.IconStale
 	case "stuck":
-		return theme.IconStuck
+		return th.IconStuck
 	default: // idle
-		return theme.IconIdle
+		return th.IconIdle
 	}
 }
 
@@ -507,22 +602,32 @@ func RoleIcon(role string) string {
 		return theme.RoleCrew
 	case "mayor":
 		return theme.RoleMayor
+	case "deacon":
+		return theme.RoleDeacon
 	default:
 		return ""
 	}
 }
 
-// AgentStatusFromAge returns "working", "stale", or "stuck" based on activity age.
-// <5m = working (green), <30m = stale (yellow), >30m = stuck (red).
+// AgentStatusFromAge returns "working", "stale", or "stuck" based on
+// activity age, with the default thresholds.
 func AgentStatusFromAge(age time.Duration) string {
-	switch {
-	case age < 5*time.Minute:
-		return "working"
-	case age < 30*time.Minute:
-		return "stale"
-	default:
-		return "stuck"
+	return data.DefaultThresholds().AgentStatus(age)
+}
+
+// Focus moves the cursor to the agent identified by "rig/name", leaving
+// detail mode if necessary.
+func (p *AgentsPane) Focus(key string) bool {
+	for i, a := range p.agents {
+		if a.Rig+"/"+a.Name == key {
+			p.detailMode = false
+			p.detailData = nil
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
 	}
+	return false
 }
 
 // Ensure AgentsPane implements Pane at compile time.
@@ -547,6 +652,8 @@ func DetectRole(name string) string {
 		return "refinery"
 	case "mayor":
 		return "mayor"
+	case "deacon":
+		return "deacon"
 	default:
 		return "polecat"
 	}
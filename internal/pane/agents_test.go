@@ -590,3 +590,49 @@ func TestAgentsPaneDetailViewSizeUpdate(t *testing.T) {
 		t.Errorf("detailVP.Height = %d, want 39", p.detailVP.Height)
 	}
 }
+
+func TestAgentsPaneFocus(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 24)
+	p.agents = []AgentInfo{
+		{Name: "amber", Rig: "kt", Status: "working"},
+		{Name: "quartz", Rig: "kt", Status: "stuck"},
+	}
+	p.detailMode = true
+
+	if !p.Focus("kt/quartz") {
+		t.Fatal("Focus should find kt/quartz")
+	}
+	if p.cursor != 1 {
+		t.Errorf("cursor = %d, want 1", p.cursor)
+	}
+	if p.detailMode {
+		t.Error("Focus should leave detail mode")
+	}
+	if p.Focus("kt/missing") {
+		t.Error("Focus should report unknown agents")
+	}
+}
+
+func TestAgentsPaneShowsOutputState(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 24)
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{
+		{Name: "toast", Rig: "gastown", Role: "polecat", Status: "working", Age: time.Minute, State: data.StateWaiting},
+		{Name: "ace", Rig: "gastown", Role: "polecat", Status: "working", Age: time.Minute, State: data.StateDone},
+		{Name: "nux", Rig: "gastown", Role: "polecat", Status: "working", Age: time.Minute},
+	}})
+
+	view := p.View()
+	for _, want := range []string{"waiting for input", "done, awaiting merge", "1 need attention"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View missing %q:\n%s", want, view)
+		}
+	}
+
+	// A narrow screen drops the label rather than wrapping the row.
+	p.SetSize(agentRowWidth, 24)
+	if strings.Contains(p.View(), "waiting") {
+		t.Error("state label should be left out when there's no room")
+	}
+}
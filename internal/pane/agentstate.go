@@ -0,0 +1,79 @@
+package pane
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
+)
+
+// AgentStateLabel describes a state read from an agent's output, e.g.
+// "waiting for input". Unknown states are returned as they are.
+func AgentStateLabel(state string) string {
+	switch state {
+	case data.StateWaiting:
+		return "waiting for input"
+	case data.StateErrorLoop:
+		return "error loop"
+	case data.StateRateLimited:
+		return "rate limited"
+	case data.StateDone:
+		return "done, awaiting merge"
+	default:
+		return state
+	}
+}
+
+// agentStateStyle colors a state by how urgently it needs a person.
+func agentStateStyle(th *theme.Theme, state string) lipgloss.Style {
+	switch state {
+	case data.StateErrorLoop:
+		return th.FailStyle
+	case data.StateDone:
+		return th.PassStyle
+	default:
+		return th.WarnStyle
+	}
+}
+
+// agentStateIcon returns the icon for a state, in its color.
+func agentStateIcon(th *theme.Theme, state string) string {
+	icon := "!"
+	switch state {
+	case data.StateWaiting:
+		icon = "?"
+	case data.StateErrorLoop:
+		icon = "✗"
+	case data.StateRateLimited:
+		icon = "⧗"
+	case data.StateDone:
+		icon = "✓"
+	}
+	return agentStateStyle(th, state).Render(icon)
+}
+
+// needsAttention reports whether a state means the agent is held up until
+// someone steps in. A finished agent is just waiting on the refinery.
+func needsAttention(state string) bool {
+	return state != "" && state != data.StateDone
+}
+
+// countNeedsAttention counts the agents held up by their state.
+func countNeedsAttention(agents []AgentInfo) int {
+	n := 0
+	for _, a := range agents {
+		if needsAttention(a.State) {
+			n++
+		}
+	}
+	return n
+}
+
+// agentIconFor returns the icon for an agent: its state's, if one was read
+// from its output, otherwise its activity status's.
+func agentIconFor(th *theme.Theme, a AgentInfo) string {
+	if a.State != "" {
+		return agentStateIcon(th, a.State)
+	}
+	return statusIcon(th, a.Status)
+}
@@ -12,29 +12,41 @@ import (
 
 const bdTimeout = 15 * time.Second
 
-// runBdCreate executes bd create with the given args and returns stdout.
-func runBdCreate(args []string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), bdTimeout)
+// runTownCmd executes name with args in dir and returns stdout. Errors
+// carry the command's stderr when it printed any.
+func runTownCmd(dir string, timeout time.Duration, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "bd", args...)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	label := name
+	if len(args) > 0 {
+		label += " " + args[0]
+	}
 	if err := cmd.Run(); err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("bd timed out after %v", bdTimeout)
+			return "", fmt.Errorf("%s timed out after %v", name, timeout)
 		}
 		errMsg := strings.TrimSpace(stderr.String())
 		if errMsg != "" {
-			return "", fmt.Errorf("bd create: %s", errMsg)
+			return "", fmt.Errorf("%s: %s", label, errMsg)
 		}
-		return "", fmt.Errorf("bd create: %w", err)
+		return "", fmt.Errorf("%s: %w", label, err)
 	}
 	return stdout.String(), nil
 }
 
+// runBdCreate executes bd create with the given args in dir and returns
+// stdout.
+func runBdCreate(dir string, args []string) (string, error) {
+	return runTownCmd(dir, bdTimeout, "bd", args...)
+}
+
 // beadIDPattern matches common bead ID formats (e.g., kt-abc1, gt-xyz9).
 var beadIDPattern = regexp.MustCompile(`[a-z]{2,}-[a-z0-9]{3,}`)
 
@@ -1,23 +1,37 @@
 package pane
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
+// convoyCheckTimeout allows for gt convoy check looking at every open
+// convoy.
+const convoyCheckTimeout = 60 * time.Second
+
+// ConvoyCheckMsg delivers the result of gt convoy check.
+type ConvoyCheckMsg struct {
+	Err error
+}
+
 // ConvoysPane displays a scrollable list of convoys with progress bars
 // and an expandable detail view showing tracked issues.
 type ConvoysPane struct {
 	convoys  []data.ConvoyInfo
 	progress map[string][2]int            // convoy ID -> (done, total)
 	issues   map[string][]data.IssueDetail // convoy ID -> tracked issues
+	stranded map[string]string             // convoy ID -> why it is stranded
 	cursor   int
 	offset   int // viewport scroll offset
 	expanded int // -1 = list mode, >= 0 = expanded convoy index
@@ -25,6 +39,14 @@ type ConvoysPane struct {
 	height   int
 	err      error
 	keys     convoyKeys
+	th       *theme.Theme
+	townRoot string // where gt convoy check runs; "" = current directory
+
+	checking  bool              // gt convoy check is running
+	awaiting  bool              // check done, waiting for the refreshed list
+	checkedAt map[string]string // convoys open when the check started, ID -> title
+	result    string            // summary of the last check
+	resultErr error
 }
 
 type convoyKeys struct {
@@ -32,6 +54,7 @@ type convoyKeys struct {
 	Down   key.Binding
 	Enter  key.Binding
 	Back   key.Binding
+	Check  key.Binding
 }
 
 // NewConvoysPane creates a new Convoys pane.
@@ -40,23 +63,41 @@ func NewConvoysPane() *ConvoysPane {
 		progress: make(map[string][2]int),
 		issues:   make(map[string][]data.IssueDetail),
 		expanded: -1,
-		keys: convoyKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-			Enter: key.NewBinding(
-				key.WithKeys("enter"),
-			),
-			Back: key.NewBinding(
-				key.WithKeys("esc", "backspace"),
-			),
-		},
+		keys:     newConvoyKeys(keymap.Default()),
+		th:       theme.Default(),
+	}
+}
+
+func newConvoyKeys(km keymap.Map) convoyKeys {
+	return convoyKeys{
+		Up:    km.Binding("convoys", "up"),
+		Down:  km.Binding("convoys", "down"),
+		Enter: km.Binding("convoys", "select"),
+		Back:  km.Binding("convoys", "back"),
+		Check: km.Binding("convoys", "check"),
 	}
 }
 
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *ConvoysPane) SetKeyMap(km keymap.Map) {
+	p.keys = newConvoyKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *ConvoysPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Enter, p.keys.Back, p.keys.Check}
+}
+
+// SetTownRoot makes gt convoy check run in the town at dir.
+func (p *ConvoysPane) SetTownRoot(dir string) {
+	p.townRoot = dir
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *ConvoysPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *ConvoysPane) ID() PaneID        { return PaneConvoys }
 func (p *ConvoysPane) Title() string      { return "Convoys" }
 func (p *ConvoysPane) ShortTitle() string { return "\U0001F69A" } // 🚚
@@ -79,19 +120,38 @@ func (p *ConvoysPane) Init() tea.Cmd {
 func (p *ConvoysPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ConvoyUpdateMsg:
+		failed := msg.Progress == nil
 		p.convoys = msg.Convoys
 		p.progress = msg.Progress
-		if p.progress == nil {
+		if failed {
 			p.progress = make(map[string][2]int)
 		}
 		if msg.Issues != nil {
 			p.issues = msg.Issues
 		}
+		p.stranded = msg.Stranded
 		if p.expanded >= len(p.convoys) {
 			p.expanded = -1
 		}
+		if p.awaiting && msg.Once {
+			if failed {
+				p.refreshFailed(msg.Err)
+			} else {
+				p.summarizeCheck()
+			}
+		}
 		p.clampScroll()
 
+	case ConvoyCheckMsg:
+		p.checking = false
+		p.resultErr = msg.Err
+		if msg.Err != nil {
+			p.result = "convoy check failed"
+			break
+		}
+		p.awaiting = true
+		p.result = "convoy check done, refreshing..."
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, p.keys.Up):
@@ -99,12 +159,14 @@ func (p *ConvoysPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.cursor--
 				p.scrollToCursor()
 			}
+			p.clearResult()
 		case key.Matches(msg, p.keys.Down):
 			maxCursor := p.maxCursor()
 			if p.cursor < maxCursor {
 				p.cursor++
 				p.scrollToCursor()
 			}
+			p.clearResult()
 		case key.Matches(msg, p.keys.Enter):
 			if p.expanded == -1 {
 				// Expand the selected convoy
@@ -114,6 +176,10 @@ func (p *ConvoysPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					p.offset = 0
 				}
 			}
+		case key.Matches(msg, p.keys.Check):
+			if !p.checking && !p.awaiting {
+				return p, p.startCheck()
+			}
 		case key.Matches(msg, p.keys.Back):
 			if p.expanded >= 0 {
 				// Collapse back to list
@@ -127,6 +193,88 @@ func (p *ConvoysPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return p, nil
 }
 
+// startCheck runs gt convoy check, noting which convoys are open so the
+// refreshed list shows what it closed.
+func (p *ConvoysPane) startCheck() tea.Cmd {
+	p.checking = true
+	p.checkedAt = make(map[string]string, len(p.convoys))
+	for _, c := range p.convoys {
+		p.checkedAt[c.ID] = c.Title
+	}
+	p.result, p.resultErr = "running gt convoy check...", nil
+	dir := p.townRoot
+	return func() tea.Msg {
+		_, err := runTownCmd(dir, convoyCheckTimeout, "gt", "convoy", "check")
+		return ConvoyCheckMsg{Err: err}
+	}
+}
+
+// refreshFailed reports a convoy check whose refreshed list couldn't be
+// fetched, so what it closed is unknown.
+func (p *ConvoysPane) refreshFailed(err error) {
+	p.awaiting = false
+	p.checkedAt = nil
+	if err == nil {
+		err = errors.New("convoy list unavailable")
+	}
+	p.result, p.resultErr = "convoy check done, but the refresh failed", err
+}
+
+// clearResult drops the outcome of a finished convoy check once the user
+// moves on, so the stranded reason shows again.
+func (p *ConvoysPane) clearResult() {
+	if !p.checking && !p.awaiting {
+		p.result, p.resultErr = "", nil
+	}
+}
+
+// summarizeCheck compares the refreshed list with the convoys open when
+// the check started.
+func (p *ConvoysPane) summarizeCheck() {
+	p.awaiting = false
+	open := make(map[string]bool, len(p.convoys))
+	for _, c := range p.convoys {
+		open[c.ID] = true
+	}
+	var closed []string
+	for _, c := range p.sortedCheckedAt() {
+		if !open[c.ID] {
+			closed = append(closed, c.Title)
+		}
+	}
+	switch len(closed) {
+	case 0:
+		p.result = "convoy check: nothing to close"
+	case 1:
+		p.result = "convoy check closed 1 convoy: " + closed[0]
+	default:
+		p.result = fmt.Sprintf("convoy check closed %d convoys: %s", len(closed), strings.Join(closed, ", "))
+	}
+	p.checkedAt = nil
+}
+
+// sortedCheckedAt returns the convoys open at the check in ID order.
+func (p *ConvoysPane) sortedCheckedAt() []data.ConvoyInfo {
+	out := make([]data.ConvoyInfo, 0, len(p.checkedAt))
+	for id, title := range p.checkedAt {
+		out = append(out, data.ConvoyInfo{ID: id, Title: title})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// StrandedCount returns the number of open convoys gt convoy stranded
+// reports as stalled.
+func (p *ConvoysPane) StrandedCount() int {
+	n := 0
+	for _, c := range p.convoys {
+		if _, ok := p.stranded[c.ID]; ok {
+			n++
+		}
+	}
+	return n
+}
+
 func (p *ConvoysPane) View() string {
 	if p.width == 0 || p.height == 0 {
 		return ""
@@ -143,23 +291,25 @@ func (p *ConvoysPane) viewList() string {
 	var b strings.Builder
 
 	header := fmt.Sprintf("─── CONVOYS (%d open) ───", len(p.convoys))
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	if n := p.StrandedCount(); n > 0 {
+		header = fmt.Sprintf("─── CONVOYS (%d open, %d stranded) ───", len(p.convoys), n)
+	}
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.convoys) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No open convoys"))
+		b.WriteString(p.th.MutedStyle.Render("  No open convoys"))
+		b.WriteString("\n")
+		b.WriteString(p.renderInfo())
 		return b.String()
 	}
 
-	contentHeight := p.height - 2 // header + footer
-	if contentHeight < 1 {
-		contentHeight = 1
-	}
+	contentHeight := p.contentHeight()
 
 	rows := p.renderListRows()
 	end := p.offset + contentHeight
@@ -176,12 +326,32 @@ func (p *ConvoysPane) viewList() string {
 		b.WriteString("\n")
 	}
 
-	footer := theme.MutedStyle.Render("j/k scroll  enter expand")
+	b.WriteString(p.renderInfo())
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s scroll  %s expand  %s close finished",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Enter), keymap.Label(p.keys.Check)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
 }
 
+// renderInfo renders the line above the list footer: the outcome of the
+// last convoy check, or why the selected convoy is stranded.
+func (p *ConvoysPane) renderInfo() string {
+	switch {
+	case p.result != "" && p.resultErr != nil:
+		text := TruncateWithEllipsis("  ✗ "+p.result+": "+p.resultErr.Error(), p.width)
+		return p.th.FailStyle.Render(text) + "\n"
+	case p.result != "":
+		return p.th.PassStyle.Render(TruncateWithEllipsis("  ✓ "+p.result, p.width)) + "\n"
+	}
+	if p.cursor < len(p.convoys) {
+		if reason, ok := p.stranded[p.convoys[p.cursor].ID]; ok {
+			return p.th.WarnStyle.Render(TruncateWithEllipsis("  ⚠ stranded: "+reason, p.width)) + "\n"
+		}
+	}
+	return "\n"
+}
+
 // renderListRows produces display rows for the convoy list.
 func (p *ConvoysPane) renderListRows() []string {
 	var rows []string
@@ -199,7 +369,10 @@ func (p *ConvoysPane) renderListRows() []string {
 		bar := p.multiColorBar(c.ID, 10)
 		fraction := fmt.Sprintf("%d/%d", done, total)
 		pctStr := fmt.Sprintf("%3d%%", pct)
-		status := convoyStatusLabel(c.Status)
+		status := convoyStatusLabel(p.th, c.Status)
+		if _, ok := p.stranded[c.ID]; ok {
+			status = p.th.WarnStyle.Render("⚠ stranded")
+		}
 
 		// Layout: "  <bar> <title>  <pct> <fraction>  <status>"
 		titleMaxLen := p.width - 10 - 5 - len(fraction) - len(status) - 8
@@ -218,7 +391,7 @@ func (p *ConvoysPane) renderListRows() []string {
 
 		selected := i == p.cursor
 		if selected {
-			rows = append(rows, theme.AccentStyle.Bold(true).Render(line))
+			rows = append(rows, p.th.AccentStyle.Bold(true).Render(line))
 		} else {
 			rows = append(rows, line)
 		}
@@ -237,16 +410,20 @@ func (p *ConvoysPane) viewDetail() string {
 	}
 
 	header := fmt.Sprintf("─── CONVOY: %s (%d/%d) ───", c.Title, done, total)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	// Convoy metadata
 	statusLine := fmt.Sprintf("  Status: %s    ID: %s",
-		convoyStatusLabel(c.Status),
-		theme.MutedStyle.Render(c.ID),
+		convoyStatusLabel(p.th, c.Status),
+		p.th.MutedStyle.Render(c.ID),
 	)
 	b.WriteString(TruncateWithEllipsis(statusLine, p.width))
 	b.WriteString("\n")
+	if reason, ok := p.stranded[c.ID]; ok {
+		b.WriteString(p.th.WarnStyle.Render(TruncateWithEllipsis("  ⚠ Stranded: "+reason, p.width)))
+		b.WriteString("\n")
+	}
 
 	// Progress bar
 	bar := p.multiColorBar(c.ID, 20)
@@ -258,18 +435,15 @@ func (p *ConvoysPane) viewDetail() string {
 	b.WriteString(barLine)
 	b.WriteString("\n")
 
-	b.WriteString(theme.MutedStyle.Render(strings.Repeat("─", p.width)))
+	b.WriteString(p.th.MutedStyle.Render(strings.Repeat("─", p.width)))
 	b.WriteString("\n")
 
 	// Issue list
 	issues := p.issues[c.ID]
-	contentHeight := p.height - 6 // header + status + bar + separator + footer + 1
-	if contentHeight < 1 {
-		contentHeight = 1
-	}
+	contentHeight := p.contentHeight()
 
 	if len(issues) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No tracked issues"))
+		b.WriteString(p.th.MutedStyle.Render("  No tracked issues"))
 		b.WriteString("\n")
 	} else {
 		rows := p.renderIssueRows(issues)
@@ -292,7 +466,8 @@ func (p *ConvoysPane) viewDetail() string {
 		}
 	}
 
-	footer := theme.MutedStyle.Render("j/k scroll  esc back")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s scroll  %s back",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Back)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -302,7 +477,7 @@ func (p *ConvoysPane) viewDetail() string {
 func (p *ConvoysPane) renderIssueRows(issues []data.IssueDetail) []string {
 	var rows []string
 	for i, iss := range issues {
-		icon := issueStatusIcon(iss.Status)
+		icon := issueStatusIcon(p.th, iss.Status)
 		assignee := iss.Assignee
 		if assignee == "" {
 			assignee = "(unassigned)"
@@ -325,13 +500,13 @@ func (p *ConvoysPane) renderIssueRows(issues []data.IssueDetail) []string {
 			icon,
 			idStr,
 			titleMaxLen, title,
-			StatusColor(iss.Status).Render(statusStr),
-			theme.MutedStyle.Render(assignee),
+			StatusColor(p.th, iss.Status).Render(statusStr),
+			p.th.MutedStyle.Render(assignee),
 		)
 
 		selected := i == p.cursor
 		if selected {
-			rows = append(rows, theme.AccentStyle.Bold(true).Render(line))
+			rows = append(rows, p.th.AccentStyle.Bold(true).Render(line))
 		} else {
 			rows = append(rows, line)
 		}
@@ -345,7 +520,7 @@ func (p *ConvoysPane) multiColorBar(convoyID string, barWidth int) string {
 	if len(issues) == 0 {
 		// Fall back to simple progress bar
 		pr := p.progress[convoyID]
-		return progressBar(pr[0], pr[1], barWidth)
+		return progressBar(p.th, pr[0], pr[1], barWidth)
 	}
 
 	var closed, inProgress, blocked, pending int
@@ -364,7 +539,7 @@ func (p *ConvoysPane) multiColorBar(convoyID string, barWidth int) string {
 
 	total := len(issues)
 	if total == 0 || barWidth <= 0 {
-		return theme.MutedStyle.Render(strings.Repeat("░", barWidth))
+		return p.th.MutedStyle.Render(strings.Repeat("░", barWidth))
 	}
 
 	closedW := closed * barWidth / total
@@ -377,46 +552,46 @@ func (p *ConvoysPane) multiColorBar(convoyID string, barWidth int) string {
 
 	var bar string
 	if closedW > 0 {
-		bar += theme.PassStyle.Render(strings.Repeat("█", closedW))
+		bar += p.th.PassStyle.Render(strings.Repeat("█", closedW))
 	}
 	if inProgW > 0 {
-		bar += lipgloss.NewStyle().Foreground(theme.ColorAccent).Render(strings.Repeat("█", inProgW))
+		bar += lipgloss.NewStyle().Foreground(p.th.Palette.Accent).Render(strings.Repeat("█", inProgW))
 	}
 	if blockedW > 0 {
-		bar += theme.FailStyle.Render(strings.Repeat("█", blockedW))
+		bar += p.th.FailStyle.Render(strings.Repeat("█", blockedW))
 	}
 	if pendingW > 0 {
-		bar += theme.MutedStyle.Render(strings.Repeat("░", pendingW))
+		bar += p.th.MutedStyle.Render(strings.Repeat("░", pendingW))
 	}
 
 	return bar
 }
 
 // issueStatusIcon returns a colored icon for an issue status.
-func issueStatusIcon(status string) string {
+func issueStatusIcon(th *theme.Theme, status string) string {
 	switch status {
 	case "COMPLETED", "CLOSED":
-		return theme.PassStyle.Render("✓")
+		return th.PassStyle.Render("✓")
 	case "IN_PROGRESS":
-		return theme.WarnStyle.Render("●")
+		return th.WarnStyle.Render("●")
 	case "BLOCKED":
-		return theme.FailStyle.Render("✗")
+		return th.FailStyle.Render("✗")
 	default: // OPEN, PENDING
-		return theme.MutedStyle.Render("○")
+		return th.MutedStyle.Render("○")
 	}
 }
 
 // convoyStatusLabel returns a styled status string.
-func convoyStatusLabel(status string) string {
+func convoyStatusLabel(th *theme.Theme, status string) string {
 	switch strings.ToLower(status) {
 	case "feeding":
-		return theme.WarnStyle.Render("feeding")
+		return th.WarnStyle.Render("feeding")
 	case "landed":
-		return theme.PassStyle.Render("landed")
+		return th.PassStyle.Render("landed")
 	case "in-progress", "in_progress":
-		return lipgloss.NewStyle().Foreground(theme.ColorAccent).Render("in-progress")
+		return lipgloss.NewStyle().Foreground(th.Palette.Accent).Render("in-progress")
 	default:
-		return theme.MutedStyle.Render(status)
+		return th.MutedStyle.Render(status)
 	}
 }
 
@@ -452,12 +627,17 @@ func (p *ConvoysPane) scrollToCursor() {
 func (p *ConvoysPane) contentHeight() int {
 	if p.expanded >= 0 {
 		h := p.height - 6 // header + status + bar + separator + footer + 1
+		if p.expanded < len(p.convoys) {
+			if _, ok := p.stranded[p.convoys[p.expanded].ID]; ok {
+				h-- // stranded reason
+			}
+		}
 		if h < 1 {
 			return 1
 		}
 		return h
 	}
-	h := p.height - 2 // header + footer
+	h := p.height - 3 // header + info line + footer
 	if h < 1 {
 		return 1
 	}
@@ -495,5 +675,19 @@ func (p *ConvoysPane) clampScroll() {
 	}
 }
 
+// Focus moves the cursor to the convoy with the given ID, collapsing any
+// expanded convoy.
+func (p *ConvoysPane) Focus(key string) bool {
+	for i, c := range p.convoys {
+		if c.ID == key {
+			p.expanded = -1
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
 // Ensure ConvoysPane implements Pane at compile time.
 var _ Pane = (*ConvoysPane)(nil)
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 func TestNewConvoysPane(t *testing.T) {
@@ -288,7 +289,7 @@ func TestIssueStatusIcon(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.status, func(t *testing.T) {
-			icon := issueStatusIcon(tt.status)
+			icon := issueStatusIcon(theme.Default(), tt.status)
 			if icon == "" {
 				t.Errorf("issueStatusIcon(%q) should not be empty", tt.status)
 			}
@@ -307,7 +308,7 @@ func TestConvoyStatusLabel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.status, func(t *testing.T) {
-			label := convoyStatusLabel(tt.status)
+			label := convoyStatusLabel(theme.Default(), tt.status)
 			if label == "" {
 				t.Errorf("convoyStatusLabel(%q) should not be empty", tt.status)
 			}
@@ -380,3 +381,96 @@ func TestConvoysPaneDetailScroll(t *testing.T) {
 		t.Error("detail view should not be empty")
 	}
 }
+
+func strandedConvoys() *ConvoysPane {
+	p := NewConvoysPane()
+	p.SetSize(100, 24)
+	p.Update(ConvoyUpdateMsg{
+		Convoys: []data.ConvoyInfo{
+			{ID: "hq-cv-1", Title: "Auth rework", Status: "open"},
+			{ID: "hq-cv-2", Title: "Docs pass", Status: "open"},
+		},
+		Progress: map[string][2]int{"hq-cv-1": {1, 3}, "hq-cv-2": {2, 2}},
+		Stranded: map[string]string{"hq-cv-1": "no active polecat for 2h"},
+	})
+	return p
+}
+
+func TestConvoysPaneShowsStranded(t *testing.T) {
+	p := strandedConvoys()
+	if p.StrandedCount() != 1 {
+		t.Errorf("StrandedCount() = %d, want 1", p.StrandedCount())
+	}
+	view := p.View()
+	for _, want := range []string{"2 open, 1 stranded", "⚠ stranded", "stranded: no active polecat for 2h"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("list view missing %q:\n%s", want, view)
+		}
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := p.View(); !strings.Contains(view, "Stranded: no active polecat for 2h") {
+		t.Errorf("detail view should give the reason:\n%s", view)
+	}
+}
+
+func TestConvoysPaneCheckSummarizesClosed(t *testing.T) {
+	p := strandedConvoys()
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if cmd == nil || !p.checking {
+		t.Fatal("c should run gt convoy check")
+	}
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}); cmd != nil {
+		t.Error("a second check should wait for the first")
+	}
+
+	p.Update(ConvoyCheckMsg{})
+	// A regular poll that started before the check doesn't count.
+	p.Update(ConvoyUpdateMsg{Convoys: p.convoys})
+	if !p.awaiting {
+		t.Fatal("only the refresh after the check should be summarized")
+	}
+
+	p.Update(ConvoyUpdateMsg{
+		Convoys:  []data.ConvoyInfo{{ID: "hq-cv-1", Title: "Auth rework"}},
+		Progress: map[string][2]int{"hq-cv-1": {1, 3}},
+		Once:     true,
+	})
+	if view := p.View(); !strings.Contains(view, "convoy check closed 1 convoy: Docs pass") {
+		t.Errorf("view should summarize the check:\n%s", view)
+	}
+
+	// Moving on brings back the selected convoy's stranded reason.
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if p.result != "" {
+		t.Errorf("result = %q, want it cleared by the cursor", p.result)
+	}
+}
+
+func TestConvoysPaneCheckRefreshFailure(t *testing.T) {
+	p := strandedConvoys()
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	p.Update(ConvoyCheckMsg{})
+	p.Update(ConvoyUpdateMsg{Once: true, Err: errTest})
+	if p.awaiting {
+		t.Error("a failed refresh should end the check")
+	}
+	view := p.View()
+	if strings.Contains(view, "closed") || !strings.Contains(view, "refresh failed: "+errTest.Error()) {
+		t.Errorf("a failed refresh should not be reported as closing convoys:\n%s", view)
+	}
+}
+
+func TestConvoysPaneCheckFailure(t *testing.T) {
+	p := strandedConvoys()
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	p.Update(ConvoyCheckMsg{Err: errTest})
+	if p.awaiting {
+		t.Error("a failed check should not wait for a refresh")
+	}
+	if view := p.View(); !strings.Contains(view, "convoy check failed") {
+		t.Errorf("view should show the failure:\n%s", view)
+	}
+}
@@ -26,6 +26,28 @@ type ConvoyUpdateMsg struct {
 	Progress map[string][2]int
 	// Issues maps convoy ID to its tracked issue details.
 	Issues map[string][]data.IssueDetail
+	// Stranded maps the ID of each stalled convoy to the reason gt convoy
+	// stranded gives.
+	Stranded map[string]string
+	// Once marks a refresh after gt convoy check, which doesn't schedule
+	// another poll.
+	Once bool
+	// Err is why the convoy list couldn't be fetched, with a nil Progress.
+	Err error
+}
+
+// TownSummary is one configured town's health, for the aggregate view on
+// the dashboard.
+type TownSummary struct {
+	Name    string
+	Status  *data.TownStatus // nil when gt status failed
+	Current bool             // the town this session is looking at
+}
+
+// TownsUpdateMsg delivers the health of every configured town. It is only
+// sent when more than one town is configured.
+type TownsUpdateMsg struct {
+	Towns []TownSummary
 }
 
 // Dashboard is the home screen pane showing system health at a glance.
@@ -33,12 +55,15 @@ type Dashboard struct {
 	width    int
 	height   int
 	viewport viewport.Model
+	th       *theme.Theme
 
 	// Cached data
 	status   *data.TownStatus
 	sessions []data.SessionInfo
 	convoys  []data.ConvoyInfo
 	progress map[string][2]int // convoy ID -> (done, total)
+	towns    []TownSummary
+	agents   []AgentInfo // from the Agents pane's poll, for their states
 	lastUpdate time.Time
 }
 
@@ -48,9 +73,15 @@ func NewDashboard() *Dashboard {
 	return &Dashboard{
 		viewport: vp,
 		progress: make(map[string][2]int),
+		th:       theme.Default(),
 	}
 }
 
+// SetTheme switches the pane to the session's theme.
+func (d *Dashboard) SetTheme(th *theme.Theme) {
+	d.th = th
+}
+
 func (d *Dashboard) ID() PaneID        { return PaneDashboard }
 func (d *Dashboard) Title() string      { return "Dashboard" }
 func (d *Dashboard) ShortTitle() string { return "\U0001F3E0" } // 🏠
@@ -77,6 +108,18 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		d.viewport.SetContent(d.renderContent())
 		return d, nil
 
+	case TownsUpdateMsg:
+		d.towns = msg.Towns
+		d.viewport.SetContent(d.renderContent())
+		return d, nil
+
+	case AgentUpdateMsg:
+		if msg.Err == nil {
+			d.agents = msg.Agents
+			d.viewport.SetContent(d.renderContent())
+		}
+		return d, nil
+
 	case ConvoyUpdateMsg:
 		d.convoys = msg.Convoys
 		d.progress = msg.Progress
@@ -105,7 +148,9 @@ func (d *Dashboard) renderContent() string {
 	var b strings.Builder
 
 	d.renderHeader(&b)
+	d.renderTowns(&b)
 	d.renderAgents(&b)
+	d.renderAgentStates(&b)
 	d.renderConvoys(&b)
 	d.renderSessions(&b)
 
@@ -114,7 +159,7 @@ func (d *Dashboard) renderContent() string {
 
 // renderHeader renders the title and health banner.
 func (d *Dashboard) renderHeader(b *strings.Builder) {
-	titleLine := theme.PaneHeaderStyle.Render(centerPad("KESTRAL", d.width))
+	titleLine := d.th.PaneHeaderStyle.Render(centerPad("KESTRAL", d.width))
 	b.WriteString(titleLine)
 	b.WriteByte('\n')
 	b.WriteString(d.separator())
@@ -122,21 +167,15 @@ func (d *Dashboard) renderHeader(b *strings.Builder) {
 
 	// Health banner
 	healthLabel := "HEALTHY"
-	healthStyle := theme.PassStyle
+	healthStyle := d.th.PassStyle
 	if d.status != nil {
-		stopped := 0
-		for _, a := range d.status.Agents {
-			if !a.Running {
-				stopped++
-			}
-		}
-		if stopped > 0 {
+		if d.status.Health() == "degraded" {
 			healthLabel = "DEGRADED"
-			healthStyle = theme.WarnStyle
+			healthStyle = d.th.WarnStyle
 		}
 	} else {
 		healthLabel = "LOADING"
-		healthStyle = theme.MutedStyle
+		healthStyle = d.th.MutedStyle
 	}
 
 	age := "…"
@@ -145,10 +184,10 @@ func (d *Dashboard) renderHeader(b *strings.Builder) {
 	}
 
 	healthLine := fmt.Sprintf("%s Town: %s    %s %s",
-		theme.PassStyle.Render("⚡"),
+		d.th.PassStyle.Render("⚡"),
 		healthStyle.Render(healthLabel),
-		theme.MutedStyle.Render("↻"),
-		theme.MutedStyle.Render(age),
+		d.th.MutedStyle.Render("↻"),
+		d.th.MutedStyle.Render(age),
 	)
 	b.WriteString(TruncateWithEllipsis(healthLine, d.width))
 	b.WriteByte('\n')
@@ -156,10 +195,54 @@ func (d *Dashboard) renderHeader(b *strings.Builder) {
 	b.WriteByte('\n')
 }
 
+// renderTowns renders one health row per town when several are configured.
+func (d *Dashboard) renderTowns(b *strings.Builder) {
+	if len(d.towns) < 2 {
+		return
+	}
+
+	healthy := 0
+	for _, t := range d.towns {
+		if t.Status != nil && t.Status.Health() == "healthy" {
+			healthy++
+		}
+	}
+	header := fmt.Sprintf("TOWNS         %d/%d healthy", healthy, len(d.towns))
+	b.WriteString(d.th.AccentStyle.Render(header))
+	b.WriteByte('\n')
+
+	for _, t := range d.towns {
+		icon, state := d.th.IconStuck, "unreachable"
+		if t.Status != nil {
+			running := 0
+			for _, a := range t.Status.Agents {
+				if a.Running {
+					running++
+				}
+			}
+			state = fmt.Sprintf("%s  %d/%d agents", t.Status.Health(), running, len(t.Status.Agents))
+			icon = d.th.IconWorking
+			if t.Status.Health() != "healthy" {
+				icon = d.th.IconStale
+			}
+		}
+		name := t.Name
+		if t.Current {
+			name = d.th.AccentStyle.Render(name)
+		}
+		line := fmt.Sprintf("  %s %s  %s", icon, name, d.th.MutedStyle.Render(state))
+		b.WriteString(TruncateWithEllipsis(line, d.width))
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(d.separator())
+	b.WriteByte('\n')
+}
+
 // renderAgents renders the agent summary section.
 func (d *Dashboard) renderAgents(b *strings.Builder) {
 	if d.status == nil {
-		b.WriteString(theme.MutedStyle.Render("AGENTS        …"))
+		b.WriteString(d.th.MutedStyle.Render("AGENTS        …"))
 		b.WriteByte('\n')
 		b.WriteString(d.separator())
 		b.WriteByte('\n')
@@ -174,11 +257,11 @@ func (d *Dashboard) renderAgents(b *strings.Builder) {
 	}
 
 	header := fmt.Sprintf("AGENTS        %d running", running)
-	b.WriteString(theme.AccentStyle.Render(header))
+	b.WriteString(d.th.AccentStyle.Render(header))
 	b.WriteByte('\n')
 
 	for _, a := range d.status.Agents {
-		icon := agentIcon(a)
+		icon := agentIcon(d.th, a)
 		state := a.State
 		if state == "" {
 			if a.Running {
@@ -191,7 +274,7 @@ func (d *Dashboard) renderAgents(b *strings.Builder) {
 		line := fmt.Sprintf("  %s %s", icon, name)
 
 		// Pad and add state right-aligned if there's room
-		stateStr := theme.MutedStyle.Render(state)
+		stateStr := d.th.MutedStyle.Render(state)
 		nameLen := 4 + len([]rune(a.Name)) // "  X "
 		stateLen := len([]rune(state))
 		if nameLen+stateLen+2 <= d.width {
@@ -210,10 +293,40 @@ func (d *Dashboard) renderAgents(b *strings.Builder) {
 	b.WriteByte('\n')
 }
 
+// renderAgentStates lists the agents whose output shows a state, such as
+// waiting for input, that their activity alone doesn't. It is left out
+// when there are none.
+func (d *Dashboard) renderAgentStates(b *strings.Builder) {
+	var flagged []AgentInfo
+	for _, a := range d.agents {
+		if a.State != "" {
+			flagged = append(flagged, a)
+		}
+	}
+	if len(flagged) == 0 {
+		return
+	}
+
+	header := fmt.Sprintf("ATTENTION     %d need attention", countNeedsAttention(flagged))
+	b.WriteString(d.th.AccentStyle.Render(header))
+	b.WriteByte('\n')
+
+	for _, a := range flagged {
+		name := padOrTruncate(a.Rig+"/"+a.Name, 20)
+		label := agentStateStyle(d.th, a.State).Render(AgentStateLabel(a.State))
+		line := fmt.Sprintf("  %s %s %s", agentStateIcon(d.th, a.State), name, label)
+		b.WriteString(TruncateWithEllipsis(line, d.width))
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(d.separator())
+	b.WriteByte('\n')
+}
+
 // renderConvoys renders the convoy summary section with progress bars.
 func (d *Dashboard) renderConvoys(b *strings.Builder) {
 	if d.convoys == nil {
-		b.WriteString(theme.MutedStyle.Render("CONVOYS       …"))
+		b.WriteString(d.th.MutedStyle.Render("CONVOYS       …"))
 		b.WriteByte('\n')
 		b.WriteString(d.separator())
 		b.WriteByte('\n')
@@ -222,11 +335,11 @@ func (d *Dashboard) renderConvoys(b *strings.Builder) {
 
 	openCount := len(d.convoys)
 	header := fmt.Sprintf("CONVOYS       %d open", openCount)
-	b.WriteString(theme.AccentStyle.Render(header))
+	b.WriteString(d.th.AccentStyle.Render(header))
 	b.WriteByte('\n')
 
 	if openCount == 0 {
-		b.WriteString(theme.MutedStyle.Render("  (none)"))
+		b.WriteString(d.th.MutedStyle.Render("  (none)"))
 		b.WriteByte('\n')
 	}
 
@@ -237,7 +350,7 @@ func (d *Dashboard) renderConvoys(b *strings.Builder) {
 			done, total = p[0], p[1]
 		}
 
-		bar := progressBar(done, total, 6)
+		bar := progressBar(d.th, done, total, 6)
 		fraction := fmt.Sprintf("%d/%d", done, total)
 		line := fmt.Sprintf("  %s %s %s",
 			title,
@@ -258,14 +371,14 @@ func (d *Dashboard) renderSessions(b *strings.Builder) {
 	label := "SESSIONS"
 	if count > 0 {
 		b.WriteString(fmt.Sprintf("%s      %d tmux",
-			theme.AccentStyle.Render(label),
+			d.th.AccentStyle.Render(label),
 			count,
 		))
 	} else if d.status == nil {
-		b.WriteString(theme.MutedStyle.Render(label + "      …"))
+		b.WriteString(d.th.MutedStyle.Render(label + "      …"))
 	} else {
 		b.WriteString(fmt.Sprintf("%s      0 tmux",
-			theme.AccentStyle.Render(label),
+			d.th.AccentStyle.Render(label),
 		))
 	}
 	b.WriteByte('\n')
@@ -276,25 +389,25 @@ func (d *Dashboard) separator() string {
 	if d.width <= 0 {
 		return ""
 	}
-	return theme.MutedStyle.Render(strings.Repeat("─", d.width))
+	return d.th.MutedStyle.Render(strings.Repeat("─", d.width))
 }
 
 // agentIcon returns the styled status icon for an agent.
-func agentIcon(a data.AgentInfo) string {
+func agentIcon(th *theme.Theme, a data.AgentInfo) string {
 	switch {
 	case !a.Running:
-		return theme.IconStuck
+		return th.IconStuck
 	case a.State == "idle":
-		return theme.IconIdle
+		return th.IconIdle
 	default:
-		return theme.IconWorking
+		return th.IconWorking
 	}
 }
 
 // progressBar renders a fixed-width progress bar using block characters.
-func progressBar(done, total, barWidth int) string {
+func progressBar(th *theme.Theme, done, total, barWidth int) string {
 	if total <= 0 || barWidth <= 0 {
-		return theme.MutedStyle.Render(strings.Repeat("░", barWidth))
+		return th.MutedStyle.Render(strings.Repeat("░", barWidth))
 	}
 
 	filled := done * barWidth / total
@@ -305,10 +418,10 @@ func progressBar(done, total, barWidth int) string {
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 
 	if done >= total {
-		return theme.PassStyle.Render(bar)
+		return th.PassStyle.Render(bar)
 	}
 	return lipgloss.NewStyle().
-		Foreground(theme.ColorAccent).
+		Foreground(th.Palette.Accent).
 		Render(bar)
 }
 
@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 func TestDashboardInterface(t *testing.T) {
@@ -209,6 +210,29 @@ func TestDashboardDegradedHealth(t *testing.T) {
 	}
 }
 
+func TestDashboardTownsSection(t *testing.T) {
+	d := NewDashboard()
+	d.SetSize(60, 30)
+
+	// A single town has nothing to compare against.
+	d.Update(TownsUpdateMsg{Towns: []TownSummary{{Name: "alpha"}}})
+	if strings.Contains(d.renderContent(), "TOWNS") {
+		t.Error("towns section should be hidden with one town")
+	}
+
+	d.Update(TownsUpdateMsg{Towns: []TownSummary{
+		{Name: "alpha", Current: true, Status: &data.TownStatus{Agents: []data.AgentInfo{{Name: "mayor", Running: true}}}},
+		{Name: "beta", Status: &data.TownStatus{Agents: []data.AgentInfo{{Name: "mayor"}}}},
+		{Name: "gamma"},
+	}})
+	content := d.renderContent()
+	for _, want := range []string{"1/3 healthy", "alpha", "healthy  1/1 agents", "degraded  0/1 agents", "unreachable"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+}
+
 func TestDashboardZeroWidth(t *testing.T) {
 	d := NewDashboard()
 	d.SetSize(0, 0)
@@ -262,7 +286,7 @@ func TestProgressBar(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		bar := progressBar(tt.done, tt.total, tt.width)
+		bar := progressBar(theme.Default(), tt.done, tt.total, tt.width)
 		if bar == "" {
 			t.Errorf("progressBar(%d, %d, %d) should not be empty", tt.done, tt.total, tt.width)
 		}
@@ -285,13 +309,13 @@ func TestAgentIcon(t *testing.T) {
 	idle := data.AgentInfo{Name: "b", Running: true, State: "idle"}
 	stopped := data.AgentInfo{Name: "c", Running: false, State: "stopped"}
 
-	if agentIcon(running) == "" {
+	if agentIcon(theme.Default(), running) == "" {
 		t.Error("running agent icon should not be empty")
 	}
-	if agentIcon(idle) == "" {
+	if agentIcon(theme.Default(), idle) == "" {
 		t.Error("idle agent icon should not be empty")
 	}
-	if agentIcon(stopped) == "" {
+	if agentIcon(theme.Default(), stopped) == "" {
 		t.Error("stopped agent icon should not be empty")
 	}
 }
@@ -332,3 +356,27 @@ func TestDashboardEmptyConvoys(t *testing.T) {
 		t.Error("should show (none) for empty convoy list")
 	}
 }
+
+func TestDashboardListsAgentStates(t *testing.T) {
+	d := NewDashboard()
+	d.SetSize(80, 40)
+	d.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "nux", Rig: "gastown", Status: "working"}}})
+	if strings.Contains(d.renderContent(), "ATTENTION") {
+		t.Error("attention section should be hidden while no agent has a state")
+	}
+
+	d.Update(AgentUpdateMsg{Agents: []AgentInfo{
+		{Name: "toast", Rig: "gastown", Status: "working", State: data.StateErrorLoop},
+		{Name: "ace", Rig: "gastown", Status: "working", State: data.StateDone},
+		{Name: "nux", Rig: "gastown", Status: "working"},
+	}})
+	content := d.renderContent()
+	for _, want := range []string{"1 need attention", "gastown/toast", "error loop", "gastown/ace", "done, awaiting merge"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "gastown/nux") {
+		t.Error("agents without a state should not be listed")
+	}
+}
@@ -0,0 +1,485 @@
+package pane
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
+)
+
+// ReadyUpdateMsg carries fresh bd ready results to the pane.
+type ReadyUpdateMsg struct {
+	Issues []data.ReadyIssue
+	Err    error
+}
+
+// dispatchMode is what the Dispatch pane is showing.
+type dispatchMode int
+
+const (
+	dispatchList     dispatchMode = iota // ready issues
+	dispatchTargets                      // where to sling the selected issue
+	dispatchSlinging                     // waiting for gt sling
+)
+
+// DispatchPane is the ready queue: it lists the issues bd ready reports,
+// ranked by priority and age, shows which rigs have idle polecats, and
+// slings the selected issue to a rig, a polecat or a crew member with gt
+// sling.
+type DispatchPane struct {
+	issues       []data.ReadyIssue
+	err          error
+	rigs         []string
+	agents       []AgentInfo
+	cursor       int
+	offset       int
+	mode         dispatchMode
+	picked       data.ReadyIssue // issue being slung, kept while polls reorder the list
+	targets      []slingTarget   // built when an issue is selected
+	targetCursor int
+	slingSeq     int    // incremented per sling so other slings' results are ignored
+	result       string // outcome of the last sling
+	resultErr    error
+	townRoot     string // where gt sling runs; "" = current directory
+	width        int
+	height       int
+	keys         dispatchKeys
+	th           *theme.Theme
+}
+
+type dispatchKeys struct {
+	Up       key.Binding
+	Down     key.Binding
+	Select   key.Binding
+	Back     key.Binding
+	Dispatch key.Binding
+}
+
+// NewDispatchPane creates a new Dispatch pane.
+func NewDispatchPane() *DispatchPane {
+	return &DispatchPane{
+		keys: newDispatchKeys(keymap.Default()),
+		th:   theme.Default(),
+	}
+}
+
+func newDispatchKeys(km keymap.Map) dispatchKeys {
+	return dispatchKeys{
+		Up:       km.Binding("dispatch", "up"),
+		Down:     km.Binding("dispatch", "down"),
+		Select:   km.Binding("dispatch", "select"),
+		Back:     km.Binding("dispatch", "back"),
+		Dispatch: km.Binding("dispatch", "dispatch"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *DispatchPane) SetKeyMap(km keymap.Map) {
+	p.keys = newDispatchKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *DispatchPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Select, p.keys.Dispatch, p.keys.Back}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *DispatchPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
+// SetTownRoot makes beads get slung in the town at dir.
+func (p *DispatchPane) SetTownRoot(dir string) {
+	p.townRoot = dir
+}
+
+func (p *DispatchPane) ID() PaneID         { return PaneDispatch }
+func (p *DispatchPane) Title() string      { return "Dispatch" }
+func (p *DispatchPane) ShortTitle() string { return "🚀" }
+
+// Badge returns the number of ready issues.
+func (p *DispatchPane) Badge() int { return len(p.issues) }
+
+func (p *DispatchPane) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+	p.clampScroll()
+}
+
+func (p *DispatchPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *DispatchPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ReadyUpdateMsg:
+		if msg.Err == nil || p.issues == nil {
+			p.issues = rankReady(msg.Issues)
+		}
+		p.err = msg.Err
+		p.clampScroll()
+
+	case RigListMsg:
+		if msg.Err == nil {
+			p.rigs = msg.Rigs
+		}
+
+	case AgentUpdateMsg:
+		if msg.Err == nil {
+			p.agents = msg.Agents
+		}
+
+	case SlingResultMsg:
+		if msg.Seq == p.slingSeq && p.mode == dispatchSlinging {
+			p.mode = dispatchList
+			p.resultErr = msg.Err
+			if msg.Err != nil {
+				p.result = fmt.Sprintf("sling %s to %s failed", msg.BeadID, msg.Target)
+			} else {
+				p.result = fmt.Sprintf("slung %s to %s", msg.BeadID, msg.Target)
+			}
+		}
+		if msg.Err != nil {
+			break
+		}
+		// Whoever slung it, it is no longer ready; drop it until the next
+		// poll agrees.
+		for i, iss := range p.issues {
+			if iss.ID == msg.BeadID {
+				p.issues = append(p.issues[:i:i], p.issues[i+1:]...)
+				break
+			}
+		}
+		p.clampScroll()
+
+	case tea.KeyMsg:
+		return p.handleKey(msg)
+	}
+	return p, nil
+}
+
+// sling starts slinging the picked issue to target.
+func (p *DispatchPane) sling(target string) tea.Cmd {
+	p.mode = dispatchSlinging
+	p.slingSeq++
+	return slingCmd(p.townRoot, p.picked.ID, target, p.slingSeq)
+}
+
+func (p *DispatchPane) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch p.mode {
+	case dispatchTargets:
+		switch {
+		case key.Matches(msg, p.keys.Up):
+			if p.targetCursor > 0 {
+				p.targetCursor--
+			}
+		case key.Matches(msg, p.keys.Down):
+			if p.targetCursor < len(p.targets)-1 {
+				p.targetCursor++
+			}
+		case key.Matches(msg, p.keys.Back):
+			p.mode = dispatchList
+		case key.Matches(msg, p.keys.Select):
+			if p.targetCursor < len(p.targets) {
+				return p, p.sling(p.targets[p.targetCursor].Target)
+			}
+		}
+
+	case dispatchList:
+		switch {
+		case key.Matches(msg, p.keys.Up):
+			if p.cursor > 0 {
+				p.cursor--
+				p.scrollToCursor()
+			}
+		case key.Matches(msg, p.keys.Down):
+			if p.cursor < len(p.issues)-1 {
+				p.cursor++
+				p.scrollToCursor()
+			}
+		case key.Matches(msg, p.keys.Select):
+			if p.cursor < len(p.issues) {
+				p.pick()
+			}
+		case key.Matches(msg, p.keys.Dispatch):
+			if p.cursor >= len(p.issues) {
+				break
+			}
+			p.pick()
+			// Straight to the first idle polecat; with none idle, the
+			// targets are shown so a rig can spawn one.
+			for i, t := range p.targets {
+				if isIdlePolecat(t.Agent) {
+					p.targetCursor = i
+					return p, p.sling(t.Target)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// pick opens the sling targets for the issue under the cursor.
+func (p *DispatchPane) pick() {
+	p.picked = p.issues[p.cursor]
+	p.targets = slingTargets(p.rigs, p.agents)
+	p.targetCursor = 0
+	p.mode = dispatchTargets
+}
+
+func (p *DispatchPane) View() string {
+	if p.width == 0 || p.height == 0 {
+		return ""
+	}
+	switch p.mode {
+	case dispatchTargets, dispatchSlinging:
+		return p.renderTargets()
+	default:
+		return p.renderList()
+	}
+}
+
+func (p *DispatchPane) renderList() string {
+	var b strings.Builder
+	header := fmt.Sprintf("─── DISPATCH (%d ready) ───", len(p.issues))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+	b.WriteString(p.renderIdle())
+	b.WriteString("\n")
+
+	if p.err != nil && len(p.issues) == 0 {
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
+		return b.String()
+	}
+	if len(p.issues) == 0 {
+		b.WriteString(p.th.MutedStyle.Render("  No ready issues — everything open is blocked or taken"))
+		b.WriteString("\n")
+		b.WriteString(p.renderResult())
+		return b.String()
+	}
+
+	contentHeight := p.contentHeight()
+	end := p.offset + contentHeight
+	if end > len(p.issues) {
+		end = len(p.issues)
+	}
+	for i := p.offset; i < end; i++ {
+		b.WriteString(p.renderIssue(i))
+		b.WriteString("\n")
+	}
+	for i := end - p.offset; i < contentHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(p.renderResult())
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s dispatch to idle  %s sling to…  %s scroll",
+		keymap.Label(p.keys.Dispatch), keymap.Label(p.keys.Select), scrollKeys(p.keys.Down, p.keys.Up)))
+	b.WriteString(TruncateWithEllipsis(footer, p.width))
+	return b.String()
+}
+
+// renderIdle renders the idle polecats by rig, the ones d dispatches to.
+func (p *DispatchPane) renderIdle() string {
+	var rigs []string
+	idle := make(map[string][]string)
+	for _, t := range slingTargets(p.rigs, p.agents) {
+		if isIdlePolecat(t.Agent) {
+			if idle[t.Agent.Rig] == nil {
+				rigs = append(rigs, t.Agent.Rig)
+			}
+			idle[t.Agent.Rig] = append(idle[t.Agent.Rig], t.Agent.Name)
+		}
+	}
+	if len(rigs) == 0 {
+		return p.th.MutedStyle.Render(TruncateWithEllipsis("  No idle polecats — sling to a rig to spawn one", p.width))
+	}
+	parts := make([]string, len(rigs))
+	for i, rig := range rigs {
+		parts[i] = fmt.Sprintf("%s (%s)", rig, strings.Join(idle[rig], ", "))
+	}
+	return p.th.PassStyle.Render(TruncateWithEllipsis("  Idle: "+strings.Join(parts, " · "), p.width))
+}
+
+func (p *DispatchPane) renderIssue(i int) string {
+	iss := p.issues[i]
+	age := "—"
+	if t := parseTime(iss.CreatedAt); !t.IsZero() {
+		age = formatDuration(time.Since(t))
+	}
+	line := fmt.Sprintf("  P%d %s%s%s%s", iss.Priority,
+		padOrTruncate(iss.ID, 12), padOrTruncate(iss.IssueType, 9), padOrTruncate(age, 6), iss.Title)
+	line = TruncateWithEllipsis(line, p.width)
+	if i == p.cursor {
+		return p.th.AccentStyle.Bold(true).Render(line)
+	}
+	return line
+}
+
+// renderResult renders the last sling's outcome above the footer, or a
+// blank line.
+func (p *DispatchPane) renderResult() string {
+	switch {
+	case p.result == "":
+		return "\n"
+	case p.resultErr != nil:
+		text := TruncateWithEllipsis("  ✗ "+p.result+": "+p.resultErr.Error(), p.width)
+		return p.th.FailStyle.Render(text) + "\n"
+	default:
+		return p.th.PassStyle.Render(TruncateWithEllipsis("  ✓ "+p.result, p.width)) + "\n"
+	}
+}
+
+func (p *DispatchPane) renderTargets() string {
+	var b strings.Builder
+	iss := p.picked
+	header := fmt.Sprintf("─── SLING %s ───", iss.ID)
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+	b.WriteString(TruncateWithEllipsis("  "+iss.Title, p.width))
+	b.WriteString("\n\n")
+
+	if p.mode == dispatchSlinging {
+		target := p.targets[p.targetCursor].Target
+		b.WriteString(p.th.AccentStyle.Render(fmt.Sprintf("  Slinging to %s...", target)))
+		return b.String()
+	}
+	if len(p.targets) == 0 {
+		b.WriteString(p.th.MutedStyle.Render("  No rigs found yet"))
+		b.WriteString("\n")
+	}
+
+	// Keep the cursor in view below the three title lines and above the
+	// footer.
+	rows := p.height - 4
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if p.targetCursor >= rows {
+		start = p.targetCursor - rows + 1
+	}
+	for i := start; i < len(p.targets) && i < start+rows; i++ {
+		b.WriteString(p.renderTarget(i))
+		b.WriteString("\n")
+	}
+
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s sling  %s back",
+		keymap.Label(p.keys.Select), keymap.Label(p.keys.Back)))
+	b.WriteString(TruncateWithEllipsis(footer, p.width))
+	return b.String()
+}
+
+func (p *DispatchPane) renderTarget(i int) string {
+	t := p.targets[i]
+	indent := "  "
+	detail := ""
+	if t.Agent != nil {
+		indent = "    "
+		detail = t.Agent.Status
+		if t.Agent.IssueID != "" {
+			detail += " on " + t.Agent.IssueID
+		}
+	}
+	line := TruncateWithEllipsis(indent+padOrTruncate(t.Label, 32)+" "+detail, p.width)
+	if i == p.targetCursor {
+		return p.th.AccentStyle.Bold(true).Render(line)
+	}
+	if t.Agent == nil {
+		return line
+	}
+	return p.th.MutedStyle.Render(line)
+}
+
+// contentHeight is the number of issue rows that fit between the header
+// and idle lines and the result and footer lines.
+func (p *DispatchPane) contentHeight() int {
+	h := p.height - 4
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// scrollToCursor ensures the cursor row is visible.
+func (p *DispatchPane) scrollToCursor() {
+	h := p.contentHeight()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+h {
+		p.offset = p.cursor - h + 1
+	}
+}
+
+// clampScroll keeps the cursor on an issue and the offset in range.
+func (p *DispatchPane) clampScroll() {
+	if p.cursor >= len(p.issues) {
+		p.cursor = len(p.issues) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	maxOffset := len(p.issues) - p.contentHeight()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	if p.offset > maxOffset {
+		p.offset = maxOffset
+	}
+	p.scrollToCursor()
+}
+
+// Focus moves the cursor to the issue with the given bead ID.
+func (p *DispatchPane) Focus(key string) bool {
+	for i, iss := range p.issues {
+		if iss.ID == key {
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
+// rankReady orders issues by priority, then oldest first, so what has
+// waited longest at a given priority is picked up next. Issues without a
+// creation time go last within their priority.
+func rankReady(issues []data.ReadyIssue) []data.ReadyIssue {
+	ranked := append([]data.ReadyIssue(nil), issues...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		ta, tb := parseTime(a.CreatedAt), parseTime(b.CreatedAt)
+		if ta.IsZero() || tb.IsZero() {
+			return !ta.IsZero() && tb.IsZero()
+		}
+		return ta.Before(tb)
+	})
+	return ranked
+}
+
+// isIdlePolecat reports whether a sling target is a polecat with nothing
+// to do: one that has finished its work, or one with no hooked issue that
+// has been quiet past the stale threshold. A polecat waiting on a prompt
+// needs a person, not more work.
+func isIdlePolecat(a *AgentInfo) bool {
+	if a == nil || a.Role != "polecat" {
+		return false
+	}
+	return a.State == data.StateDone || (a.IssueID == "" && a.State == "" && a.Status != "working")
+}
+
+// Ensure DispatchPane implements Pane at compile time.
+var _ Pane = (*DispatchPane)(nil)
+
+// Ensure ReadyUpdateMsg implements tea.Msg.
+var _ tea.Msg = ReadyUpdateMsg{}
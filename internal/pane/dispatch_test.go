@@ -0,0 +1,204 @@
+package pane
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+func dispatchWithData() *DispatchPane {
+	p := NewDispatchPane()
+	p.SetSize(80, 20)
+	p.Update(ReadyUpdateMsg{Issues: []data.ReadyIssue{
+		{ID: "kt-abc", Title: "Fix login", Priority: 1, IssueType: "bug"},
+		{ID: "kt-def", Title: "Add export", Priority: 2, IssueType: "feature"},
+	}})
+	p.Update(RigListMsg{Rigs: []string{"kestral_tui"}})
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{
+		{Name: "witness", Rig: "kestral_tui", Role: "witness"},
+		{Name: "quartz", Rig: "kestral_tui", Role: "polecat", Status: "idle"},
+		{Name: "max", Rig: "kestral_tui", Role: "crew", Status: "working"},
+		{Name: "onyx", Rig: "beads", Role: "polecat", Status: "working", IssueID: "bd-1"},
+	}})
+	return p
+}
+
+func TestDispatchPaneListsReadyIssues(t *testing.T) {
+	p := dispatchWithData()
+	if p.Badge() != 2 {
+		t.Errorf("Badge() = %d, want 2", p.Badge())
+	}
+	view := p.View()
+	for _, want := range []string{"DISPATCH (2 ready)", "kt-abc", "Fix login", "P2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestSlingTargets(t *testing.T) {
+	p := dispatchWithData()
+	var got []string
+	for _, tg := range slingTargets(p.rigs, p.agents) {
+		got = append(got, tg.Target)
+	}
+	want := []string{"kestral_tui", "kestral_tui/quartz", "kestral_tui/crew/max", "beads", "beads/onyx"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("targets = %v, want %v", got, want)
+	}
+}
+
+func TestDispatchPaneSlingFlow(t *testing.T) {
+	p := dispatchWithData()
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.mode != dispatchTargets || p.picked.ID != "kt-def" {
+		t.Fatalf("enter should open targets for kt-def, mode = %d picked = %q", p.mode, p.picked.ID)
+	}
+	if view := p.View(); !strings.Contains(view, "SLING kt-def") || !strings.Contains(view, "kestral_tui/quartz") {
+		t.Errorf("targets view:\n%s", view)
+	}
+
+	// A poll that reorders the list doesn't change what is being slung.
+	p.Update(ReadyUpdateMsg{Issues: []data.ReadyIssue{{ID: "kt-new"}, {ID: "kt-def"}, {ID: "kt-abc"}}})
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || p.mode != dispatchSlinging {
+		t.Fatal("enter on a target should start gt sling")
+	}
+
+	p.Update(SlingResultMsg{BeadID: "kt-def", Target: "kestral_tui/quartz", Seq: p.slingSeq})
+	if p.mode != dispatchList || len(p.issues) != 2 {
+		t.Errorf("slung issue should leave the list, mode = %d issues = %d", p.mode, len(p.issues))
+	}
+	if view := p.View(); !strings.Contains(view, "slung kt-def to kestral_tui/quartz") {
+		t.Errorf("view should confirm the sling:\n%s", view)
+	}
+}
+
+func TestDispatchPaneIgnoresOtherSlings(t *testing.T) {
+	p := dispatchWithData()
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.mode != dispatchTargets {
+		t.Fatal("enter should open targets")
+	}
+
+	// Slung from elsewhere, e.g. New Issue: the issue leaves the list, but
+	// the target picker stays open and no result is shown.
+	p.Update(SlingResultMsg{BeadID: "kt-def", Target: "kestral_tui"})
+	if p.mode != dispatchTargets || p.result != "" {
+		t.Errorf("another pane's sling changed mode = %d result = %q", p.mode, p.result)
+	}
+	if len(p.issues) != 1 || p.issues[0].ID != "kt-abc" {
+		t.Errorf("issues = %v, want just kt-abc", p.issues)
+	}
+
+	// Nor does a stale result from an earlier sling end the current one.
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(SlingResultMsg{BeadID: "kt-abc", Target: "kestral_tui", Seq: p.slingSeq - 1, Err: errTest})
+	if p.mode != dispatchSlinging || p.result != "" {
+		t.Errorf("stale result changed mode = %d result = %q", p.mode, p.result)
+	}
+}
+
+func TestDispatchPaneSlingError(t *testing.T) {
+	p := dispatchWithData()
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.mode != dispatchList {
+		t.Fatal("esc should return to the list")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(SlingResultMsg{BeadID: "kt-abc", Target: "kestral_tui", Seq: p.slingSeq, Err: errTest})
+	if len(p.issues) != 2 {
+		t.Error("a failed sling should keep the issue listed")
+	}
+	if view := p.View(); !strings.Contains(view, "sling kt-abc to kestral_tui failed") {
+		t.Errorf("view should show the failure:\n%s", view)
+	}
+}
+
+func TestRankReady(t *testing.T) {
+	issues := rankReady([]data.ReadyIssue{
+		{ID: "new-p2", Priority: 2, CreatedAt: "2026-02-07T10:00:00Z"},
+		{ID: "undated-p1", Priority: 1},
+		{ID: "new-p1", Priority: 1, CreatedAt: "2026-02-07T10:00:00Z"},
+		{ID: "old-p1", Priority: 1, CreatedAt: "2026-02-01T10:00:00Z"},
+		{ID: "p0", Priority: 0, CreatedAt: "2026-02-07T12:00:00Z"},
+	})
+	var got []string
+	for _, iss := range issues {
+		got = append(got, iss.ID)
+	}
+	want := []string{"p0", "old-p1", "new-p1", "undated-p1", "new-p2"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("ranked = %v, want %v", got, want)
+	}
+}
+
+func TestDispatchPaneShowsIdlePolecats(t *testing.T) {
+	p := dispatchWithData()
+	if view := p.View(); !strings.Contains(view, "Idle: kestral_tui (quartz)") {
+		t.Errorf("view should list idle polecats by rig:\n%s", view)
+	}
+
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "quartz", Rig: "kestral_tui", Role: "polecat", Status: "working"}}})
+	if view := p.View(); !strings.Contains(view, "No idle polecats") {
+		t.Errorf("view should say no polecat is idle:\n%s", view)
+	}
+}
+
+func TestDispatchPaneDispatchToIdle(t *testing.T) {
+	p := dispatchWithData()
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if cmd == nil || p.mode != dispatchSlinging {
+		t.Fatal("d should sling straight to an idle polecat")
+	}
+	if target := p.targets[p.targetCursor].Target; target != "kestral_tui/quartz" {
+		t.Errorf("dispatched to %q, want kestral_tui/quartz", target)
+	}
+
+	// With nobody idle, d falls back to choosing a target.
+	p = dispatchWithData()
+	p.Update(AgentUpdateMsg{})
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if cmd != nil || p.mode != dispatchTargets {
+		t.Errorf("d with no idle polecat should open targets, mode = %d", p.mode)
+	}
+}
+
+func TestIdlePolecatsFromSessionActivity(t *testing.T) {
+	now := time.Unix(1773500000, 0)
+	th := data.DefaultThresholds()
+	// window_activity as tmux reports it: unix seconds of the last output.
+	polecat := func(name string, activity int64, issue, state string) AgentInfo {
+		age := now.Sub(time.Unix(activity, 0))
+		return AgentInfo{Name: name, Rig: "kt", Role: "polecat", Status: th.AgentStatus(age),
+			Age: age, IssueID: issue, State: state}
+	}
+	agents := []AgentInfo{
+		polecat("busy", 1773499990, "kt-1", ""),  // 10s ago, working
+		polecat("quiet", 1773499280, "", ""),     // 12m ago, no issue
+		polecat("stuck", 1773497000, "kt-2", ""), // 50m ago on an issue
+		polecat("finished", 1773499970, "kt-3", data.StateDone),
+		polecat("prompted", 1773499280, "", data.StateWaiting),
+		polecat("fresh", 1773499940, "", ""), // 1m ago, between tasks
+	}
+	var idle []string
+	for i := range agents {
+		if isIdlePolecat(&agents[i]) {
+			idle = append(idle, agents[i].Name)
+		}
+	}
+	if got := strings.Join(idle, " "); got != "quiet finished" {
+		t.Errorf("idle polecats = %q, want \"quiet finished\"", got)
+	}
+}
@@ -0,0 +1,325 @@
+package pane
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
+)
+
+// EventKind identifies the kind of status change an Event describes.
+type EventKind string
+
+const (
+	EventAgentStarted    EventKind = "agent_started"
+	EventAgentGone       EventKind = "agent_gone"
+	EventAgentStatus     EventKind = "agent_status"
+	EventAgentStuck      EventKind = "agent_stuck"
+	EventWitnessStatus   EventKind = "witness_status"
+	EventWitnessDead     EventKind = "witness_dead"
+	EventPROpened        EventKind = "pr_opened"
+	EventPRClosed        EventKind = "pr_closed"
+	EventCIFailed        EventKind = "ci_failed"
+	EventCIPassed        EventKind = "ci_passed"
+	EventMRMerged        EventKind = "mr_merged"
+	EventMRFailed        EventKind = "mr_failed"
+	EventMRSkipped       EventKind = "mr_skipped"
+	EventMRAction        EventKind = "mr_action"
+	EventConvoyOpened    EventKind = "convoy_opened"
+	EventConvoyCompleted EventKind = "convoy_completed"
+	EventMailReceived    EventKind = "mail_received"
+	EventIssueClosed     EventKind = "issue_closed"
+)
+
+// EventKinds lists every event kind, for validating configuration.
+var EventKinds = []EventKind{
+	EventAgentStarted, EventAgentGone, EventAgentStatus, EventAgentStuck,
+	EventWitnessStatus, EventWitnessDead,
+	EventPROpened, EventPRClosed, EventCIFailed, EventCIPassed,
+	EventMRMerged, EventMRFailed, EventMRSkipped, EventMRAction,
+	EventConvoyOpened, EventConvoyCompleted,
+	EventMailReceived, EventIssueClosed,
+}
+
+// EventLink points at the entity an event is about so it can be opened
+// from the Events pane.
+type EventLink struct {
+	Pane PaneID
+	Key  string // entity key understood by the target pane's Focus
+}
+
+// Event is a single status change detected between two polls.
+type Event struct {
+	Kind    EventKind
+	Subject string // rig/agent, PR number, convoy ID, ...
+	Message string
+	At      time.Time
+	Link    EventLink
+}
+
+// EventMsg delivers newly detected events to panes.
+type EventMsg struct {
+	Events []Event
+}
+
+// EventSelectedMsg asks the root model to follow an event's deep link.
+type EventSelectedMsg struct {
+	Link EventLink
+}
+
+// Focuser is implemented by panes that can move their cursor to a specific
+// entity, for following deep links.
+type Focuser interface {
+	Focus(key string) bool
+}
+
+// EventsPane displays a scrollable log of recent events, newest first.
+type EventsPane struct {
+	events []Event
+	limit  int // maximum events retained
+	unseen int // events received since the pane last had input
+	cursor int
+	offset int
+	width  int
+	height int
+	keys   eventKeys
+	th     *theme.Theme
+}
+
+type eventKeys struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+}
+
+// NewEventsPane creates an Events pane retaining up to limit events.
+func NewEventsPane(limit int) *EventsPane {
+	if limit < 1 {
+		limit = 1
+	}
+	return &EventsPane{
+		limit: limit,
+		keys:  newEventKeys(keymap.Default()),
+		th:    theme.Default(),
+	}
+}
+
+func newEventKeys(km keymap.Map) eventKeys {
+	return eventKeys{
+		Up:     km.Binding("events", "up"),
+		Down:   km.Binding("events", "down"),
+		Select: km.Binding("events", "select"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *EventsPane) SetKeyMap(km keymap.Map) {
+	p.keys = newEventKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *EventsPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Select}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *EventsPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
+func (p *EventsPane) ID() PaneID         { return PaneEvents }
+func (p *EventsPane) Title() string      { return "Events" }
+func (p *EventsPane) ShortTitle() string { return "\U0001F514" } // 🔔
+
+// Badge returns the count of events received since the pane was last used.
+func (p *EventsPane) Badge() int {
+	return p.unseen
+}
+
+func (p *EventsPane) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+	p.clampScroll()
+}
+
+func (p *EventsPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *EventsPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case EventMsg:
+		// Prepend newest first, keeping the cursor on the same event.
+		n := len(msg.Events)
+		incoming := make([]Event, n)
+		for i, e := range msg.Events {
+			incoming[n-1-i] = e
+		}
+		p.events = append(incoming, p.events...)
+		if len(p.events) > p.limit {
+			p.events = p.events[:p.limit]
+		}
+		if p.cursor > 0 {
+			p.cursor += n
+		}
+		p.unseen += n
+		if p.unseen > len(p.events) {
+			p.unseen = len(p.events)
+		}
+		p.clampScroll()
+
+	case tea.KeyMsg:
+		p.unseen = 0
+		switch {
+		case key.Matches(msg, p.keys.Up):
+			if p.cursor > 0 {
+				p.cursor--
+				p.scrollToCursor()
+			}
+		case key.Matches(msg, p.keys.Down):
+			if p.cursor < len(p.events)-1 {
+				p.cursor++
+				p.scrollToCursor()
+			}
+		case key.Matches(msg, p.keys.Select):
+			if p.cursor < len(p.events) {
+				link := p.events[p.cursor].Link
+				return p, func() tea.Msg {
+					return EventSelectedMsg{Link: link}
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// Latest returns the most recent event, if any.
+func (p *EventsPane) Latest() (Event, bool) {
+	if len(p.events) == 0 {
+		return Event{}, false
+	}
+	return p.events[0], true
+}
+
+func (p *EventsPane) View() string {
+	if p.width == 0 || p.height == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	header := fmt.Sprintf("─── EVENTS (%d) ───", len(p.events))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+
+	if len(p.events) == 0 {
+		b.WriteString(p.th.MutedStyle.Render("  No events yet"))
+		return b.String()
+	}
+
+	contentHeight := p.contentHeight()
+	end := p.offset + contentHeight
+	if end > len(p.events) {
+		end = len(p.events)
+	}
+	for i := p.offset; i < end; i++ {
+		b.WriteString(p.formatRow(p.events[i], i == p.cursor))
+		b.WriteString("\n")
+	}
+	for i := end - p.offset; i < contentHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s scroll  %s open",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Select)))
+	b.WriteString(TruncateWithEllipsis(footer, p.width))
+
+	return b.String()
+}
+
+// formatRow renders "  15:04:05 ● message".
+func (p *EventsPane) formatRow(e Event, selected bool) string {
+	stamp := e.At.Format("15:04:05")
+	msgMax := p.width - len(stamp) - 6
+	if msgMax < 1 {
+		msgMax = 1
+	}
+	text := TruncateWithEllipsis(e.Message, msgMax)
+
+	if selected {
+		return p.th.AccentStyle.Bold(true).Render(fmt.Sprintf("  %s ● %s", stamp, text))
+	}
+	return fmt.Sprintf("  %s %s %s",
+		p.th.MutedStyle.Render(stamp),
+		EventStyle(p.th, e.Kind).Render("●"),
+		text)
+}
+
+// EventStyle returns the color used for an event kind.
+func EventStyle(th *theme.Theme, kind EventKind) lipgloss.Style {
+	switch kind {
+	case EventAgentStuck, EventWitnessDead, EventCIFailed, EventMRFailed:
+		return th.FailStyle
+	case EventAgentGone, EventAgentStatus, EventWitnessStatus, EventPRClosed, EventMRSkipped:
+		return th.WarnStyle
+	case EventConvoyCompleted, EventMRMerged, EventCIPassed, EventIssueClosed:
+		return th.PassStyle
+	default:
+		return th.AccentStyle
+	}
+}
+
+func (p *EventsPane) contentHeight() int {
+	h := p.height - 2 // header + footer
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// scrollToCursor ensures the cursor row is visible.
+func (p *EventsPane) scrollToCursor() {
+	contentHeight := p.contentHeight()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+contentHeight {
+		p.offset = p.cursor - contentHeight + 1
+	}
+	p.clampScroll()
+}
+
+// clampScroll ensures offset and cursor stay in valid range.
+func (p *EventsPane) clampScroll() {
+	maxOffset := len(p.events) - p.contentHeight()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	if p.offset > maxOffset {
+		p.offset = maxOffset
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+	if p.cursor >= len(p.events) {
+		p.cursor = len(p.events) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+}
+
+// Ensure EventsPane implements Pane at compile time.
+var _ Pane = (*EventsPane)(nil)
+
+// Ensure message types implement tea.Msg.
+var (
+	_ tea.Msg = EventMsg{}
+	_ tea.Msg = EventSelectedMsg{}
+)
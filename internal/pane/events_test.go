@@ -0,0 +1,126 @@
+package pane
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testEvents(n int) []Event {
+	base := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	events := make([]Event, n)
+	for i := range events {
+		events[i] = Event{
+			Kind:    EventAgentStatus,
+			Subject: "kt/agent",
+			Message: "event " + string(rune('a'+i)),
+			At:      base.Add(time.Duration(i) * time.Minute),
+			Link:    EventLink{Pane: PaneAgents, Key: "kt/agent"},
+		}
+	}
+	return events
+}
+
+func TestNewEventsPane(t *testing.T) {
+	p := NewEventsPane(10)
+	if p.ID() != PaneEvents {
+		t.Errorf("ID() = %d, want %d", p.ID(), PaneEvents)
+	}
+	if p.Title() != "Events" {
+		t.Errorf("Title() = %q, want %q", p.Title(), "Events")
+	}
+	if p.Badge() != 0 {
+		t.Errorf("Badge() = %d, want 0 for empty pane", p.Badge())
+	}
+	if _, ok := p.Latest(); ok {
+		t.Error("Latest() should report no events for empty pane")
+	}
+}
+
+func TestEventsPaneNewestFirstAndLimit(t *testing.T) {
+	p := NewEventsPane(3)
+	p.SetSize(80, 24)
+
+	events := testEvents(4)
+	p.Update(EventMsg{Events: events[:2]})
+	p.Update(EventMsg{Events: events[2:]})
+
+	if len(p.events) != 3 {
+		t.Fatalf("events = %d, want 3 (limit)", len(p.events))
+	}
+	if p.events[0].Message != "event d" || p.events[2].Message != "event b" {
+		t.Errorf("events should be newest first, got %q..%q", p.events[0].Message, p.events[2].Message)
+	}
+	latest, ok := p.Latest()
+	if !ok || latest.Message != "event d" {
+		t.Errorf("Latest() = %q, want %q", latest.Message, "event d")
+	}
+	if p.Badge() != 3 {
+		t.Errorf("Badge() = %d, want 3", p.Badge())
+	}
+}
+
+func TestEventsPaneKeyClearsBadge(t *testing.T) {
+	p := NewEventsPane(10)
+	p.SetSize(80, 24)
+	p.Update(EventMsg{Events: testEvents(2)})
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if p.Badge() != 0 {
+		t.Errorf("Badge() = %d, want 0 after input", p.Badge())
+	}
+	if p.cursor != 1 {
+		t.Errorf("cursor = %d, want 1", p.cursor)
+	}
+
+	// New events keep the cursor on the same entry.
+	p.Update(EventMsg{Events: testEvents(1)})
+	if p.cursor != 2 {
+		t.Errorf("cursor = %d, want 2 after new event", p.cursor)
+	}
+}
+
+func TestEventsPaneSelectEmitsLink(t *testing.T) {
+	p := NewEventsPane(10)
+	p.SetSize(80, 24)
+	p.Update(EventMsg{Events: testEvents(1)})
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should return a command")
+	}
+	msg, ok := cmd().(EventSelectedMsg)
+	if !ok {
+		t.Fatalf("expected EventSelectedMsg, got %T", cmd())
+	}
+	if msg.Link != (EventLink{Pane: PaneAgents, Key: "kt/agent"}) {
+		t.Errorf("link = %+v", msg.Link)
+	}
+}
+
+func TestEventsPaneView(t *testing.T) {
+	p := NewEventsPane(10)
+	p.SetSize(80, 10)
+
+	if !strings.Contains(p.View(), "No events yet") {
+		t.Error("empty view should show placeholder")
+	}
+
+	p.Update(EventMsg{Events: testEvents(2)})
+	view := p.View()
+	if !strings.Contains(view, "EVENTS (2)") {
+		t.Error("view should show event count in header")
+	}
+	if !strings.Contains(view, "15:01:00") || !strings.Contains(view, "event b") {
+		t.Error("view should show timestamp and message")
+	}
+}
+
+func TestEventsPaneViewZeroSize(t *testing.T) {
+	p := NewEventsPane(10)
+	if v := p.View(); v != "" {
+		t.Errorf("View() with zero size = %q, want empty", v)
+	}
+}
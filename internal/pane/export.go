@@ -0,0 +1,41 @@
+package pane
+
+import (
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/export"
+)
+
+// Exporter is implemented by panes whose view can be exported as Markdown.
+// Other panes export the daily digest instead.
+type Exporter interface {
+	// Export renders the current view. It returns false until the pane
+	// has data to export.
+	Export(now time.Time) (export.Doc, bool)
+}
+
+func (d *Dashboard) Export(now time.Time) (export.Doc, bool) {
+	if d.status == nil && d.convoys == nil {
+		return export.Doc{}, false
+	}
+	return export.Dashboard(d.status, d.sessions, d.convoys, d.progress, now), true
+}
+
+// Export renders the expanded convoy, or the one under the cursor.
+func (p *ConvoysPane) Export(now time.Time) (export.Doc, bool) {
+	i := p.cursor
+	if p.expanded >= 0 {
+		i = p.expanded
+	}
+	if i >= len(p.convoys) {
+		return export.Doc{}, false
+	}
+	c := p.convoys[i]
+	return export.Convoy(c, p.issues[c.ID], now), true
+}
+
+// Ensure the Dashboard and Convoys panes implement Exporter at compile time.
+var (
+	_ Exporter = (*Dashboard)(nil)
+	_ Exporter = (*ConvoysPane)(nil)
+)
@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -49,6 +50,7 @@ type HistoryPane struct {
 	err         error
 	filter      historyFilter
 	keys        historyKeys
+	th          *theme.Theme
 }
 
 type historyKeys struct {
@@ -63,26 +65,36 @@ type historyKeys struct {
 func NewHistoryPane() *HistoryPane {
 	return &HistoryPane{
 		filter: historyFilter{dateRange: "all"},
-		keys: historyKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-			Filter: key.NewBinding(
-				key.WithKeys("f"),
-			),
-			Agent: key.NewBinding(
-				key.WithKeys("a"),
-			),
-			Type: key.NewBinding(
-				key.WithKeys("t"),
-			),
-		},
+		keys:   newHistoryKeys(keymap.Default()),
+		th:     theme.Default(),
 	}
 }
 
+func newHistoryKeys(km keymap.Map) historyKeys {
+	return historyKeys{
+		Up:     km.Binding("history", "up"),
+		Down:   km.Binding("history", "down"),
+		Filter: km.Binding("history", "filter_date"),
+		Agent:  km.Binding("history", "filter_agent"),
+		Type:   km.Binding("history", "filter_type"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *HistoryPane) SetKeyMap(km keymap.Map) {
+	p.keys = newHistoryKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *HistoryPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Filter, p.keys.Agent, p.keys.Type}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *HistoryPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *HistoryPane) ID() PaneID        { return PaneHistory }
 func (p *HistoryPane) Title() string      { return "History" }
 func (p *HistoryPane) ShortTitle() string { return "📜" }
@@ -149,7 +161,7 @@ func (p *HistoryPane) View() string {
 
 	// Header
 	header := fmt.Sprintf("─── HISTORY (%d completed) ───", len(p.entries))
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	// Filter bar
@@ -158,12 +170,12 @@ func (p *HistoryPane) View() string {
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.entries) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No completed work"))
+		b.WriteString(p.th.MutedStyle.Render("  No completed work"))
 		return b.String()
 	}
 
@@ -191,7 +203,9 @@ func (p *HistoryPane) View() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("j/k scroll  f=date  a=agent  t=type")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s scroll  %s=date  %s=agent  %s=type",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Filter),
+		keymap.Label(p.keys.Agent), keymap.Label(p.keys.Type)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -202,14 +216,14 @@ func (p *HistoryPane) renderFilterBar() string {
 	parts := []string{}
 
 	dateLabel := "date:" + p.filter.dateRange
-	parts = append(parts, theme.AccentStyle.Render(dateLabel))
+	parts = append(parts, p.th.AccentStyle.Render(dateLabel))
 
 	if p.filter.agent != "" {
-		parts = append(parts, theme.AccentStyle.Render("agent:"+p.filter.agent))
+		parts = append(parts, p.th.AccentStyle.Render("agent:"+p.filter.agent))
 	}
 
 	if p.filter.issueType != "" {
-		parts = append(parts, theme.AccentStyle.Render("type:"+p.filter.issueType))
+		parts = append(parts, p.th.AccentStyle.Render("type:"+p.filter.issueType))
 	}
 
 	return "  " + strings.Join(parts, "  ")
@@ -224,7 +238,7 @@ func (p *HistoryPane) renderRows() []string {
 		day := e.ClosedAt.Format("2006-01-02")
 		if day != lastDay {
 			// Day header
-			dayHeader := theme.AccentStyle.Render("  ── " + day + " ──")
+			dayHeader := p.th.AccentStyle.Render("  ── " + day + " ──")
 			rows = append(rows, dayHeader)
 			lastDay = day
 		}
@@ -238,7 +252,7 @@ func (p *HistoryPane) renderRows() []string {
 // formatEntryRow formats a single history entry.
 func (p *HistoryPane) formatEntryRow(e historyEntry, selected bool) string {
 	// Layout: "  ✓ <id>  <title>  <assignee>  <duration>"
-	icon := theme.PassStyle.Render("✓")
+	icon := p.th.PassStyle.Render("✓")
 
 	idCol := 12
 	assigneeCol := 14
@@ -264,7 +278,7 @@ func (p *HistoryPane) formatEntryRow(e historyEntry, selected bool) string {
 	line := fmt.Sprintf("  %s %s%s%s%s", icon, idStr, titleStr, assigneeStr, durStr)
 
 	if selected {
-		return theme.AccentStyle.Bold(true).Render(line)
+		return p.th.AccentStyle.Bold(true).Render(line)
 	}
 	return line
 }
@@ -543,6 +557,19 @@ func formatDuration(d time.Duration) string {
 	}
 }
 
+// Focus moves the cursor to the closed bead with the given ID, if it is
+// visible under the current filters.
+func (p *HistoryPane) Focus(key string) bool {
+	for i, e := range p.entries {
+		if e.ID == key {
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
 // Ensure HistoryPane implements Pane at compile time.
 var _ Pane = (*HistoryPane)(nil)
 
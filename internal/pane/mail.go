@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -48,6 +49,7 @@ type MailPane struct {
 	height   int
 	err      error
 	keys     mailKeys
+	th       *theme.Theme
 	view     mailView
 }
 
@@ -61,23 +63,35 @@ type mailKeys struct {
 // NewMailPane creates a new Mail pane.
 func NewMailPane() *MailPane {
 	return &MailPane{
-		keys: mailKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-			Select: key.NewBinding(
-				key.WithKeys("enter"),
-			),
-			Back: key.NewBinding(
-				key.WithKeys("esc"),
-			),
-		},
+		keys: newMailKeys(keymap.Default()),
+		th:   theme.Default(),
 	}
 }
 
+func newMailKeys(km keymap.Map) mailKeys {
+	return mailKeys{
+		Up:     km.Binding("mail", "up"),
+		Down:   km.Binding("mail", "down"),
+		Select: km.Binding("mail", "select"),
+		Back:   km.Binding("mail", "back"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *MailPane) SetKeyMap(km keymap.Map) {
+	p.keys = newMailKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *MailPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Select, p.keys.Back}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *MailPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *MailPane) ID() PaneID        { return PaneMail }
 func (p *MailPane) Title() string      { return "Mail" }
 func (p *MailPane) ShortTitle() string { return "\U0001F4E7" } // 📧
@@ -181,16 +195,16 @@ func (p *MailPane) viewInbox() string {
 	// Header line
 	unread := p.Badge()
 	header := fmt.Sprintf("─── MAIL (%d unread) ───", unread)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.messages) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No messages"))
+		b.WriteString(p.th.MutedStyle.Render("  No messages"))
 		return b.String()
 	}
 
@@ -218,7 +232,8 @@ func (p *MailPane) viewInbox() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("j/k=scroll  enter=read  esc=back")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s=scroll  %s=read  %s=back",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Select), keymap.Label(p.keys.Back)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -230,9 +245,9 @@ func (p *MailPane) renderInboxRows() []string {
 		selected := i == p.cursor
 
 		// Read/unread icon
-		icon := theme.IconRead
+		icon := p.th.IconRead
 		if !m.Read {
-			icon = theme.IconUnread
+			icon = p.th.IconUnread
 		}
 
 		// Format: "  ● from      subject            age"
@@ -254,16 +269,16 @@ func (p *MailPane) renderInboxRows() []string {
 		line := fmt.Sprintf("  %s %s%s%s", icon, fromStr, subjectStr, ageStr)
 
 		if selected {
-			line = theme.AccentStyle.Bold(true).Render(
+			line = p.th.AccentStyle.Bold(true).Render(
 				fmt.Sprintf("  %s %s%s%s", iconChar(!m.Read), fromStr, subjectStr, ageStr))
 		}
 		rows = append(rows, line)
 
 		// Priority indicator on second line for high-priority
 		if m.Priority == "high" || m.Priority == "urgent" {
-			priLine := fmt.Sprintf("      %s", theme.WarnStyle.Render("⚡ "+m.Priority))
+			priLine := fmt.Sprintf("      %s", p.th.WarnStyle.Render("⚡ "+m.Priority))
 			if selected {
-				priLine = theme.AccentStyle.Render(priLine)
+				priLine = p.th.AccentStyle.Render(priLine)
 			}
 			rows = append(rows, priLine)
 		}
@@ -282,7 +297,7 @@ func (p *MailPane) viewMessage() string {
 
 	// Header
 	header := fmt.Sprintf("─── MESSAGE ───")
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	// Message metadata
@@ -293,9 +308,9 @@ func (p *MailPane) viewMessage() string {
 		fmt.Sprintf("  Date:    %s", m.Timestamp.Format("2006-01-02 15:04")),
 	}
 	if m.Priority != "" && m.Priority != "normal" {
-		lines = append(lines, fmt.Sprintf("  Priority: %s", theme.WarnStyle.Render(m.Priority)))
+		lines = append(lines, fmt.Sprintf("  Priority: %s", p.th.WarnStyle.Render(m.Priority)))
 	}
-	lines = append(lines, theme.MutedStyle.Render(strings.Repeat("─", p.width)))
+	lines = append(lines, p.th.MutedStyle.Render(strings.Repeat("─", p.width)))
 
 	// Body lines
 	bodyLines := wrapText(m.Body, p.width-2)
@@ -334,7 +349,8 @@ func (p *MailPane) viewMessage() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("esc=back  j/k=scroll")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s=back  %s=scroll",
+		keymap.Label(p.keys.Back), scrollKeys(p.keys.Down, p.keys.Up)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -444,6 +460,20 @@ func wrapText(text string, width int) []string {
 	return result
 }
 
+// Focus moves the cursor to the message with the given ID and returns to
+// the inbox.
+func (p *MailPane) Focus(key string) bool {
+	for i, m := range p.messages {
+		if m.ID == key {
+			p.view = mailViewInbox
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
 // Ensure MailPane implements Pane at compile time.
 var _ Pane = (*MailPane)(nil)
 
@@ -0,0 +1,123 @@
+package pane
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// mrActionTimeout bounds a merge queue action.
+const mrActionTimeout = 30 * time.Second
+
+// MRActionKind is something the Refinery pane can do to a merge request.
+type MRActionKind string
+
+const (
+	MRRetry MRActionKind = "retry" // requeue a failed MR
+	MRSkip  MRActionKind = "skip"  // close a queued MR without merging
+	MRFront MRActionKind = "front" // raise to P0, behind only older P0 MRs
+	MRBump  MRActionKind = "bump"  // raise priority by one
+)
+
+// MRAction is a merge queue action on one MR.
+type MRAction struct {
+	Kind     MRActionKind
+	Rig      string
+	MR       string // MR bead ID
+	BeadID   string // issue the MR merges
+	Priority int    // new priority, for front and bump
+}
+
+// MRActionMsg delivers the result of a merge queue action. AuditErr is set
+// if the action couldn't be recorded on its MR bead.
+type MRActionMsg struct {
+	Action   MRAction
+	Err      error
+	AuditErr error
+}
+
+// Describe says what the action does, e.g. "bump kt-mr1 (kt-abc) to P1".
+func (a MRAction) Describe() string {
+	subject := a.MR
+	if a.BeadID != "" && a.BeadID != a.MR {
+		subject += " (" + a.BeadID + ")"
+	}
+	switch a.Kind {
+	case MRFront:
+		return fmt.Sprintf("move %s to the front (P%d)", subject, a.Priority)
+	case MRBump:
+		return fmt.Sprintf("bump %s to P%d", subject, a.Priority)
+	default:
+		return fmt.Sprintf("%s %s", a.Kind, subject)
+	}
+}
+
+// command returns the gt or bd invocation for the action. Retrying goes
+// through the merge queue; skipping closes the MR with a reason the
+// refinery history reads as skipped, and reordering changes its priority.
+// The refinery takes MRs of equal priority oldest first, so an MR moved to
+// the front still waits behind any older P0 MR.
+func (a MRAction) command() (string, []string) {
+	switch a.Kind {
+	case MRRetry:
+		return "gt", []string{"mq", "retry", a.Rig, a.MR}
+	case MRSkip:
+		return "bd", []string{"close", a.MR, "--reason", "skipped from kestral"}
+	default:
+		return "bd", []string{"update", a.MR, "--priority=" + strconv.Itoa(a.Priority)}
+	}
+}
+
+// mrActionCmd runs a merge queue action in the town at dir, then records
+// it, failed or not, as a comment on the MR bead so the audit trail
+// outlasts the session.
+func mrActionCmd(dir string, a MRAction) tea.Cmd {
+	return func() tea.Msg {
+		name, args := a.command()
+		_, err := runTownCmd(dir, mrActionTimeout, name, args...)
+		_, auditErr := runTownCmd(dir, mrActionTimeout, "bd", "comments", "add", a.MR, a.auditNote(err))
+		return MRActionMsg{Action: a, Err: err, AuditErr: auditErr}
+	}
+}
+
+// auditNote is the comment recording the action and its outcome.
+func (a MRAction) auditNote(err error) string {
+	note := "kestral: " + a.Describe()
+	if err != nil {
+		note += " failed: " + err.Error()
+	}
+	return note
+}
+
+// mrActions lists the actions that apply to an MR, given where it is in
+// the rig's refinery: failed MRs can be retried, queued ones skipped or
+// reordered, and the one being processed skipped.
+func mrActions(rig string, mr data.MergeRequest, queued, current bool) []MRAction {
+	base := MRAction{Rig: rig, MR: mr.ID, BeadID: mr.BeadID}
+	var actions []MRAction
+	with := func(kind MRActionKind, priority int) {
+		a := base
+		a.Kind, a.Priority = kind, priority
+		actions = append(actions, a)
+	}
+	switch {
+	case mr.Status == "failed" && !queued && !current:
+		with(MRRetry, 0)
+	case queued:
+		with(MRSkip, 0)
+		if mr.Priority > 0 {
+			with(MRFront, 0)
+			with(MRBump, mr.Priority-1)
+		}
+	case current:
+		with(MRSkip, 0)
+	}
+	return actions
+}
+
+// Ensure MRActionMsg implements tea.Msg.
+var _ tea.Msg = MRActionMsg{}
@@ -0,0 +1,116 @@
+package pane
+
+import (
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+// maxMRExcerpt is how many lines of refinery output the detail view shows
+// when nothing in it could be parsed.
+const maxMRExcerpt = 12
+
+// MRSelectedMsg asks the app for the refinery output behind an MR's detail
+// view.
+type MRSelectedMsg struct {
+	Rig string
+	MR  data.MergeRequest
+}
+
+// MROutputMsg carries the refinery session's recent output for an MR.
+type MROutputMsg struct {
+	MR     string // MR bead ID
+	Output string
+}
+
+var (
+	goTestFailPattern    = regexp.MustCompile(`^\s*--- FAIL: (\S+)`)
+	goPkgFailPattern     = regexp.MustCompile(`^FAIL\s+(\S+)`)
+	goLocationPattern    = regexp.MustCompile(`^\s*(\S+\.go:\d+(?::\d+)?): (.+)`)
+	mergeConflictPattern = regexp.MustCompile(`CONFLICT \([^)]*\): (?:Merge conflict in )?(.+)`)
+)
+
+// mrFailure is what the refinery output says about why an MR failed.
+type mrFailure struct {
+	Tests     []string // failing Go tests
+	Packages  []string // failing Go packages
+	Locations []string // file:line: message from test or build output
+	Conflicts []string // files with merge conflicts
+	Excerpt   []string // tail of the output when nothing else was found
+}
+
+// empty reports whether no failure was recognised.
+func (f mrFailure) empty() bool {
+	return len(f.Tests) == 0 && len(f.Packages) == 0 && len(f.Locations) == 0 && len(f.Conflicts) == 0
+}
+
+// parseMRFailure summarises the refinery output for mr. The refinery works
+// through MRs one at a time, so the output from the last line naming the
+// MR, its issue or its branch onwards is taken to be about it; if none
+// does, the whole capture is used.
+func parseMRFailure(output string, mr data.MergeRequest) mrFailure {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	start := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		if mentionsMR(lines[i], mr) {
+			start = i
+			break
+		}
+	}
+	lines = lines[start:]
+
+	var f mrFailure
+	seen := make(map[string]bool)
+	add := func(list *[]string, s string) {
+		if !seen[s] {
+			seen[s] = true
+			*list = append(*list, s)
+		}
+	}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " ")
+		switch {
+		case goTestFailPattern.MatchString(line):
+			add(&f.Tests, goTestFailPattern.FindStringSubmatch(line)[1])
+		case goPkgFailPattern.MatchString(line):
+			add(&f.Packages, goPkgFailPattern.FindStringSubmatch(line)[1])
+		case mergeConflictPattern.MatchString(line):
+			add(&f.Conflicts, strings.TrimSpace(mergeConflictPattern.FindStringSubmatch(line)[1]))
+		case goLocationPattern.MatchString(line):
+			m := goLocationPattern.FindStringSubmatch(line)
+			add(&f.Locations, m[1]+": "+strings.TrimSpace(m[2]))
+		}
+	}
+	if f.empty() {
+		var nonBlank []string
+		for _, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				nonBlank = append(nonBlank, strings.TrimRight(line, " "))
+			}
+		}
+		if len(nonBlank) > maxMRExcerpt {
+			nonBlank = nonBlank[len(nonBlank)-maxMRExcerpt:]
+		}
+		f.Excerpt = nonBlank
+	}
+	return f
+}
+
+// mentionsMR reports whether a line of refinery output names mr.
+func mentionsMR(line string, mr data.MergeRequest) bool {
+	for _, s := range []string{mr.ID, mr.BeadID, mr.Branch} {
+		if s != "" && strings.Contains(line, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure refinery drill-down message types implement tea.Msg.
+var (
+	_ tea.Msg = MRSelectedMsg{}
+	_ tea.Msg = MROutputMsg{}
+)
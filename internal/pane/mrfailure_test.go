@@ -0,0 +1,190 @@
+package pane
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+const refineryOutput = `Processing kt-mr0 (polecat/onyx/kt-old)
+--- FAIL: TestOld (0.00s)
+Processing kt-mr1 (polecat/quartz/kt-abc)
+Merging polecat/quartz/kt-abc into main
+Running go test ./...
+--- FAIL: TestLogin (0.01s)
+    auth_test.go:42: got 401, want 200
+--- FAIL: TestLogout (0.00s)
+FAIL
+FAIL	github.com/t/k/internal/auth	0.120s
+ok  	github.com/t/k/internal/app	0.200s
+`
+
+func TestParseMRFailureTests(t *testing.T) {
+	mr := data.MergeRequest{ID: "kt-mr1", BeadID: "kt-abc", Branch: "polecat/quartz/kt-abc"}
+	f := parseMRFailure(refineryOutput, mr)
+	if strings.Join(f.Tests, " ") != "TestLogin TestLogout" {
+		t.Errorf("tests = %v; the earlier MR's TestOld should be left out", f.Tests)
+	}
+	if strings.Join(f.Packages, " ") != "github.com/t/k/internal/auth" {
+		t.Errorf("packages = %v", f.Packages)
+	}
+	if len(f.Locations) != 1 || f.Locations[0] != "auth_test.go:42: got 401, want 200" {
+		t.Errorf("locations = %v", f.Locations)
+	}
+	if f.Excerpt != nil {
+		t.Errorf("no excerpt when a failure was parsed, got %v", f.Excerpt)
+	}
+}
+
+func TestParseMRFailureConflict(t *testing.T) {
+	out := "Merging polecat/quartz/kt-abc\nAuto-merging app.go\n" +
+		"CONFLICT (content): Merge conflict in internal/app/app.go\n" +
+		"Automatic merge failed; fix conflicts and then commit the result.\n"
+	f := parseMRFailure(out, data.MergeRequest{ID: "kt-mr1", Branch: "polecat/quartz/kt-abc"})
+	if len(f.Conflicts) != 1 || f.Conflicts[0] != "internal/app/app.go" {
+		t.Errorf("conflicts = %v", f.Conflicts)
+	}
+
+	f = parseMRFailure("idle\nwaiting for work\n", data.MergeRequest{ID: "kt-mr9"})
+	if !f.empty() || strings.Join(f.Excerpt, "|") != "idle|waiting for work" {
+		t.Errorf("unparsed output should fall back to an excerpt, got %+v", f)
+	}
+}
+
+func TestRefineryPaneFailureDrillDown(t *testing.T) {
+	p := NewRefineryPane()
+	p.SetSize(100, 40)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig: "kt",
+		History: []data.MergeRequest{{
+			ID: "kt-mr1", BeadID: "kt-abc", Title: "Fix auth", Status: "failed",
+			Branch: "polecat/quartz/kt-abc", Worker: "quartz",
+		}},
+	}}})
+	cursorTo(t, p, "kt-mr1")
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || p.detail == nil {
+		t.Fatal("enter should open the MR's detail")
+	}
+	if sel, ok := cmd().(MRSelectedMsg); !ok || sel.Rig != "kt" || sel.MR.ID != "kt-mr1" {
+		t.Errorf("enter should ask for the refinery output, got %#v", sel)
+	}
+	if view := p.View(); !strings.Contains(view, "Loading refinery output") || !strings.Contains(view, "kt/quartz") {
+		t.Errorf("detail before output:\n%s", view)
+	}
+
+	p.Update(MROutputMsg{MR: "kt-mr1", Output: refineryOutput})
+	view := p.View()
+	for _, want := range []string{"WHY IT FAILED", "2 failing tests: TestLogin, TestLogout", "auth_test.go:42"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail missing %q:\n%s", want, view)
+		}
+	}
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd == nil {
+		t.Fatal("a should open the polecat")
+	}
+	if sel, ok := cmd().(EventSelectedMsg); !ok || sel.Link != (EventLink{Pane: PaneAgents, Key: "kt/quartz"}) {
+		t.Errorf("a should link to the polecat in Agents, got %#v", sel)
+	}
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if cmd == nil {
+		t.Fatal("i should open the MR's issue")
+	}
+	if sel, ok := cmd().(EventSelectedMsg); !ok || sel.Link != (EventLink{Pane: PaneHistory, Key: "kt-abc"}) {
+		t.Errorf("i should link to the issue in History, got %#v", sel)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.detail != nil {
+		t.Error("esc should return to the queue")
+	}
+}
+
+func TestRefineryPaneDetailOffsetClampedOnUpdate(t *testing.T) {
+	p := NewRefineryPane()
+	p.SetSize(100, 12)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig:     "kt",
+		History: []data.MergeRequest{{ID: "kt-mr1", BeadID: "kt-abc", Status: "merged", Branch: "polecat/quartz/kt-abc"}},
+	}}})
+	cursorTo(t, p, "kt-mr1")
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(MROutputMsg{MR: "kt-mr1", Output: "merging kt-mr1\n" + strings.Repeat("ok  pkg\n", 30)})
+	for i := 0; i < 40; i++ {
+		p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if p.detailOffset == 0 {
+		t.Fatal("down should scroll the detail")
+	}
+
+	p.Update(MROutputMsg{MR: "kt-mr1", Output: "merging kt-mr1\n"})
+	if want := max(len(p.detailRows())-p.detailHeight(), 0); p.detailOffset != want {
+		t.Errorf("detailOffset = %d after the output shrank, want %d", p.detailOffset, want)
+	}
+	before := p.detailOffset
+	p.View()
+	if p.detailOffset != before {
+		t.Error("View should not move the detail offset")
+	}
+}
+
+func TestRefineryPaneDetailActionsFollowTheDetail(t *testing.T) {
+	p := NewRefineryPane()
+	p.SetSize(100, 40)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig:     "kt",
+		Queue:   []data.MergeRequest{{ID: "kt-mr2", BeadID: "kt-def", Priority: 2}},
+		History: []data.MergeRequest{{ID: "kt-mr1", BeadID: "kt-abc", Status: "failed"}},
+	}}})
+	cursorTo(t, p, "kt-mr1")
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// The list is reordered under the detail view: new MRs are queued and
+	// kt-mr1 drops out of the history, leaving another failed MR on the
+	// cursor's row.
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig: "kt",
+		Queue: []data.MergeRequest{
+			{ID: "kt-mr3", BeadID: "kt-ghi", Priority: 1},
+			{ID: "kt-mr2", BeadID: "kt-def", Priority: 2},
+		},
+		History: []data.MergeRequest{{ID: "kt-mr9", BeadID: "kt-xyz", Status: "failed"}},
+	}}})
+	if mr := p.selectedMR(); mr != nil && mr.ID == "kt-mr1" {
+		t.Fatal("the test needs the cursor off the detail MR")
+	}
+	if view := p.View(); !strings.Contains(view, "MR kt-mr1") || !strings.Contains(view, "R retry") {
+		t.Errorf("detail footer should list the detail MR's actions:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if p.proposed == nil || p.proposed.MR != "kt-mr1" {
+		t.Fatalf("R should retry the MR in the detail view, got %+v", p.proposed)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// From the queue, an action applies to the MR opened, wherever the
+	// poll put it.
+	p.cursor = 0
+	cursorTo(t, p, "kt-mr2")
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig: "kt",
+		Queue: []data.MergeRequest{
+			{ID: "kt-mr4", BeadID: "kt-jkl", Priority: 0},
+			{ID: "kt-mr2", BeadID: "kt-def", Priority: 2},
+		},
+	}}})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if p.proposed == nil || p.proposed.MR != "kt-mr2" || p.proposed.Priority != 1 {
+		t.Errorf("b should bump kt-mr2 to P1, got %+v", p.proposed)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -20,6 +21,9 @@ var issuePriorities = []string{"P0 Critical", "P1 High", "P2 Medium", "P3 Low",
 // priorityFlags maps display labels to bd create --priority flags.
 var priorityFlags = []string{"0", "1", "2", "3", "4"}
 
+// submitModes are the choices for what happens after bd create.
+var submitModes = []string{"create", "create and sling"}
+
 // formField identifies the active field in the form.
 type formField int
 
@@ -29,6 +33,7 @@ const (
 	fieldType
 	fieldPriority
 	fieldRig
+	fieldSling
 	fieldCount // sentinel for wrapping
 )
 
@@ -47,10 +52,13 @@ type RigListMsg struct {
 	Err  error
 }
 
-// IssueSubmitMsg delivers the result of a bd create call.
+// IssueSubmitMsg delivers the result of a bd create call, and of the gt
+// sling that follows it when "create and sling" was chosen.
 type IssueSubmitMsg struct {
-	BeadID string
-	Err    error
+	BeadID   string
+	Err      error
+	SlungTo  string // rig the new issue was slung to
+	SlingErr error
 }
 
 // NewIssuePane provides a form for creating bug reports and feature requests.
@@ -69,12 +77,17 @@ type NewIssuePane struct {
 	priorityIdx int      // index into issuePriorities
 	rigs        []string // populated from RigListMsg
 	rigIdx      int      // index into rigs
+	slingIdx    int      // index into submitModes
+	townRoot    string   // where bd create runs; "" = current directory
 
 	// Result
 	resultID  string
 	resultErr error
+	slungTo   string
+	slingErr  error
 
 	keys newIssueKeys
+	th   *theme.Theme
 }
 
 type newIssueKeys struct {
@@ -86,6 +99,11 @@ type newIssueKeys struct {
 	Cancel key.Binding
 }
 
+// SetTownRoot makes issues get created in the town at dir.
+func (p *NewIssuePane) SetTownRoot(dir string) {
+	p.townRoot = dir
+}
+
 // NewNewIssuePane creates a new issue creation pane.
 func NewNewIssuePane() *NewIssuePane {
 	ti := textinput.New()
@@ -97,29 +115,40 @@ func NewNewIssuePane() *NewIssuePane {
 		titleInput:  ti,
 		description: []string{""},
 		priorityIdx: 2, // Default P2 Medium
-		keys: newIssueKeys{
-			Up: key.NewBinding(
-				key.WithKeys("up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("down"),
-			),
-			Left: key.NewBinding(
-				key.WithKeys("left", "h"),
-			),
-			Right: key.NewBinding(
-				key.WithKeys("right", "l"),
-			),
-			Submit: key.NewBinding(
-				key.WithKeys("ctrl+s"),
-			),
-			Cancel: key.NewBinding(
-				key.WithKeys("esc"),
-			),
-		},
+		keys:        newNewIssueKeys(keymap.Default()),
+		th:          theme.Default(),
 	}
 }
 
+func newNewIssueKeys(km keymap.Map) newIssueKeys {
+	return newIssueKeys{
+		Up:     km.Binding("new_issue", "up"),
+		Down:   km.Binding("new_issue", "down"),
+		Left:   km.Binding("new_issue", "left"),
+		Right:  km.Binding("new_issue", "right"),
+		Submit: km.Binding("new_issue", "submit"),
+		Cancel: km.Binding("new_issue", "cancel"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *NewIssuePane) SetKeyMap(km keymap.Map) {
+	p.keys = newNewIssueKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *NewIssuePane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Left, p.keys.Right, p.keys.Submit, p.keys.Cancel}
+}
+
+// CapturesInput is always true: the form takes every key.
+func (p *NewIssuePane) CapturesInput() bool { return true }
+
+// SetTheme switches the pane to the session's theme.
+func (p *NewIssuePane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *NewIssuePane) ID() PaneID        { return PaneNewIssue }
 func (p *NewIssuePane) Title() string      { return "New Issue" }
 func (p *NewIssuePane) ShortTitle() string { return "\U0001F4DD" } // 📝
@@ -150,6 +179,8 @@ func (p *NewIssuePane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		p.state = stateResult
 		p.resultID = msg.BeadID
 		p.resultErr = msg.Err
+		p.slungTo = msg.SlungTo
+		p.slingErr = msg.SlingErr
 		return p, nil
 
 	case tea.KeyMsg:
@@ -215,6 +246,8 @@ func (p *NewIssuePane) handleFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return p.handleToggleKey(msg, &p.rigIdx, len(p.rigs))
 		}
 		return p.handleNavKey(msg)
+	case fieldSling:
+		return p.handleToggleKey(msg, &p.slingIdx, len(submitModes))
 	}
 	return p, nil
 }
@@ -379,23 +412,36 @@ func (p *NewIssuePane) submit() (tea.Model, tea.Cmd) {
 		args = append(args, "-d", desc)
 	}
 
+	rig := ""
 	if len(p.rigs) > 0 && p.rigIdx < len(p.rigs) {
-		args = append(args, "--rig", p.rigs[p.rigIdx])
+		rig = p.rigs[p.rigIdx]
+		args = append(args, "--rig", rig)
 	}
 
-	return p, submitIssueCmd(args)
+	return p, submitIssueCmd(p.townRoot, args, p.slingIdx == 1, rig)
 }
 
-// submitIssueCmd returns a tea.Cmd that shells out to bd create.
-func submitIssueCmd(args []string) tea.Cmd {
+// submitIssueCmd returns a tea.Cmd that shells out to bd create in dir and,
+// if sling is set, slings the new issue to a new polecat in rig.
+func submitIssueCmd(dir string, args []string, sling bool, rig string) tea.Cmd {
 	return func() tea.Msg {
-		stdout, err := runBdCreate(args)
+		stdout, err := runBdCreate(dir, args)
 		if err != nil {
 			return IssueSubmitMsg{Err: err}
 		}
 		// Parse bead ID from output (typically first line contains the ID)
-		id := parseBeadID(stdout)
-		return IssueSubmitMsg{BeadID: id}
+		msg := IssueSubmitMsg{BeadID: parseBeadID(stdout)}
+		switch {
+		case !sling:
+		case rig == "":
+			msg.SlingErr = fmt.Errorf("no rig selected to sling to")
+		default:
+			msg.SlingErr = runSling(dir, msg.BeadID, rig)
+			if msg.SlingErr == nil {
+				msg.SlungTo = rig
+			}
+		}
+		return msg
 	}
 }
 
@@ -410,8 +456,11 @@ func (p *NewIssuePane) resetForm() {
 	p.typeIdx = 0
 	p.priorityIdx = 2
 	p.rigIdx = 0
+	p.slingIdx = 0
 	p.resultID = ""
 	p.resultErr = nil
+	p.slungTo = ""
+	p.slingErr = nil
 }
 
 // View renders the new issue form.
@@ -434,7 +483,7 @@ func (p *NewIssuePane) renderForm() string {
 	var b strings.Builder
 
 	header := "─── NEW ISSUE ───"
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	// Title field
@@ -459,15 +508,19 @@ func (p *NewIssuePane) renderForm() string {
 	}
 	p.renderField(&b, fieldRig, "Rig", rigView)
 
+	// What to do once created
+	p.renderField(&b, fieldSling, "Then", p.renderToggle(submitModes, p.slingIdx))
+
 	b.WriteString("\n")
 
 	// Help text
 	help := p.fieldHelp()
-	b.WriteString(theme.MutedStyle.Render(help))
+	b.WriteString(p.th.MutedStyle.Render(help))
 	b.WriteString("\n")
 
 	// Footer
-	footer := theme.MutedStyle.Render("enter/ctrl+s submit  tab next field  ←/→ toggle  esc cancel")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("enter/%s submit  tab next field  %s/%s toggle  %s cancel",
+		keymap.Label(p.keys.Submit), keymap.Label(p.keys.Left), keymap.Label(p.keys.Right), keymap.Label(p.keys.Cancel)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -475,14 +528,14 @@ func (p *NewIssuePane) renderForm() string {
 
 func (p *NewIssuePane) renderField(b *strings.Builder, field formField, label, content string) {
 	active := p.activeField == field
-	labelStyle := theme.MutedStyle
+	labelStyle := p.th.MutedStyle
 	if active {
-		labelStyle = theme.AccentStyle
+		labelStyle = p.th.AccentStyle
 	}
 
 	indicator := "  "
 	if active {
-		indicator = theme.AccentStyle.Render("> ")
+		indicator = p.th.AccentStyle.Render("> ")
 	}
 
 	b.WriteString(indicator)
@@ -520,7 +573,7 @@ func (p *NewIssuePane) renderDescription() string {
 				pos = len(runes)
 			}
 			before := string(runes[:pos])
-			cursor := theme.AccentStyle.Render("│")
+			cursor := p.th.AccentStyle.Render("│")
 			after := ""
 			if pos < len(runes) {
 				after = string(runes[pos:])
@@ -529,16 +582,16 @@ func (p *NewIssuePane) renderDescription() string {
 		}
 
 		if display == "" && !active {
-			display = theme.MutedStyle.Render("(empty)")
+			display = p.th.MutedStyle.Render("(empty)")
 		}
 		lines = append(lines, display)
 	}
 
 	if len(lines) == 0 {
 		if active {
-			return theme.AccentStyle.Render("│")
+			return p.th.AccentStyle.Render("│")
 		}
-		return theme.MutedStyle.Render("(empty)")
+		return p.th.MutedStyle.Render("(empty)")
 	}
 
 	return strings.Join(lines, "\n    ")
@@ -548,9 +601,9 @@ func (p *NewIssuePane) renderToggle(options []string, selected int) string {
 	var parts []string
 	for i, opt := range options {
 		if i == selected {
-			parts = append(parts, theme.AccentStyle.Bold(true).Render("["+opt+"]"))
+			parts = append(parts, p.th.AccentStyle.Bold(true).Render("["+opt+"]"))
 		} else {
-			parts = append(parts, theme.MutedStyle.Render(" "+opt+" "))
+			parts = append(parts, p.th.MutedStyle.Render(" "+opt+" "))
 		}
 	}
 	return strings.Join(parts, "")
@@ -568,6 +621,8 @@ func (p *NewIssuePane) fieldHelp() string {
 		return "  P0: drop everything  P1: today  P2: normal  P3: soon  P4: someday"
 	case fieldRig:
 		return "  Which rig owns this issue? Use ←/→ to select."
+	case fieldSling:
+		return "  create and sling: hand it to a new polecat in the selected rig"
 	default:
 		return ""
 	}
@@ -576,26 +631,35 @@ func (p *NewIssuePane) fieldHelp() string {
 func (p *NewIssuePane) renderSubmitting() string {
 	var b strings.Builder
 	header := "─── NEW ISSUE ───"
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n\n")
-	b.WriteString(theme.AccentStyle.Render("  Submitting..."))
+	b.WriteString(p.th.AccentStyle.Render("  Submitting..."))
 	return b.String()
 }
 
 func (p *NewIssuePane) renderResult() string {
 	var b strings.Builder
 	header := "─── NEW ISSUE ───"
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n\n")
 
 	if p.resultErr != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.resultErr.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.resultErr.Error()))
 		b.WriteString("\n\n")
-		b.WriteString(theme.MutedStyle.Render("  n=try again  esc=back"))
+		b.WriteString(p.th.MutedStyle.Render("  n=try again  esc=back"))
 	} else {
-		b.WriteString(theme.PassStyle.Render(fmt.Sprintf("  Created: %s", p.resultID)))
-		b.WriteString("\n\n")
-		b.WriteString(theme.MutedStyle.Render("  n=create another  esc=back"))
+		b.WriteString(p.th.PassStyle.Render(fmt.Sprintf("  Created: %s", p.resultID)))
+		b.WriteString("\n")
+		switch {
+		case p.slingErr != nil:
+			b.WriteString(p.th.FailStyle.Render("  Sling failed: " + p.slingErr.Error()))
+			b.WriteString("\n")
+		case p.slungTo != "":
+			b.WriteString(p.th.PassStyle.Render(fmt.Sprintf("  Slung to %s (new polecat)", p.slungTo)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(p.th.MutedStyle.Render("  n=create another  esc=back"))
 	}
 
 	return b.String()
@@ -231,6 +231,35 @@ func TestNewIssuePaneResultView(t *testing.T) {
 	}
 }
 
+func TestNewIssuePaneResultShowsSling(t *testing.T) {
+	p := NewNewIssuePane()
+	p.SetSize(80, 24)
+
+	p.Update(IssueSubmitMsg{BeadID: "kt-abc1", SlungTo: "kestral_tui"})
+	if view := p.View(); !strings.Contains(view, "Slung to kestral_tui") {
+		t.Errorf("result should report the sling:\n%s", view)
+	}
+
+	p.resetForm()
+	p.Update(IssueSubmitMsg{BeadID: "kt-abc2", SlingErr: errTest})
+	view := p.View()
+	if !strings.Contains(view, "kt-abc2") || !strings.Contains(view, "Sling failed") {
+		t.Errorf("a failed sling should still show the created issue:\n%s", view)
+	}
+}
+
+func TestNewIssuePaneSlingToggle(t *testing.T) {
+	p := NewNewIssuePane()
+	p.activeField = fieldSling
+	p.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if submitModes[p.slingIdx] != "create and sling" {
+		t.Fatalf("right should pick create and sling, got %q", submitModes[p.slingIdx])
+	}
+	if got := p.SaveState()["then"]; got != "create and sling" {
+		t.Errorf("saved draft then = %q", got)
+	}
+}
+
 func TestNewIssuePaneResultError(t *testing.T) {
 	p := NewNewIssuePane()
 	p.SetSize(80, 24)
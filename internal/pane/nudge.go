@@ -0,0 +1,273 @@
+package pane
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
+)
+
+// nudgeTimeout bounds gt nudge, which returns once the message is typed
+// into the agent's session.
+const nudgeTimeout = 30 * time.Second
+
+// maxNudgeReply is how many reply lines the exchange keeps.
+const maxNudgeReply = 20
+
+// NudgeOpenMsg asks the app to open the nudge composer for the agent
+// identified by "rig/name" in the Agents pane's detail view.
+type NudgeOpenMsg struct {
+	Agent string
+}
+
+// NudgeRequestMsg asks the app to nudge an agent. The app captures the
+// agent's session output first, so the reply can be told apart from what
+// was already on screen.
+type NudgeRequestMsg struct {
+	Rig     string
+	Session string // session name within the rig, as FetchAgentOutput takes it
+	Target  string // gt nudge address, e.g. "kestral_tui/quartz"
+	Text    string
+}
+
+// NudgeSentMsg delivers the result of a gt nudge call along with the
+// session output captured just before it.
+type NudgeSentMsg struct {
+	Rig      string
+	Session  string
+	Target   string
+	Text     string
+	Baseline string
+	Err      error
+}
+
+// NudgeOutputMsg carries the nudged agent's session output while the app
+// watches for its reply. Done marks the last capture.
+type NudgeOutputMsg struct {
+	Target string
+	Output string
+	Done   bool
+}
+
+// SendNudge runs gt nudge in the town at dir.
+func SendNudge(dir, target, text string) error {
+	_, err := runTownCmd(dir, nudgeTimeout, "gt", "nudge", target, text)
+	return err
+}
+
+// Session is the agent's tmux session name within its rig: gt-<rig>-<Session>.
+func (a AgentInfo) Session() string {
+	if a.Role == "crew" {
+		return "crew-" + a.Name
+	}
+	return a.Name
+}
+
+// NudgeTarget is the address gt nudge takes for the agent.
+func (a AgentInfo) NudgeTarget() string {
+	switch a.Role {
+	case "mayor":
+		return "mayor/"
+	case "crew":
+		return a.Rig + "/crew/" + a.Name
+	default:
+		return a.Rig + "/" + a.Name
+	}
+}
+
+// nudgeReply returns the non-blank lines of after that were not in before,
+// in order. Lines are matched by count rather than position, so output that
+// scrolled or a prompt box redrawn below new text isn't mistaken for a
+// reply.
+func nudgeReply(before, after string) []string {
+	seen := make(map[string]int)
+	for _, line := range strings.Split(before, "\n") {
+		seen[strings.TrimRight(line, " ")]++
+	}
+	var reply []string
+	for _, line := range strings.Split(after, "\n") {
+		line = strings.TrimRight(line, " ")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if seen[line] > 0 {
+			seen[line]--
+			continue
+		}
+		reply = append(reply, line)
+	}
+	if len(reply) > maxNudgeReply {
+		reply = reply[len(reply)-maxNudgeReply:]
+	}
+	return reply
+}
+
+type nudgeKeys struct {
+	Send   key.Binding
+	Cancel key.Binding
+}
+
+func newNudgeKeys(km keymap.Map) nudgeKeys {
+	return nudgeKeys{
+		Send:   km.Binding("nudge", "send"),
+		Cancel: km.Binding("nudge", "cancel"),
+	}
+}
+
+// nudgeConsole is a one-line composer for gt nudge and the last exchange
+// with the agent: what was sent and what the agent printed since.
+type nudgeConsole struct {
+	input     textinput.Model
+	composing bool
+	keys      nudgeKeys
+
+	target   string
+	text     string
+	sentAt   time.Time
+	sending  bool
+	watching bool
+	err      error
+	baseline string
+	reply    []string
+}
+
+func newNudgeConsole() nudgeConsole {
+	ti := textinput.New()
+	ti.Placeholder = "message for the agent"
+	ti.CharLimit = 500
+	ti.Prompt = "nudge> "
+	return nudgeConsole{input: ti, keys: newNudgeKeys(keymap.Default())}
+}
+
+// open starts composing a nudge for target. An exchange with a different
+// agent is cleared.
+func (c *nudgeConsole) open(target string) tea.Cmd {
+	if target != c.target {
+		c.reset()
+		c.target = target
+	}
+	c.composing = true
+	c.input.SetValue("")
+	c.input.Focus()
+	return textinput.Blink
+}
+
+// reset forgets the exchange.
+func (c *nudgeConsole) reset() {
+	c.composing = false
+	c.input.Blur()
+	c.target, c.text, c.baseline = "", "", ""
+	c.sending, c.watching = false, false
+	c.err = nil
+	c.reply = nil
+	c.sentAt = time.Time{}
+}
+
+// handleKey handles a key while composing. req is filled in with the
+// agent's rig and session; the message is sent as a NudgeRequestMsg.
+func (c *nudgeConsole) handleKey(msg tea.KeyMsg, req NudgeRequestMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, c.keys.Cancel):
+		c.composing = false
+		c.input.Blur()
+		return nil
+	case key.Matches(msg, c.keys.Send):
+		text := strings.TrimSpace(c.input.Value())
+		if text == "" {
+			return nil
+		}
+		c.composing = false
+		c.input.Blur()
+		c.text, c.sending, c.watching = text, true, false
+		c.err, c.reply, c.baseline = nil, nil, ""
+		c.sentAt = time.Now()
+		req.Target, req.Text = c.target, text
+		return func() tea.Msg { return req }
+	}
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return cmd
+}
+
+// update applies a nudge result or output capture for the console's
+// target. It reports whether anything changed.
+func (c *nudgeConsole) update(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case NudgeSentMsg:
+		if msg.Target != c.target || msg.Text != c.text {
+			return false
+		}
+		c.sending = false
+		c.err = msg.Err
+		c.baseline = msg.Baseline
+		c.watching = msg.Err == nil
+		return true
+	case NudgeOutputMsg:
+		if msg.Target != c.target || c.sending || c.err != nil || c.text == "" {
+			return false
+		}
+		c.reply = nudgeReply(c.baseline, msg.Output)
+		c.watching = !msg.Done
+		return true
+	}
+	return false
+}
+
+// render renders the exchange as a detail view section.
+func (c *nudgeConsole) render(th *theme.Theme, width int) string {
+	if c.text == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(th.AccentStyle.Render("Nudge"))
+	b.WriteString("\n")
+	sent := fmt.Sprintf("  → %s", c.text)
+	b.WriteString(TruncateWithEllipsis(sent, width-6) + th.MutedStyle.Render(" "+c.sentAt.Format("15:04")))
+	b.WriteString("\n")
+
+	maxLine := width - 4
+	switch {
+	case c.sending:
+		b.WriteString(th.MutedStyle.Render("  Sending..."))
+		b.WriteString("\n")
+	case c.err != nil:
+		b.WriteString(th.FailStyle.Render(TruncateWithEllipsis("  ✗ nudge failed: "+c.err.Error(), width)))
+		b.WriteString("\n")
+	default:
+		for _, line := range c.reply {
+			b.WriteString("  ← " + TruncateWithEllipsis(line, maxLine))
+			b.WriteString("\n")
+		}
+		if c.watching {
+			b.WriteString(th.MutedStyle.Render("  watching for a reply..."))
+			b.WriteString("\n")
+		} else if len(c.reply) == 0 {
+			b.WriteString(th.MutedStyle.Render("  (no reply)"))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// view renders the composer line with its key hints.
+func (c *nudgeConsole) view(th *theme.Theme, width int) string {
+	c.input.Width = width - len(c.input.Prompt) - 1
+	hint := th.MutedStyle.Render(fmt.Sprintf("%s send  %s cancel",
+		keymap.Label(c.keys.Send), keymap.Label(c.keys.Cancel)))
+	return c.input.View() + "\n" + TruncateWithEllipsis(hint, width)
+}
+
+// Ensure nudge message types implement tea.Msg.
+var (
+	_ tea.Msg = NudgeOpenMsg{}
+	_ tea.Msg = NudgeRequestMsg{}
+	_ tea.Msg = NudgeSentMsg{}
+	_ tea.Msg = NudgeOutputMsg{}
+)
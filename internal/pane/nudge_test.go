@@ -0,0 +1,115 @@
+package pane
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNudgeReply(t *testing.T) {
+	before := "working on kt-abc\n> \n"
+	after := "working on kt-abc\n> status?\nAlmost done, running tests\n\n> \n"
+	got := nudgeReply(before, after)
+	want := []string{"> status?", "Almost done, running tests"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("nudgeReply = %q, want %q", got, want)
+	}
+	if got := nudgeReply(after, after); len(got) != 0 {
+		t.Errorf("unchanged output should have no reply, got %q", got)
+	}
+}
+
+func TestAgentNudgeTarget(t *testing.T) {
+	tests := []struct {
+		agent   AgentInfo
+		target  string
+		session string
+	}{
+		{AgentInfo{Name: "quartz", Rig: "kt", Role: "polecat"}, "kt/quartz", "quartz"},
+		{AgentInfo{Name: "witness", Rig: "kt", Role: "witness"}, "kt/witness", "witness"},
+		{AgentInfo{Name: "max", Rig: "kt", Role: "crew"}, "kt/crew/max", "crew-max"},
+		{AgentInfo{Name: "mayor", Rig: "hq", Role: "mayor"}, "mayor/", "mayor"},
+	}
+	for _, tt := range tests {
+		if got := tt.agent.NudgeTarget(); got != tt.target {
+			t.Errorf("%s NudgeTarget() = %q, want %q", tt.agent.Name, got, tt.target)
+		}
+		if got := tt.agent.Session(); got != tt.session {
+			t.Errorf("%s Session() = %q, want %q", tt.agent.Name, got, tt.session)
+		}
+	}
+}
+
+func TestAgentsPaneNudgeExchange(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 30)
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "quartz", Rig: "kt", Role: "polecat", Status: "working"}}})
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if !p.detailMode || !p.CapturesInput() || cmd == nil {
+		t.Fatal("n should open the detail view with the nudge composer")
+	}
+
+	for _, r := range "status?" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.CapturesInput() || cmd == nil {
+		t.Fatal("enter should send the nudge and close the composer")
+	}
+	req, ok := cmd().(NudgeRequestMsg)
+	if !ok || req.Target != "kt/quartz" || req.Text != "status?" || req.Session != "quartz" {
+		t.Fatalf("request = %+v", req)
+	}
+
+	p.Update(NudgeSentMsg{Target: "kt/quartz", Text: "status?", Baseline: "> \n"})
+	if view := p.View(); !strings.Contains(view, "→ status?") || !strings.Contains(view, "watching for a reply") {
+		t.Errorf("detail view should show the sent nudge:\n%s", view)
+	}
+
+	p.Update(NudgeOutputMsg{Target: "kt/quartz", Output: "> status?\nTests pass, pushing now\n> \n", Done: true})
+	view := p.View()
+	if !strings.Contains(view, "← Tests pass, pushing now") {
+		t.Errorf("detail view should show the reply:\n%s", view)
+	}
+	if strings.Contains(view, "watching for a reply") {
+		t.Error("the last capture should end the watch")
+	}
+}
+
+func TestAgentsPaneNudgeCancel(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 30)
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "quartz", Rig: "kt", Role: "polecat"}}})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if !p.CapturesInput() {
+		t.Fatal("n in the detail view should open the composer")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.CapturesInput() || !p.detailMode {
+		t.Error("esc should close the composer and stay in the detail view")
+	}
+}
+
+func TestAgentsPaneNudgeFailure(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 30)
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "witness", Rig: "kt", Role: "witness"}}})
+	if _, ok := p.OpenNudge("kt/witness"); !ok {
+		t.Fatal("OpenNudge should find the witness")
+	}
+	if _, ok := p.OpenNudge("kt/nobody"); ok {
+		t.Error("OpenNudge should report an unknown agent")
+	}
+
+	p.OpenNudge("kt/witness")
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hi")})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(NudgeSentMsg{Target: "kt/witness", Text: "hi", Err: errTest})
+	if view := p.View(); !strings.Contains(view, "nudge failed") {
+		t.Errorf("detail view should show the failure:\n%s", view)
+	}
+}
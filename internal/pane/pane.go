@@ -2,10 +2,15 @@ package pane
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 // PaneID identifies each TUI pane.
@@ -25,6 +30,9 @@ const (
 	PaneResources
 	PaneNewIssue
 	PaneWitness
+	PaneEvents
+	PaneDispatch
+	PaneServices
 )
 
 // Pane is the interface that all TUI panes implement.
@@ -37,6 +45,30 @@ type Pane interface {
 	SetSize(w, h int)   // called on resize
 }
 
+// Rebindable is implemented by panes whose keys can be remapped in the
+// keybindings config section.
+type Rebindable interface {
+	SetKeyMap(km keymap.Map)
+	KeyBindings() []key.Binding // shown in the help view
+}
+
+// InputCapturer is implemented by panes that take text input. While
+// CapturesInput is true, global keys go to the pane instead.
+type InputCapturer interface {
+	CapturesInput() bool
+}
+
+// Themed is implemented by panes that render with the session's theme.
+type Themed interface {
+	SetTheme(th *theme.Theme)
+}
+
+// scrollKeys formats the first down and up keys for footer hints, e.g.
+// "j/k".
+func scrollKeys(down, up key.Binding) string {
+	return keymap.Label(down) + "/" + keymap.Label(up)
+}
+
 // TruncateWithEllipsis truncates s to maxLen, appending "…" if truncated.
 // If maxLen < 1, returns an empty string.
 func TruncateWithEllipsis(s string, maxLen int) string {
@@ -53,6 +85,15 @@ func TruncateWithEllipsis(s string, maxLen int) string {
 	return string(runes[:maxLen-1]) + "…"
 }
 
+// capitalize upper-cases the first letter of s, e.g. a service's display
+// name: "mayor" -> "Mayor".
+func capitalize(s string) string {
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
 // FormatAge formats a duration as a human-readable age string.
 func FormatAge(d time.Duration) string {
 	switch {
@@ -67,22 +108,20 @@ func FormatAge(d time.Duration) string {
 	}
 }
 
-// StatusColor maps a work status string to a lipgloss style with the
-// appropriate foreground color.
-func StatusColor(status string) lipgloss.Style {
-	base := lipgloss.NewStyle()
+// StatusColor maps a work status string to the theme style for it.
+func StatusColor(th *theme.Theme, status string) lipgloss.Style {
 	switch status {
 	case "OPEN", "PENDING":
-		return base.Foreground(lipgloss.Color("12")) // blue
+		return th.AccentStyle
 	case "IN_PROGRESS":
-		return base.Foreground(lipgloss.Color("11")) // yellow
+		return th.WarnStyle
 	case "COMPLETED", "CLOSED":
-		return base.Foreground(lipgloss.Color("10")) // green
+		return th.PassStyle
 	case "BLOCKED":
-		return base.Foreground(lipgloss.Color("9")) // red
+		return th.FailStyle
 	case "ESCALATED":
-		return base.Foreground(lipgloss.Color("13")) // magenta
+		return th.FailStyle.Bold(true)
 	default:
-		return base.Foreground(lipgloss.Color("7")) // white/default
+		return lipgloss.NewStyle()
 	}
 }
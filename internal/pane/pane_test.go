@@ -3,6 +3,8 @@ package pane
 import (
 	"testing"
 	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 func TestTruncateWithEllipsis(t *testing.T) {
@@ -64,13 +66,24 @@ func TestStatusColor(t *testing.T) {
 	}
 	for _, s := range statuses {
 		t.Run(s, func(t *testing.T) {
-			style := StatusColor(s)
+			style := StatusColor(theme.Default(), s)
 			// Verify it returns a usable style (renders without panic).
 			_ = style.Render("test")
 		})
 	}
 }
 
+func TestStatusColorFollowsTheme(t *testing.T) {
+	for _, th := range theme.Builtins() {
+		if got := StatusColor(th, "BLOCKED").GetForeground(); got != th.Palette.Fail {
+			t.Errorf("%s: BLOCKED foreground = %v, want %v", th.Name, got, th.Palette.Fail)
+		}
+		if got := StatusColor(th, "COMPLETED").GetForeground(); got != th.Palette.Pass {
+			t.Errorf("%s: COMPLETED foreground = %v, want %v", th.Name, got, th.Palette.Pass)
+		}
+	}
+}
+
 func TestPaneIDValues(t *testing.T) {
 	// Ensure the iota enum values are sequential starting from 0.
 	if PaneDashboard != 0 {
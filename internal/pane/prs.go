@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -28,27 +29,48 @@ type PRsPane struct {
 	err    error
 	detail bool // showing detail view
 	keys   prKeys
+	th     *theme.Theme
 }
 
 type prKeys struct {
-	Up   key.Binding
-	Down key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Back   key.Binding
 }
 
 // NewPRsPane creates a new PRs pane.
 func NewPRsPane() *PRsPane {
 	return &PRsPane{
-		keys: prKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-		},
+		keys: newPrKeys(keymap.Default()),
+		th:   theme.Default(),
 	}
 }
 
+func newPrKeys(km keymap.Map) prKeys {
+	return prKeys{
+		Up:     km.Binding("prs", "up"),
+		Down:   km.Binding("prs", "down"),
+		Select: km.Binding("prs", "select"),
+		Back:   km.Binding("prs", "back"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *PRsPane) SetKeyMap(km keymap.Map) {
+	p.keys = newPrKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *PRsPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Select, p.keys.Back}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *PRsPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *PRsPane) ID() PaneID        { return PanePRs }
 func (p *PRsPane) Title() string      { return "PRs" }
 func (p *PRsPane) ShortTitle() string { return "📋" }
@@ -96,7 +118,7 @@ func (p *PRsPane) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			p.cursor++
 			p.scrollToCursor()
 		}
-	case msg.String() == "enter":
+	case key.Matches(msg, p.keys.Select):
 		if p.cursor < len(p.prs) {
 			p.detail = true
 		}
@@ -105,8 +127,7 @@ func (p *PRsPane) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (p *PRsPane) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "q":
+	if key.Matches(msg, p.keys.Back) {
 		p.detail = false
 	}
 	return p, nil
@@ -128,16 +149,16 @@ func (p *PRsPane) renderList() string {
 
 	// Header
 	header := fmt.Sprintf("─── PRs (%d open) ───", len(p.prs))
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.prs) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No open PRs"))
+		b.WriteString(p.th.MutedStyle.Render("  No open PRs"))
 		return b.String()
 	}
 
@@ -164,7 +185,8 @@ func (p *PRsPane) renderList() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("j/k scroll  enter detail  r refresh")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s scroll  %s detail",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Select)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -175,18 +197,18 @@ func (p *PRsPane) renderRows() []string {
 	var rows []string
 	for i, pr := range p.prs {
 		selected := i == p.cursor
-		icon := prStatusIcon(pr)
-		line := formatPRRow(icon, pr, p.width, selected)
+		icon := prStatusIcon(p.th, pr)
+		line := formatPRRow(p.th, icon, pr, p.width, selected)
 		rows = append(rows, line)
 
 		// Second line: branch + review + merge info
-		detail := formatPRDetailLine(pr, p.width, selected)
+		detail := formatPRDetailLine(p.th, pr, p.width, selected)
 		rows = append(rows, detail)
 	}
 	return rows
 }
 
-func formatPRRow(icon string, pr data.PRInfo, width int, selected bool) string {
+func formatPRRow(th *theme.Theme, icon string, pr data.PRInfo, width int, selected bool) string {
 	numStr := fmt.Sprintf("#%d", pr.Number)
 	author := pr.Author.Login
 	if author == "" {
@@ -207,31 +229,31 @@ func formatPRRow(icon string, pr data.PRInfo, width int, selected bool) string {
 	line := fmt.Sprintf("  %s %s  %s  %s  %s",
 		icon, numStr,
 		padOrTruncate(title, titleMax),
-		theme.MutedStyle.Render(author),
-		theme.MutedStyle.Render(age),
+		th.MutedStyle.Render(author),
+		th.MutedStyle.Render(age),
 	)
 
 	if selected {
-		return theme.AccentStyle.Bold(true).Render(
+		return th.AccentStyle.Bold(true).Render(
 			fmt.Sprintf("  %s %s  %s  %s  %s", icon, numStr, padOrTruncate(title, titleMax), author, age),
 		)
 	}
 	return line
 }
 
-func formatPRDetailLine(pr data.PRInfo, width int, selected bool) string {
+func formatPRDetailLine(th *theme.Theme, pr data.PRInfo, width int, selected bool) string {
 	parts := []string{}
 
 	// Review state
-	review := prReviewLabel(pr.ReviewDecision)
+	review := prReviewLabel(th, pr.ReviewDecision)
 	parts = append(parts, review)
 
 	// Checks summary
-	checks := prChecksLabel(pr)
+	checks := prChecksLabel(th, pr)
 	parts = append(parts, checks)
 
 	// Merge state
-	merge := prMergeLabel(pr)
+	merge := prMergeLabel(th, pr)
 	parts = append(parts, merge)
 
 	// Diff stats
@@ -240,9 +262,9 @@ func formatPRDetailLine(pr data.PRInfo, width int, selected bool) string {
 
 	line := "      " + strings.Join(parts, "  ")
 
-	style := theme.MutedStyle
+	style := th.MutedStyle
 	if selected {
-		style = theme.AccentStyle
+		style = th.AccentStyle
 	}
 	return style.Render(TruncateWithEllipsis(line, width))
 }
@@ -253,12 +275,12 @@ func (p *PRsPane) renderDetail() string {
 
 	// Header
 	header := fmt.Sprintf("─── PR #%d ───", pr.Number)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n\n")
 
 	// Title
 	b.WriteString("  ")
-	b.WriteString(theme.AccentStyle.Bold(true).Render(TruncateWithEllipsis(pr.Title, p.width-2)))
+	b.WriteString(p.th.AccentStyle.Bold(true).Render(TruncateWithEllipsis(pr.Title, p.width-2)))
 	b.WriteString("\n\n")
 
 	// Meta
@@ -270,20 +292,20 @@ func (p *PRsPane) renderDetail() string {
 	b.WriteString(fmt.Sprintf("  Branch:   %s\n", TruncateWithEllipsis(pr.HeadRefName, p.width-12)))
 	b.WriteString(fmt.Sprintf("  Created:  %s\n", prAge(pr.CreatedAt)))
 	if pr.IsDraft {
-		b.WriteString(fmt.Sprintf("  Draft:    %s\n", theme.MutedStyle.Render("yes")))
+		b.WriteString(fmt.Sprintf("  Draft:    %s\n", p.th.MutedStyle.Render("yes")))
 	}
 	b.WriteString("\n")
 
 	// Status checks
 	b.WriteString("  ")
-	b.WriteString(theme.PaneHeaderStyle.Render("Status Checks"))
+	b.WriteString(p.th.PaneHeaderStyle.Render("Status Checks"))
 	b.WriteString("\n")
 	if len(pr.StatusChecks) == 0 {
-		b.WriteString(theme.MutedStyle.Render("    (none)"))
+		b.WriteString(p.th.MutedStyle.Render("    (none)"))
 		b.WriteString("\n")
 	} else {
 		for _, check := range pr.StatusChecks {
-			icon := checkIcon(check)
+			icon := checkIcon(p.th, check)
 			name := TruncateWithEllipsis(check.Name, p.width-8)
 			b.WriteString(fmt.Sprintf("    %s %s\n", icon, name))
 		}
@@ -291,22 +313,22 @@ func (p *PRsPane) renderDetail() string {
 	b.WriteString("\n")
 
 	// Review state
-	b.WriteString(fmt.Sprintf("  Review:     %s\n", prReviewLabel(pr.ReviewDecision)))
-	b.WriteString(fmt.Sprintf("  Mergeable:  %s\n", prMergeLabel(pr)))
+	b.WriteString(fmt.Sprintf("  Review:     %s\n", prReviewLabel(p.th, pr.ReviewDecision)))
+	b.WriteString(fmt.Sprintf("  Mergeable:  %s\n", prMergeLabel(p.th, pr)))
 	b.WriteString(fmt.Sprintf("  Changes:    +%d -%d (%d files)\n", pr.Additions, pr.Deletions, pr.ChangedFiles))
 	b.WriteString("\n")
 
 	// Footer
-	footer := theme.MutedStyle.Render("esc back")
+	footer := p.th.MutedStyle.Render(keymap.Label(p.keys.Back) + " back")
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
 }
 
 // prStatusIcon returns a colored icon summarizing the PR's overall status.
-func prStatusIcon(pr data.PRInfo) string {
+func prStatusIcon(th *theme.Theme, pr data.PRInfo) string {
 	if pr.IsDraft {
-		return theme.MutedStyle.Render("○")
+		return th.MutedStyle.Render("○")
 	}
 
 	checksOK := prAllChecksPassed(pr)
@@ -314,19 +336,19 @@ func prStatusIcon(pr data.PRInfo) string {
 
 	switch {
 	case checksOK && approved:
-		return theme.PassStyle.Render("✓")
+		return th.PassStyle.Render("✓")
 	case pr.Mergeable == "CONFLICTING" || pr.ReviewDecision == "CHANGES_REQUESTED":
-		return theme.FailStyle.Render("✗")
+		return th.FailStyle.Render("✗")
 	case !checksOK && len(pr.StatusChecks) > 0:
 		// Check if any failed vs still pending
 		for _, c := range pr.StatusChecks {
 			if c.Conclusion == "FAILURE" || c.Conclusion == "CANCELLED" || c.Conclusion == "TIMED_OUT" {
-				return theme.FailStyle.Render("✗")
+				return th.FailStyle.Render("✗")
 			}
 		}
-		return theme.WarnStyle.Render("◐")
+		return th.WarnStyle.Render("◐")
 	default:
-		return theme.WarnStyle.Render("◐")
+		return th.WarnStyle.Render("◐")
 	}
 }
 
@@ -343,22 +365,22 @@ func prAllChecksPassed(pr data.PRInfo) bool {
 	return true
 }
 
-func prReviewLabel(decision string) string {
+func prReviewLabel(th *theme.Theme, decision string) string {
 	switch decision {
 	case "APPROVED":
-		return theme.PassStyle.Render("approved")
+		return th.PassStyle.Render("approved")
 	case "CHANGES_REQUESTED":
-		return theme.FailStyle.Render("changes requested")
+		return th.FailStyle.Render("changes requested")
 	case "REVIEW_REQUIRED":
-		return theme.WarnStyle.Render("review required")
+		return th.WarnStyle.Render("review required")
 	default:
-		return theme.MutedStyle.Render("no reviews")
+		return th.MutedStyle.Render("no reviews")
 	}
 }
 
-func prChecksLabel(pr data.PRInfo) string {
+func prChecksLabel(th *theme.Theme, pr data.PRInfo) string {
 	if len(pr.StatusChecks) == 0 {
-		return theme.MutedStyle.Render("no checks")
+		return th.MutedStyle.Render("no checks")
 	}
 
 	passed, failed, pending := 0, 0, 0
@@ -375,35 +397,35 @@ func prChecksLabel(pr data.PRInfo) string {
 
 	total := len(pr.StatusChecks)
 	if failed > 0 {
-		return theme.FailStyle.Render(fmt.Sprintf("%d/%d checks", passed, total))
+		return th.FailStyle.Render(fmt.Sprintf("%d/%d checks", passed, total))
 	}
 	if pending > 0 {
-		return theme.WarnStyle.Render(fmt.Sprintf("%d/%d checks", passed, total))
+		return th.WarnStyle.Render(fmt.Sprintf("%d/%d checks", passed, total))
 	}
-	return theme.PassStyle.Render(fmt.Sprintf("%d/%d checks", passed, total))
+	return th.PassStyle.Render(fmt.Sprintf("%d/%d checks", passed, total))
 }
 
-func prMergeLabel(pr data.PRInfo) string {
+func prMergeLabel(th *theme.Theme, pr data.PRInfo) string {
 	switch pr.Mergeable {
 	case "MERGEABLE":
-		return theme.PassStyle.Render("mergeable")
+		return th.PassStyle.Render("mergeable")
 	case "CONFLICTING":
-		return theme.FailStyle.Render("conflicts")
+		return th.FailStyle.Render("conflicts")
 	default:
-		return theme.MutedStyle.Render("unknown")
+		return th.MutedStyle.Render("unknown")
 	}
 }
 
-func checkIcon(c data.PRStatusCheck) string {
+func checkIcon(th *theme.Theme, c data.PRStatusCheck) string {
 	switch c.Conclusion {
 	case "SUCCESS":
-		return theme.PassStyle.Render("✓")
+		return th.PassStyle.Render("✓")
 	case "FAILURE", "CANCELLED", "TIMED_OUT":
-		return theme.FailStyle.Render("✗")
+		return th.FailStyle.Render("✗")
 	case "NEUTRAL", "SKIPPED":
-		return theme.MutedStyle.Render("–")
+		return th.MutedStyle.Render("–")
 	default:
-		return theme.WarnStyle.Render("◐")
+		return th.WarnStyle.Render("◐")
 	}
 }
 
@@ -461,6 +483,19 @@ func (p *PRsPane) clampScroll() {
 	}
 }
 
+// Focus moves the cursor to the PR with the given number.
+func (p *PRsPane) Focus(key string) bool {
+	for i, pr := range p.prs {
+		if fmt.Sprint(pr.Number) == key {
+			p.detail = false
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
 // Ensure PRsPane implements Pane at compile time.
 var _ Pane = (*PRsPane)(nil)
 
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 func TestNewPRsPane(t *testing.T) {
@@ -297,7 +298,7 @@ func TestPRStatusIcon(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Just verify it doesn't panic and returns something
-			icon := prStatusIcon(tt.pr)
+			icon := prStatusIcon(theme.Default(), tt.pr)
 			if icon == "" {
 				t.Error("prStatusIcon should return a non-empty icon")
 			}
@@ -343,7 +344,7 @@ func TestPRReviewLabel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.decision, func(t *testing.T) {
-			label := prReviewLabel(tt.decision)
+			label := prReviewLabel(theme.Default(), tt.decision)
 			if !strings.Contains(label, tt.contains) {
 				t.Errorf("prReviewLabel(%q) = %q, want to contain %q", tt.decision, label, tt.contains)
 			}
@@ -364,7 +365,7 @@ func TestPRMergeLabel(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.mergeable, func(t *testing.T) {
 			pr := data.PRInfo{Mergeable: tt.mergeable}
-			label := prMergeLabel(pr)
+			label := prMergeLabel(theme.Default(), pr)
 			if !strings.Contains(label, tt.contains) {
 				t.Errorf("prMergeLabel(%q) = %q, want to contain %q", tt.mergeable, label, tt.contains)
 			}
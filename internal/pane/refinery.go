@@ -3,21 +3,26 @@ package pane
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
-// RefineryUpdateMsg carries fresh refinery data to the pane.
+// RefineryUpdateMsg carries fresh refinery data to the pane. Once marks a
+// refresh after a queue action, which doesn't schedule another poll.
 type RefineryUpdateMsg struct {
 	Statuses []data.RefineryStatus
 	Err      error
+	Once     bool
 }
 
-// RefineryPane displays merge queue status per rig.
+// RefineryPane displays merge queue status per rig and runs queue actions
+// on the selected merge request once confirmed.
 type RefineryPane struct {
 	statuses []data.RefineryStatus
 	cursor   int
@@ -27,35 +32,83 @@ type RefineryPane struct {
 	height   int
 	err      error
 	keys     refineryKeys
+	th       *theme.Theme
+
+	detail       *data.MergeRequest // MR shown in detail; nil in the list
+	detailRig    string
+	detailOutput string // refinery session output
+	detailLoaded bool
+	detailOffset int
+
+	townRoot  string    // where gt and bd run; "" = current directory
+	proposed  *MRAction // awaiting confirmation, or running
+	acting    bool
+	result    string // outcome of the last action
+	resultErr error
 }
 
 type refineryKeys struct {
-	Up    key.Binding
-	Down  key.Binding
-	Left  key.Binding
-	Right key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Left    key.Binding
+	Right   key.Binding
+	Retry   key.Binding
+	Skip    key.Binding
+	Front   key.Binding
+	Bump    key.Binding
+	Select  key.Binding
+	Polecat key.Binding
+	Issue   key.Binding
+	Back    key.Binding
 }
 
 // NewRefineryPane creates a new Refinery Status pane.
 func NewRefineryPane() *RefineryPane {
 	return &RefineryPane{
-		keys: refineryKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-			Left: key.NewBinding(
-				key.WithKeys("h", "left"),
-			),
-			Right: key.NewBinding(
-				key.WithKeys("l", "right"),
-			),
-		},
+		keys: newRefineryKeys(keymap.Default()),
+		th:   theme.Default(),
 	}
 }
 
+func newRefineryKeys(km keymap.Map) refineryKeys {
+	return refineryKeys{
+		Up:      km.Binding("refinery", "up"),
+		Down:    km.Binding("refinery", "down"),
+		Left:    km.Binding("refinery", "prev_rig"),
+		Right:   km.Binding("refinery", "next_rig"),
+		Retry:   km.Binding("refinery", "retry"),
+		Skip:    km.Binding("refinery", "skip"),
+		Front:   km.Binding("refinery", "front"),
+		Bump:    km.Binding("refinery", "bump"),
+		Select:  km.Binding("refinery", "select"),
+		Polecat: km.Binding("refinery", "polecat"),
+		Issue:   km.Binding("refinery", "issue"),
+		Back:    km.Binding("refinery", "back"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *RefineryPane) SetKeyMap(km keymap.Map) {
+	p.keys = newRefineryKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *RefineryPane) KeyBindings() []key.Binding {
+	k := p.keys
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Select, k.Polecat, k.Issue,
+		k.Retry, k.Skip, k.Front, k.Bump, k.Back}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *RefineryPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
+// SetTownRoot makes queue actions run in the town at dir.
+func (p *RefineryPane) SetTownRoot(dir string) {
+	p.townRoot = dir
+}
+
 func (p *RefineryPane) ID() PaneID        { return PaneRefinery }
 func (p *RefineryPane) Title() string      { return "Refinery" }
 func (p *RefineryPane) ShortTitle() string { return "🔧" }
@@ -73,6 +126,7 @@ func (p *RefineryPane) SetSize(w, h int) {
 	p.width = w
 	p.height = h
 	p.clampScroll()
+	p.clampDetail()
 }
 
 func (p *RefineryPane) Init() tea.Cmd {
@@ -82,61 +136,288 @@ func (p *RefineryPane) Init() tea.Cmd {
 func (p *RefineryPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case RefineryUpdateMsg:
+		rig, mr := p.selection()
 		p.statuses = msg.Statuses
 		p.err = msg.Err
-		if p.rigIdx >= len(p.statuses) {
-			p.rigIdx = 0
+		p.reselect(rig, mr)
+		p.refreshDetail()
+		p.clampDetail()
+
+	case MROutputMsg:
+		if p.detail != nil && msg.MR == p.detail.ID {
+			p.detailOutput = msg.Output
+			p.detailLoaded = true
+			p.clampDetail()
+		}
+
+	case MRActionMsg:
+		if p.proposed == nil || !p.acting || *p.proposed != msg.Action {
+			break
+		}
+		p.proposed, p.acting = nil, false
+		p.resultErr = msg.Err
+		if msg.Err != nil {
+			p.result = msg.Action.Describe() + " failed"
+		} else {
+			p.result = msg.Action.Describe()
 		}
-		p.clampScroll()
 
 	case tea.KeyMsg:
+		if p.proposed != nil {
+			return p.handleConfirmKey(msg)
+		}
 		switch {
+		case key.Matches(msg, p.keys.Retry):
+			p.propose(MRRetry)
+		case key.Matches(msg, p.keys.Skip):
+			p.propose(MRSkip)
+		case key.Matches(msg, p.keys.Front):
+			p.propose(MRFront)
+		case key.Matches(msg, p.keys.Bump):
+			p.propose(MRBump)
+		case p.detail != nil:
+			return p.handleDetailKey(msg)
+		case key.Matches(msg, p.keys.Select):
+			return p, p.openDetail()
 		case key.Matches(msg, p.keys.Up):
 			if p.cursor > 0 {
 				p.cursor--
 				p.scrollToCursor()
 			}
+			p.result = ""
 		case key.Matches(msg, p.keys.Down):
 			p.cursor++
 			p.clampScroll()
 			p.scrollToCursor()
+			p.result = ""
 		case key.Matches(msg, p.keys.Left):
 			if p.rigIdx > 0 {
 				p.rigIdx--
 				p.cursor = 0
 				p.offset = 0
 			}
+			p.result = ""
 		case key.Matches(msg, p.keys.Right):
 			if p.rigIdx < len(p.statuses)-1 {
 				p.rigIdx++
 				p.cursor = 0
 				p.offset = 0
 			}
+			p.result = ""
 		}
 	}
 	return p, nil
 }
 
+// openDetail shows the MR under the cursor and asks for the refinery
+// output behind it.
+func (p *RefineryPane) openDetail() tea.Cmd {
+	mr := p.selectedMR()
+	if mr == nil {
+		return nil
+	}
+	selected := *mr
+	p.detail = &selected
+	p.detailRig = p.statuses[p.rigIdx].Rig
+	p.detailOutput, p.detailLoaded, p.detailOffset = "", false, 0
+	p.result = ""
+	rig := p.detailRig
+	return func() tea.Msg { return MRSelectedMsg{Rig: rig, MR: selected} }
+}
+
+// selection returns the selected rig and the ID of the MR under the
+// cursor, if any.
+func (p *RefineryPane) selection() (rig, mr string) {
+	if p.rigIdx < len(p.statuses) {
+		rig = p.statuses[p.rigIdx].Rig
+	}
+	if m := p.selectedMR(); m != nil {
+		mr = m.ID
+	}
+	return rig, mr
+}
+
+// reselect finds the selected rig and MR again after a poll, which can
+// add or drop rigs and move MRs between the queue and the history. The
+// cursor stays where it was if the MR is gone.
+func (p *RefineryPane) reselect(rig, mr string) {
+	found := false
+	for i, s := range p.statuses {
+		if s.Rig == rig {
+			p.rigIdx, found = i, true
+			break
+		}
+	}
+	if !found {
+		if p.rigIdx >= len(p.statuses) {
+			p.rigIdx = 0
+		}
+		if rig != "" {
+			p.cursor, p.offset = 0, 0
+		}
+	}
+	if found && mr != "" {
+		_, mrAt := p.layoutRows()
+		row := -1
+		for i, m := range mrAt {
+			if m.ID == mr && (row < 0 || i < row) {
+				row = i
+			}
+		}
+		if row >= 0 {
+			p.cursor = row
+			p.scrollToCursor()
+			return
+		}
+	}
+	p.clampScroll()
+}
+
+// refreshDetail picks up the detail MR's latest state after a poll, such
+// as a retried MR going back into the queue.
+func (p *RefineryPane) refreshDetail() {
+	if p.detail == nil {
+		return
+	}
+	for _, s := range p.statuses {
+		if s.Rig != p.detailRig {
+			continue
+		}
+		all := append(append([]data.MergeRequest(nil), s.Queue...), s.History...)
+		if s.Current != nil {
+			all = append(all, *s.Current)
+		}
+		for _, mr := range all {
+			if mr.ID == p.detail.ID {
+				*p.detail = mr
+				return
+			}
+		}
+	}
+}
+
+func (p *RefineryPane) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.keys.Back):
+		p.detail = nil
+		p.result = ""
+	case key.Matches(msg, p.keys.Up):
+		if p.detailOffset > 0 {
+			p.detailOffset--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.detailOffset < len(p.detailRows())-p.detailHeight() {
+			p.detailOffset++
+		}
+	case key.Matches(msg, p.keys.Polecat):
+		if p.detail.Worker == "" {
+			break
+		}
+		link := EventLink{Pane: PaneAgents, Key: p.detailRig + "/" + p.detail.Worker}
+		return p, func() tea.Msg { return EventSelectedMsg{Link: link} }
+	case key.Matches(msg, p.keys.Issue):
+		if p.detail.BeadID == "" {
+			break
+		}
+		link := EventLink{Pane: PaneHistory, Key: p.detail.BeadID}
+		return p, func() tea.Msg { return EventSelectedMsg{Link: link} }
+	}
+	return p, nil
+}
+
+// clampDetail keeps the detail view's scroll offset within its rows, which
+// change as the refinery output arrives.
+func (p *RefineryPane) clampDetail() {
+	if p.detail == nil {
+		return
+	}
+	p.detailOffset = max(min(p.detailOffset, len(p.detailRows())-p.detailHeight()), 0)
+}
+
+// handleConfirmKey runs or cancels the proposed action. A running action
+// can't be cancelled.
+func (p *RefineryPane) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if p.acting {
+		return p, nil
+	}
+	switch {
+	case key.Matches(msg, p.keys.Select):
+		p.acting = true
+		return p, mrActionCmd(p.townRoot, *p.proposed)
+	case key.Matches(msg, p.keys.Back):
+		p.proposed = nil
+	}
+	return p, nil
+}
+
+// propose asks to confirm an action on the selected MR, if it applies
+// there.
+func (p *RefineryPane) propose(kind MRActionKind) {
+	for _, a := range p.selectedActions() {
+		if a.Kind == kind {
+			p.proposed = &a
+			p.result = ""
+			return
+		}
+	}
+}
+
+// selectedActions lists the actions that apply to the shown MR.
+func (p *RefineryPane) selectedActions() []MRAction {
+	rig, mr := p.shownMR()
+	if mr == nil {
+		return nil
+	}
+	queued, current := false, false
+	for _, s := range p.statuses {
+		if s.Rig != rig {
+			continue
+		}
+		for _, q := range s.Queue {
+			queued = queued || q.ID == mr.ID
+		}
+		current = s.Current != nil && s.Current.ID == mr.ID
+	}
+	return mrActions(rig, *mr, queued, current)
+}
+
+// shownMR returns the MR that keys act on and its rig: the one in the
+// detail view, which a poll may have moved away from the cursor, or else
+// the one under the cursor.
+func (p *RefineryPane) shownMR() (string, *data.MergeRequest) {
+	if p.detail != nil {
+		return p.detailRig, p.detail
+	}
+	mr := p.selectedMR()
+	if mr == nil {
+		return "", nil
+	}
+	return p.statuses[p.rigIdx].Rig, mr
+}
+
 func (p *RefineryPane) View() string {
 	if p.width == 0 || p.height == 0 {
 		return ""
 	}
+	if p.detail != nil {
+		return p.renderDetail()
+	}
 
 	var b strings.Builder
 
 	// Header
 	totalQueue := p.Badge()
 	header := fmt.Sprintf("─── REFINERY (%d queued) ───", totalQueue)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.statuses) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No refineries active"))
+		b.WriteString(p.th.MutedStyle.Render("  No refineries active"))
 		return b.String()
 	}
 
@@ -147,13 +428,7 @@ func (p *RefineryPane) View() string {
 	}
 
 	// Content area
-	contentHeight := p.height - 2 // header + footer
-	if len(p.statuses) > 1 {
-		contentHeight-- // rig tabs
-	}
-	if contentHeight < 1 {
-		contentHeight = 1
-	}
+	contentHeight := p.contentHeight()
 
 	rows := p.renderRows()
 	end := p.offset + contentHeight
@@ -175,27 +450,174 @@ func (p *RefineryPane) View() string {
 		b.WriteString("\n")
 	}
 
+	b.WriteString(p.renderLink())
+
 	// Footer
 	var footerParts []string
-	footerParts = append(footerParts, "j/k to scroll")
+	footerParts = append(footerParts, scrollKeys(p.keys.Down, p.keys.Up)+" to scroll")
+	if p.selectedMR() != nil {
+		footerParts = append(footerParts, keymap.Label(p.keys.Select)+" details")
+	}
 	if len(p.statuses) > 1 {
-		footerParts = append(footerParts, "h/l switch rig")
+		footerParts = append(footerParts, keymap.Label(p.keys.Left)+"/"+keymap.Label(p.keys.Right)+" switch rig")
 	}
-	footer := theme.MutedStyle.Render(strings.Join(footerParts, "  "))
+	if actions := p.selectedActions(); len(actions) > 0 && p.proposed == nil {
+		for _, a := range actions {
+			footerParts = append(footerParts, keymap.Label(p.actionKey(a.Kind))+" "+string(a.Kind))
+		}
+	}
+	footer := p.th.MutedStyle.Render(strings.Join(footerParts, "  "))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
 }
 
+// renderLink renders the line above the footer: the action awaiting
+// confirmation or its outcome, else the selected MR's pull request, or a
+// blank line.
+func (p *RefineryPane) renderLink() string {
+	switch {
+	case p.proposed != nil && p.acting:
+		return p.th.MutedStyle.Render(TruncateWithEllipsis("  Running: "+p.proposed.Describe()+"...", p.width)) + "\n"
+	case p.proposed != nil:
+		prompt := fmt.Sprintf("  %s? %s confirm  %s cancel", capitalize(p.proposed.Describe()),
+			keymap.Label(p.keys.Select), keymap.Label(p.keys.Back))
+		return p.th.WarnStyle.Render(TruncateWithEllipsis(prompt, p.width)) + "\n"
+	case p.result != "" && p.resultErr != nil:
+		text := TruncateWithEllipsis("  ✗ "+capitalize(p.result)+": "+p.resultErr.Error(), p.width)
+		return p.th.FailStyle.Render(text) + "\n"
+	case p.result != "":
+		return p.th.PassStyle.Render(TruncateWithEllipsis("  ✓ "+capitalize(p.result), p.width)) + "\n"
+	}
+	_, mr := p.shownMR()
+	if mr == nil || mr.PRURL == "" {
+		return "\n"
+	}
+	return p.th.AccentStyle.Render(TruncateWithEllipsis("  PR: "+mr.PRURL, p.width)) + "\n"
+}
+
+// renderDetail renders the selected MR's fields and, from the refinery's
+// output, why it failed.
+func (p *RefineryPane) renderDetail() string {
+	var b strings.Builder
+	header := fmt.Sprintf("─── MR %s ───", p.detail.ID)
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+
+	rows := p.detailRows()
+	h := p.detailHeight()
+	start := min(p.detailOffset, len(rows))
+	end := min(start+h, len(rows))
+	for _, row := range rows[start:end] {
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+	for i := end - start; i < h; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(p.renderLink())
+	parts := []string{keymap.Label(p.keys.Back) + " back", scrollKeys(p.keys.Down, p.keys.Up) + " scroll"}
+	if p.detail.Worker != "" {
+		parts = append(parts, keymap.Label(p.keys.Polecat)+" polecat")
+	}
+	if p.detail.BeadID != "" {
+		parts = append(parts, keymap.Label(p.keys.Issue)+" issue")
+	}
+	if p.proposed == nil {
+		for _, a := range p.selectedActions() {
+			parts = append(parts, keymap.Label(p.actionKey(a.Kind))+" "+string(a.Kind))
+		}
+	}
+	b.WriteString(TruncateWithEllipsis(p.th.MutedStyle.Render(strings.Join(parts, "  ")), p.width))
+	return b.String()
+}
+
+// detailRows lays out the detail view below its header.
+func (p *RefineryPane) detailRows() []string {
+	mr := p.detail
+	w := p.width
+	rows := []string{
+		TruncateWithEllipsis("  "+mr.Title, w),
+		fmt.Sprintf("  %s %s", historyIcon(p.th, mr.Status), mr.Status),
+	}
+	field := func(label, value string) {
+		if value != "" {
+			rows = append(rows, TruncateWithEllipsis(fmt.Sprintf("  %-9s%s", label+":", value), w))
+		}
+	}
+	field("Issue", mr.BeadID)
+	if mr.Worker != "" {
+		field("Polecat", p.detailRig+"/"+mr.Worker)
+	}
+	field("Branch", mr.Branch)
+	field("PR", mr.PRURL)
+	if wait := mrWait(mr.QueuedAt); wait != "" {
+		field("Queued", wait+" ago")
+	}
+	if mr.MergedAt != "" {
+		field("Closed", mrWait(mr.MergedAt)+" ago")
+	}
+	rows = append(rows, "")
+
+	heading := "  REFINERY OUTPUT"
+	if mr.Status == "failed" {
+		heading = "  WHY IT FAILED"
+	}
+	rows = append(rows, p.th.AccentStyle.Render(heading))
+	switch {
+	case !p.detailLoaded:
+		return append(rows, p.th.MutedStyle.Render("  Loading refinery output..."))
+	case strings.TrimSpace(p.detailOutput) == "":
+		return append(rows, p.th.MutedStyle.Render("  No refinery output; is its session running?"))
+	}
+
+	f := parseMRFailure(p.detailOutput, *mr)
+	fail := func(text string) {
+		rows = append(rows, p.th.FailStyle.Render(TruncateWithEllipsis("  ✗ "+text, w)))
+	}
+	for _, c := range f.Conflicts {
+		fail("merge conflict in " + c)
+	}
+	if len(f.Tests) > 0 {
+		fail(fmt.Sprintf("%d failing tests: %s", len(f.Tests), strings.Join(f.Tests, ", ")))
+	}
+	for _, pkg := range f.Packages {
+		fail("FAIL " + pkg)
+	}
+	for _, loc := range f.Locations {
+		rows = append(rows, p.th.MutedStyle.Render(TruncateWithEllipsis("    "+loc, w)))
+	}
+	if len(f.Excerpt) > 0 {
+		if mr.Status == "failed" {
+			rows = append(rows, p.th.MutedStyle.Render("  No test failure or conflict found; latest output:"))
+		}
+		for _, line := range f.Excerpt {
+			rows = append(rows, TruncateWithEllipsis("    "+line, w))
+		}
+	}
+	return rows
+}
+
+// detailHeight is the number of detail rows that fit between the header
+// and the status and footer lines.
+func (p *RefineryPane) detailHeight() int {
+	h := p.height - 3
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
 // renderRigTabs renders the rig selector tabs.
 func (p *RefineryPane) renderRigTabs() string {
 	var parts []string
 	for i, s := range p.statuses {
 		label := s.Rig
 		if i == p.rigIdx {
-			parts = append(parts, theme.AccentStyle.Bold(true).Render("["+label+"]"))
+			parts = append(parts, p.th.AccentStyle.Bold(true).Render("["+label+"]"))
 		} else {
-			parts = append(parts, theme.MutedStyle.Render(" "+label+" "))
+			parts = append(parts, p.th.MutedStyle.Render(" "+label+" "))
 		}
 	}
 	return "  " + strings.Join(parts, " ")
@@ -203,19 +625,34 @@ func (p *RefineryPane) renderRigTabs() string {
 
 // renderRows produces display rows for the currently selected rig.
 func (p *RefineryPane) renderRows() []string {
+	rows, _ := p.layoutRows()
+	return rows
+}
+
+// selectedMR returns the merge request under the cursor, or nil when the
+// cursor is on a heading or metric row.
+func (p *RefineryPane) selectedMR() *data.MergeRequest {
+	_, mrAt := p.layoutRows()
+	return mrAt[p.cursor]
+}
+
+// layoutRows produces display rows for the currently selected rig, and the
+// merge request each MR row (including its detail lines) belongs to.
+func (p *RefineryPane) layoutRows() ([]string, map[int]*data.MergeRequest) {
 	if p.rigIdx >= len(p.statuses) {
-		return nil
+		return nil, nil
 	}
 
 	s := p.statuses[p.rigIdx]
 	var rows []string
+	mrAt := make(map[int]*data.MergeRequest)
 	rowIdx := 0
 
 	// Status line
-	statusIcon := theme.IconIdle
+	statusIcon := p.th.IconIdle
 	statusLabel := "stopped"
 	if s.Running {
-		statusIcon = theme.IconWorking
+		statusIcon = p.th.IconWorking
 		statusLabel = "running"
 	}
 	rows = append(rows, fmt.Sprintf("  %s Refinery: %s", statusIcon, statusLabel))
@@ -226,28 +663,36 @@ func (p *RefineryPane) renderRows() []string {
 	rowIdx++
 
 	// Current MR being processed
-	rows = append(rows, theme.AccentStyle.Render("  CURRENT"))
+	rows = append(rows, p.th.AccentStyle.Render("  CURRENT"))
 	rowIdx++
 
 	if s.Current != nil {
 		mr := s.Current
-		icon := testStatusIcon(mr.Status)
+		icon := testStatusIcon(p.th, mr.Status)
 		selected := p.cursor == rowIdx
-		line := formatMRRow(icon, mr.BeadID, mr.Title, mr.Status, p.width, selected)
+		line := formatMRRow(p.th, icon, mr.BeadID, mr.Title, mr.Status, p.width, selected)
 		rows = append(rows, line)
+		mrAt[rowIdx] = mr
 		rowIdx++
+		if wait := mrWait(mr.QueuedAt); wait != "" {
+			rows = append(rows, formatMRDetail(p.th, "queued", wait+" ago", p.width, selected))
+			mrAt[rowIdx] = mr
+			rowIdx++
+		}
 		if mr.Branch != "" {
-			branchLine := formatMRDetail("branch", mr.Branch, p.width, selected)
+			branchLine := formatMRDetail(p.th, "branch", mr.Branch, p.width, selected)
 			rows = append(rows, branchLine)
+			mrAt[rowIdx] = mr
 			rowIdx++
 		}
 		if mr.PRURL != "" {
-			prLine := formatMRDetail("pr", mr.PRURL, p.width, selected)
+			prLine := formatMRDetail(p.th, "pr", mr.PRURL, p.width, selected)
 			rows = append(rows, prLine)
+			mrAt[rowIdx] = mr
 			rowIdx++
 		}
 	} else {
-		rows = append(rows, theme.MutedStyle.Render("  (idle)"))
+		rows = append(rows, p.th.MutedStyle.Render("  (idle)"))
 		rowIdx++
 	}
 
@@ -257,18 +702,19 @@ func (p *RefineryPane) renderRows() []string {
 
 	// Queue
 	queueLabel := fmt.Sprintf("  QUEUE (%d)", len(s.Queue))
-	rows = append(rows, theme.AccentStyle.Render(queueLabel))
+	rows = append(rows, p.th.AccentStyle.Render(queueLabel))
 	rowIdx++
 
 	if len(s.Queue) == 0 {
-		rows = append(rows, theme.MutedStyle.Render("  (empty)"))
+		rows = append(rows, p.th.MutedStyle.Render("  (empty)"))
 		rowIdx++
 	} else {
 		for i, mr := range s.Queue {
-			posLabel := fmt.Sprintf("#%d", i+1)
+			posLabel := fmt.Sprintf("#%d P%d", i+1, mr.Priority)
 			selected := p.cursor == rowIdx
-			line := formatQueueRow(posLabel, mr.BeadID, mr.Title, p.width, selected)
+			line := formatQueueRow(p.th, posLabel, mr.BeadID, mr.Title, mrWait(mr.QueuedAt), p.width, selected)
 			rows = append(rows, line)
+			mrAt[rowIdx] = &s.Queue[i]
 			rowIdx++
 		}
 	}
@@ -278,35 +724,37 @@ func (p *RefineryPane) renderRows() []string {
 	rowIdx++
 
 	// Metrics
-	rows = append(rows, theme.AccentStyle.Render("  METRICS"))
+	rows = append(rows, p.th.AccentStyle.Render("  METRICS"))
 	rowIdx++
 
 	successStr := fmt.Sprintf("%.0f%%", s.SuccessRate)
 	if s.SuccessRate >= 90 {
-		successStr = theme.PassStyle.Render(successStr)
+		successStr = p.th.PassStyle.Render(successStr)
 	} else if s.SuccessRate >= 70 {
-		successStr = theme.WarnStyle.Render(successStr)
+		successStr = p.th.WarnStyle.Render(successStr)
 	} else if len(s.History) > 0 {
-		successStr = theme.FailStyle.Render(successStr)
+		successStr = p.th.FailStyle.Render(successStr)
 	} else {
-		successStr = theme.MutedStyle.Render("—")
+		successStr = p.th.MutedStyle.Render("—")
 	}
 	rows = append(rows, fmt.Sprintf("  Success rate:  %s", successStr))
 	rowIdx++
 
 	avgTime := "—"
 	if s.AvgMergeTime > 0 {
-		avgTime = FormatAge(0) // Will show "just now" for 0
-		if s.AvgMergeTime >= 60 {
-			avgTime = fmt.Sprintf("%dm", s.AvgMergeTime/60)
-		} else {
-			avgTime = fmt.Sprintf("%ds", s.AvgMergeTime)
-		}
+		avgTime = fmt.Sprintf("%s (p90 %s)", formatMergeTime(s.AvgMergeTime), formatMergeTime(s.P90MergeTime))
+	}
+	rows = append(rows, fmt.Sprintf("  Avg merge:     %s", p.th.MutedStyle.Render(avgTime)))
+	rowIdx++
+
+	streak := p.th.MutedStyle.Render("—")
+	if s.FailStreak > 0 {
+		streak = p.th.FailStyle.Render(fmt.Sprintf("%d failed in a row", s.FailStreak))
 	}
-	rows = append(rows, fmt.Sprintf("  Avg merge:     %s", theme.MutedStyle.Render(avgTime)))
+	rows = append(rows, fmt.Sprintf("  Fail streak:   %s", streak))
 	rowIdx++
 
-	rows = append(rows, fmt.Sprintf("  Queue wait:    %s", theme.MutedStyle.Render(queueWaitEstimate(s))))
+	rows = append(rows, fmt.Sprintf("  Queue wait:    %s", p.th.MutedStyle.Render(queueWaitEstimate(s))))
 	rowIdx++
 
 	// Blank separator
@@ -315,26 +763,27 @@ func (p *RefineryPane) renderRows() []string {
 
 	// History (last 10)
 	histLabel := fmt.Sprintf("  HISTORY (%d)", len(s.History))
-	rows = append(rows, theme.AccentStyle.Render(histLabel))
+	rows = append(rows, p.th.AccentStyle.Render(histLabel))
 	rowIdx++
 
 	if len(s.History) == 0 {
-		rows = append(rows, theme.MutedStyle.Render("  (no history)"))
+		rows = append(rows, p.th.MutedStyle.Render("  (no history)"))
 		rowIdx++
 	} else {
-		for _, mr := range s.History {
-			icon := historyIcon(mr.Status)
+		for i, mr := range s.History {
+			icon := historyIcon(p.th, mr.Status)
 			selected := p.cursor == rowIdx
-			line := formatMRRow(icon, mr.BeadID, mr.Title, mr.Status, p.width, selected)
+			line := formatMRRow(p.th, icon, mr.BeadID, mr.Title, mr.Status, p.width, selected)
 			rows = append(rows, line)
+			mrAt[rowIdx] = &s.History[i]
 			rowIdx++
 		}
 	}
 
-	return rows
+	return rows, mrAt
 }
 
-func formatMRRow(icon, beadID, title, status string, width int, selected bool) string {
+func formatMRRow(th *theme.Theme, icon, beadID, title, status string, width int, selected bool) string {
 	maxTitle := width - 8 - len(beadID) - len(status) - 4
 	if maxTitle < 0 {
 		maxTitle = 0
@@ -344,64 +793,102 @@ func formatMRRow(icon, beadID, title, status string, width int, selected bool) s
 	line := fmt.Sprintf("  %s %s %s", icon, beadID, truncTitle)
 
 	if selected {
-		return theme.AccentStyle.Bold(true).Render(line)
+		return th.AccentStyle.Bold(true).Render(line)
 	}
 	return line
 }
 
-func formatMRDetail(label, value string, width int, selected bool) string {
+func formatMRDetail(th *theme.Theme, label, value string, width int, selected bool) string {
 	maxVal := width - 10 - len(label)
 	if maxVal < 0 {
 		maxVal = 0
 	}
 	line := fmt.Sprintf("      %s: %s", label, TruncateWithEllipsis(value, maxVal))
-	style := theme.MutedStyle
+	style := th.MutedStyle
 	if selected {
-		style = theme.AccentStyle
+		style = th.AccentStyle
 	}
 	return style.Render(line)
 }
 
-func formatQueueRow(pos, beadID, title string, width int, selected bool) string {
+// formatQueueRow renders a queued MR with how long it has waited.
+func formatQueueRow(th *theme.Theme, pos, beadID, title, wait string, width int, selected bool) string {
 	maxTitle := width - 8 - len(pos) - len(beadID) - 2
+	if wait != "" {
+		maxTitle -= len(wait) + 2
+	}
 	if maxTitle < 0 {
 		maxTitle = 0
 	}
 	truncTitle := TruncateWithEllipsis(title, maxTitle)
 	line := fmt.Sprintf("  %s %s %s", pos, beadID, truncTitle)
+	if wait != "" {
+		line += "  " + wait
+	}
 
 	if selected {
-		return theme.AccentStyle.Bold(true).Render(line)
+		return th.AccentStyle.Bold(true).Render(line)
 	}
-	return theme.MutedStyle.Render(line)
+	return th.MutedStyle.Render(line)
 }
 
-func testStatusIcon(status string) string {
+func testStatusIcon(th *theme.Theme, status string) string {
 	switch status {
 	case "testing", "IN_PROGRESS":
-		return theme.IconStale // yellow half-circle = in progress
+		return th.IconStale // yellow half-circle = in progress
 	case "merged", "COMPLETED":
-		return theme.IconWorking // green = pass
+		return th.IconWorking // green = pass
 	case "failed":
-		return theme.IconStuck // red = fail
+		return th.IconStuck // red = fail
 	default:
-		return theme.IconIdle // muted
+		return th.IconIdle // muted
 	}
 }
 
-func historyIcon(status string) string {
+func historyIcon(th *theme.Theme, status string) string {
 	switch status {
 	case "merged", "COMPLETED":
-		return theme.PassStyle.Render("✓")
+		return th.PassStyle.Render("✓")
 	case "failed":
-		return theme.FailStyle.Render("✗")
+		return th.FailStyle.Render("✗")
 	case "skipped":
-		return theme.MutedStyle.Render("⊘")
+		return th.MutedStyle.Render("⊘")
 	default:
-		return theme.MutedStyle.Render("·")
+		return th.MutedStyle.Render("·")
 	}
 }
 
+// actionKey is the key bound to an action kind.
+func (p *RefineryPane) actionKey(kind MRActionKind) key.Binding {
+	switch kind {
+	case MRRetry:
+		return p.keys.Retry
+	case MRSkip:
+		return p.keys.Skip
+	case MRFront:
+		return p.keys.Front
+	default:
+		return p.keys.Bump
+	}
+}
+
+// mrWait is how long ago an MR was queued, or "" if that isn't known.
+func mrWait(queuedAt string) string {
+	t := parseTime(queuedAt)
+	if t.IsZero() {
+		return ""
+	}
+	return formatDuration(time.Since(t))
+}
+
+// formatMergeTime formats a merge time in seconds.
+func formatMergeTime(sec int) string {
+	if sec < 60 {
+		return fmt.Sprintf("%ds", sec)
+	}
+	return formatDuration(time.Duration(sec) * time.Second)
+}
+
 func queueWaitEstimate(s data.RefineryStatus) string {
 	if s.QueueDepth == 0 {
 		return "—"
@@ -460,7 +947,7 @@ func (p *RefineryPane) clampScroll() {
 }
 
 func (p *RefineryPane) contentHeight() int {
-	h := p.height - 2 // header + footer
+	h := p.height - 3 // header + PR link + footer
 	if len(p.statuses) > 1 {
 		h-- // rig tabs
 	}
@@ -470,6 +957,19 @@ func (p *RefineryPane) contentHeight() int {
 	return h
 }
 
+// Focus switches to the refinery tab for the given rig.
+func (p *RefineryPane) Focus(key string) bool {
+	for i, s := range p.statuses {
+		if s.Rig == key {
+			p.rigIdx = i
+			p.cursor = 0
+			p.offset = 0
+			return true
+		}
+	}
+	return false
+}
+
 // Ensure RefineryPane implements Pane at compile time.
 var _ Pane = (*RefineryPane)(nil)
 
@@ -3,10 +3,12 @@ package pane
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
 func TestNewRefineryPane(t *testing.T) {
@@ -351,7 +353,7 @@ func TestTestStatusIcon(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.status, func(t *testing.T) {
-			got := testStatusIcon(tt.status)
+			got := testStatusIcon(theme.Default(), tt.status)
 			if got == "" {
 				t.Errorf("testStatusIcon(%q) returned empty string", tt.status)
 			}
@@ -372,7 +374,7 @@ func TestHistoryIcon(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.status, func(t *testing.T) {
-			got := historyIcon(tt.status)
+			got := historyIcon(theme.Default(), tt.status)
 			if got == "" {
 				t.Errorf("historyIcon(%q) returned empty string", tt.status)
 			}
@@ -416,3 +418,193 @@ func TestRefineryPaneRigIdxClamped(t *testing.T) {
 		t.Errorf("rigIdx should be clamped to 0 when out of bounds, got %d", p.rigIdx)
 	}
 }
+
+func TestRefineryPaneShowsWaitAndMergeTimes(t *testing.T) {
+	p := NewRefineryPane()
+	p.SetSize(100, 40)
+	queued := time.Now().Add(-12 * time.Minute).Format(time.RFC3339)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig:        "kestral_tui",
+		QueueDepth: 1,
+		Queue: []data.MergeRequest{
+			{ID: "mr-2", BeadID: "kt-def", Title: "Add feature", QueuedAt: queued, PRURL: "https://github.com/t/k/pull/7"},
+		},
+		History:      []data.MergeRequest{{ID: "mr-0", BeadID: "kt-xyz", Status: "failed"}},
+		AvgMergeTime: 14 * 60,
+		P90MergeTime: 32 * 60,
+		FailStreak:   1,
+	}}})
+
+	view := p.View()
+	for _, want := range []string{"kt-def Add feature  12m", "14m (p90 32m)", "1 failed in a row"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "PR: ") {
+		t.Error("PR link should only show for the selected MR")
+	}
+
+	// Move onto the queued MR: status, blank, CURRENT, (idle), blank, QUEUE, #1.
+	for i := 0; i < 6; i++ {
+		p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if view := p.View(); !strings.Contains(view, "PR: https://github.com/t/k/pull/7") {
+		t.Errorf("selected MR should link its PR:\n%s", view)
+	}
+}
+
+func refineryWithQueue() *RefineryPane {
+	p := NewRefineryPane()
+	p.SetSize(100, 40)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig:        "kt",
+		QueueDepth: 1,
+		Queue:      []data.MergeRequest{{ID: "kt-mr2", BeadID: "kt-def", Title: "Add feature", Priority: 2}},
+		History:    []data.MergeRequest{{ID: "kt-mr1", BeadID: "kt-abc", Title: "Fix auth", Status: "failed"}},
+	}}})
+	return p
+}
+
+// cursorTo moves the cursor down to the first row of the MR with id.
+func cursorTo(t *testing.T, p *RefineryPane, id string) {
+	t.Helper()
+	for i := 0; i < 40; i++ {
+		if mr := p.selectedMR(); mr != nil && mr.ID == id {
+			return
+		}
+		p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	t.Fatalf("no row for %s", id)
+}
+
+func TestRefineryPaneKeepsSelectionAcrossPolls(t *testing.T) {
+	p := NewRefineryPane()
+	p.SetSize(100, 40)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "bd"},
+		{Rig: "kt", Queue: []data.MergeRequest{
+			{ID: "kt-mr1", BeadID: "kt-a", Priority: 1},
+			{ID: "kt-mr2", BeadID: "kt-b", Priority: 2},
+		}},
+	}})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	cursorTo(t, p, "kt-mr2")
+
+	// A new rig sorts ahead of kt, and kt-mr2 is bumped past kt-mr1.
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "aa"},
+		{Rig: "bd"},
+		{Rig: "kt", Queue: []data.MergeRequest{
+			{ID: "kt-mr2", BeadID: "kt-b", Priority: 1},
+			{ID: "kt-mr1", BeadID: "kt-a", Priority: 1},
+		}},
+	}})
+	if p.statuses[p.rigIdx].Rig != "kt" {
+		t.Errorf("selected rig = %s, want kt", p.statuses[p.rigIdx].Rig)
+	}
+	if mr := p.selectedMR(); mr == nil || mr.ID != "kt-mr2" {
+		t.Errorf("selected MR = %+v, want kt-mr2", mr)
+	}
+
+	// kt-mr2 merges: it moves to the history and stays selected there.
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{
+		{Rig: "kt",
+			Queue:   []data.MergeRequest{{ID: "kt-mr1", BeadID: "kt-a", Priority: 1}},
+			History: []data.MergeRequest{{ID: "kt-mr2", BeadID: "kt-b", Status: "merged"}}},
+	}})
+	if mr := p.selectedMR(); mr == nil || mr.ID != "kt-mr2" || mr.Status != "merged" {
+		t.Errorf("selected MR = %+v, want the merged kt-mr2", mr)
+	}
+}
+
+func TestMRActions(t *testing.T) {
+	failed := data.MergeRequest{ID: "kt-mr1", Status: "failed", Priority: 2}
+	queued := data.MergeRequest{ID: "kt-mr2", Priority: 2}
+	top := data.MergeRequest{ID: "kt-mr3", Priority: 0}
+
+	kindsOf := func(actions []MRAction) string {
+		var ks []string
+		for _, a := range actions {
+			ks = append(ks, string(a.Kind))
+		}
+		return strings.Join(ks, " ")
+	}
+	if got := kindsOf(mrActions("kt", failed, false, false)); got != "retry" {
+		t.Errorf("failed MR actions = %q, want retry", got)
+	}
+	if got := kindsOf(mrActions("kt", queued, true, false)); got != "skip front bump" {
+		t.Errorf("queued MR actions = %q, want skip front bump", got)
+	}
+	if got := kindsOf(mrActions("kt", top, true, false)); got != "skip" {
+		t.Errorf("P0 MR actions = %q, want skip", got)
+	}
+	if got := kindsOf(mrActions("kt", queued, false, true)); got != "skip" {
+		t.Errorf("current MR actions = %q, want skip", got)
+	}
+	if got := kindsOf(mrActions("kt", data.MergeRequest{Status: "merged"}, false, false)); got != "" {
+		t.Errorf("merged MR actions = %q, want none", got)
+	}
+}
+
+func TestMRActionAuditNote(t *testing.T) {
+	a := MRAction{Kind: MRSkip, Rig: "kt", MR: "kt-mr2", BeadID: "kt-def"}
+	if got := a.auditNote(nil); got != "kestral: skip kt-mr2 (kt-def)" {
+		t.Errorf("auditNote = %q", got)
+	}
+	if got := a.auditNote(errTest); got != "kestral: skip kt-mr2 (kt-def) failed: "+errTest.Error() {
+		t.Errorf("auditNote for a failure = %q", got)
+	}
+}
+
+func TestRefineryPaneActionNeedsConfirmation(t *testing.T) {
+	p := refineryWithQueue()
+	cursorTo(t, p, "kt-mr2")
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if cmd != nil || p.proposed == nil || p.proposed.Kind != MRBump || p.proposed.Priority != 1 {
+		t.Fatalf("b should propose bumping to P1, got %+v", p.proposed)
+	}
+	if view := p.View(); !strings.Contains(view, "Bump kt-mr2 (kt-def) to P1? enter confirm") {
+		t.Errorf("view should ask for confirmation:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.proposed != nil {
+		t.Fatal("esc should cancel the action")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !p.acting {
+		t.Fatal("enter should run the action")
+	}
+	action := *p.proposed
+	p.Update(MRActionMsg{Action: action})
+	if p.proposed != nil || p.acting {
+		t.Error("the action should finish")
+	}
+	if view := p.View(); !strings.Contains(view, "✓ Move kt-mr2 (kt-def) to the front (P0)") {
+		t.Errorf("view should confirm the action:\n%s", view)
+	}
+}
+
+func TestRefineryPaneRetryOnlyFailed(t *testing.T) {
+	p := refineryWithQueue()
+	cursorTo(t, p, "kt-mr2")
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if p.proposed != nil {
+		t.Error("a queued MR can't be retried")
+	}
+
+	cursorTo(t, p, "kt-mr1")
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if p.proposed == nil || p.proposed.Kind != MRRetry {
+		t.Fatalf("R on a failed MR should propose a retry, got %+v", p.proposed)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(MRActionMsg{Action: *p.proposed, Err: errTest})
+	if view := p.View(); !strings.Contains(view, "✗ Retry kt-mr1 (kt-abc) failed") {
+		t.Errorf("view should show the failure:\n%s", view)
+	}
+}
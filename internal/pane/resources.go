@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -17,12 +18,6 @@ const (
 	// Number of historical samples to keep per session for sparklines.
 	maxSamples = 10
 
-	// Alert thresholds.
-	cpuWarnThreshold  = 80.0
-	cpuAlertThreshold = 95.0
-	// Number of consecutive samples required for sustained alerts.
-	cpuWarnSamples  = 4  // 4 * 30s = 2 min
-	cpuAlertSamples = 10 // 10 * 30s = 5 min
 	// Stale indicator: no activity change for 15 minutes.
 	staleThreshold = 15 * time.Minute
 )
@@ -43,15 +38,20 @@ const (
 	sortFieldCount // sentinel for cycling
 )
 
+// sortFieldNames labels each sortField in the header and in saved state.
+var sortFieldNames = [...]string{"cpu", "mem", "name"}
+
 // sessionHistory tracks CPU samples for sparkline and alert detection.
 type sessionHistory struct {
 	cpuSamples []float64 // circular buffer, most recent last
+	keep       int       // samples kept beyond maxSamples for long alert windows
 }
 
 func (h *sessionHistory) addSample(cpu float64) {
+	keep := max(h.keep, maxSamples)
 	h.cpuSamples = append(h.cpuSamples, cpu)
-	if len(h.cpuSamples) > maxSamples {
-		h.cpuSamples = h.cpuSamples[len(h.cpuSamples)-maxSamples:]
+	if len(h.cpuSamples) > keep {
+		h.cpuSamples = h.cpuSamples[len(h.cpuSamples)-keep:]
 	}
 }
 
@@ -80,6 +80,8 @@ type ResourcesPane struct {
 	err      error
 	sortBy   sortField
 	keys     resourceKeys
+	th       *theme.Theme
+	limits   data.Thresholds // CPU warning and alert levels
 }
 
 type resourceKeys struct {
@@ -92,20 +94,40 @@ type resourceKeys struct {
 func NewResourcesPane() *ResourcesPane {
 	return &ResourcesPane{
 		history: make(map[string]*sessionHistory),
-		keys: resourceKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-			Sort: key.NewBinding(
-				key.WithKeys("s"),
-			),
-		},
+		keys:    newResourceKeys(keymap.Default()),
+		th:      theme.Default(),
+		limits:  data.DefaultThresholds(),
+	}
+}
+
+func newResourceKeys(km keymap.Map) resourceKeys {
+	return resourceKeys{
+		Up:   km.Binding("resources", "up"),
+		Down: km.Binding("resources", "down"),
+		Sort: km.Binding("resources", "sort"),
 	}
 }
 
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *ResourcesPane) SetKeyMap(km keymap.Map) {
+	p.keys = newResourceKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *ResourcesPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Sort}
+}
+
+// SetThresholds sets the CPU levels the pane warns and alerts at.
+func (p *ResourcesPane) SetThresholds(t data.Thresholds) {
+	p.limits = t
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *ResourcesPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
 func (p *ResourcesPane) ID() PaneID        { return PaneResources }
 func (p *ResourcesPane) Title() string      { return "Resources" }
 func (p *ResourcesPane) ShortTitle() string { return "\U0001F4CA" } // 📊
@@ -141,7 +163,7 @@ func (p *ResourcesPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		for _, s := range p.sessions {
 			h, ok := p.history[s.Name]
 			if !ok {
-				h = &sessionHistory{}
+				h = &sessionHistory{keep: max(p.limits.CPUWarnSamples, p.limits.CPUAlertSamples)}
 				p.history[s.Name] = h
 			}
 			h.addSample(s.CPUPercent)
@@ -178,24 +200,24 @@ func (p *ResourcesPane) View() string {
 
 	// Header
 	alerts := p.Badge()
-	sortLabel := [...]string{"cpu", "mem", "name"}[p.sortBy]
+	sortLabel := sortFieldNames[p.sortBy]
 	header := fmt.Sprintf("─── RESOURCES (%d alerts, sort:%s) ───", alerts, sortLabel)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.sessions) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No tmux sessions"))
+		b.WriteString(p.th.MutedStyle.Render("  No tmux sessions"))
 		return b.String()
 	}
 
 	// Column header
 	colHeader := p.formatColumnHeader()
-	b.WriteString(theme.MutedStyle.Render(TruncateWithEllipsis(colHeader, p.width)))
+	b.WriteString(p.th.MutedStyle.Render(TruncateWithEllipsis(colHeader, p.width)))
 	b.WriteString("\n")
 
 	// Content area
@@ -221,7 +243,8 @@ func (p *ResourcesPane) View() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("j/k=scroll  s=sort  auto-refreshes every 30s")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s=scroll  %s=sort  auto-refreshes every 30s",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Sort)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -256,30 +279,30 @@ func (p *ResourcesPane) renderRows() []string {
 		// Apply status coloring
 		switch {
 		case selected:
-			line = theme.AccentStyle.Bold(true).Render(
+			line = p.th.AccentStyle.Bold(true).Render(
 				fmt.Sprintf("  %s %s %s %s %s %s %s",
 					name, cpu, mem, procs, uptime, statusStr, spark))
 		case status == "alert":
 			// Color the status portion red
 			line = fmt.Sprintf("  %s %s %s %s %s %s %s",
 				name,
-				theme.FailStyle.Render(cpu),
+				p.th.FailStyle.Render(cpu),
 				mem, procs, uptime,
-				theme.FailStyle.Render(statusStr),
+				p.th.FailStyle.Render(statusStr),
 				spark)
 		case status == "warning" || status == "stale":
 			line = fmt.Sprintf("  %s %s %s %s %s %s %s",
 				name,
-				theme.WarnStyle.Render(cpu),
+				p.th.WarnStyle.Render(cpu),
 				mem, procs, uptime,
-				theme.WarnStyle.Render(statusStr),
+				p.th.WarnStyle.Render(statusStr),
 				spark)
 		case status == "healthy":
 			line = fmt.Sprintf("  %s %s %s %s %s %s %s",
 				name,
-				theme.PassStyle.Render(cpu),
+				p.th.PassStyle.Render(cpu),
 				mem, procs, uptime,
-				theme.PassStyle.Render(statusStr),
+				p.th.PassStyle.Render(statusStr),
 				spark)
 		}
 
@@ -302,16 +325,16 @@ func (p *ResourcesPane) sessionStatus(name string, cpu float64, activityTS int64
 
 	// Check for sustained high CPU alerts
 	if h != nil {
-		if h.sustainedAbove(cpuAlertThreshold, cpuAlertSamples) {
+		if h.sustainedAbove(p.limits.CPUAlert, p.limits.CPUAlertSamples) {
 			return "alert"
 		}
-		if h.sustainedAbove(cpuWarnThreshold, cpuWarnSamples) {
+		if h.sustainedAbove(p.limits.CPUWarn, p.limits.CPUWarnSamples) {
 			return "warning"
 		}
 	}
 
 	// Instantaneous threshold checks (for first few samples before sustained detection)
-	if cpu > cpuAlertThreshold {
+	if cpu > p.limits.CPUAlert {
 		return "warning" // not yet sustained, show as warning
 	}
 
@@ -322,7 +345,7 @@ func (p *ResourcesPane) sessionStatus(name string, cpu float64, activityTS int64
 func (p *ResourcesPane) renderSparkline(name string) string {
 	h, ok := p.history[name]
 	if !ok || len(h.cpuSamples) == 0 {
-		return theme.MutedStyle.Render(strings.Repeat("░", maxSamples))
+		return p.th.MutedStyle.Render(strings.Repeat("░", maxSamples))
 	}
 
 	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
@@ -333,7 +356,7 @@ func (p *ResourcesPane) renderSparkline(name string) string {
 		sb.WriteRune('░')
 	}
 
-	for _, v := range h.cpuSamples {
+	for _, v := range h.cpuSamples[max(len(h.cpuSamples)-maxSamples, 0):] {
 		// Map 0-100% to block index 0-7
 		idx := int(v / 100.0 * float64(len(blocks)))
 		if idx >= len(blocks) {
@@ -345,12 +368,12 @@ func (p *ResourcesPane) renderSparkline(name string) string {
 
 		ch := string(blocks[idx])
 		switch {
-		case v >= cpuAlertThreshold:
-			sb.WriteString(theme.FailStyle.Render(ch))
-		case v >= cpuWarnThreshold:
-			sb.WriteString(theme.WarnStyle.Render(ch))
+		case v >= p.limits.CPUAlert:
+			sb.WriteString(p.th.FailStyle.Render(ch))
+		case v >= p.limits.CPUWarn:
+			sb.WriteString(p.th.WarnStyle.Render(ch))
 		default:
-			sb.WriteString(theme.PassStyle.Render(ch))
+			sb.WriteString(p.th.PassStyle.Render(ch))
 		}
 	}
 	return sb.String()
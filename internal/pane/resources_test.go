@@ -347,3 +347,17 @@ func TestResourcesPaneSparkline(t *testing.T) {
 
 // Ensure ResourcesPane implements Pane at compile time.
 var _ Pane = (*ResourcesPane)(nil)
+
+func TestResourcesPaneUsesConfiguredThresholds(t *testing.T) {
+	p := NewResourcesPane()
+	limits := data.DefaultThresholds()
+	limits.CPUAlert, limits.CPUAlertSamples = 50, 12
+	p.SetThresholds(limits)
+
+	for i := 0; i < 12; i++ {
+		p.Update(ResourceUpdateMsg{Sessions: []data.SessionResource{{Name: "gt-kt-quartz", CPUPercent: 60}}})
+	}
+	if got := p.sessionStatus("gt-kt-quartz", 60, 0); got != "alert" {
+		t.Errorf("sessionStatus = %q, want alert after 12 polls above the configured 50%%", got)
+	}
+}
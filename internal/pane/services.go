@@ -0,0 +1,473 @@
+package pane
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
+	"github.com/tnguyen21/kestral-tui/internal/theme"
+)
+
+// serviceTimeout allows for gt starting or stopping a service, or a rig's
+// witness and refinery.
+const serviceTimeout = 60 * time.Second
+
+// ServiceInfo holds a town service's state for display.
+type ServiceInfo struct {
+	Name       string // mayor, deacon
+	Running    bool
+	Status     string        // first line of gt <service> status
+	Uptime     time.Duration // 0 if no session
+	LastActive time.Duration // time since last activity, 0 if no session
+	HasSession bool
+	Err        string // why gt <service> status failed
+}
+
+// ServicesUpdateMsg carries fresh town service state to the pane. Once
+// marks a refresh after an action, which doesn't schedule another poll.
+type ServicesUpdateMsg struct {
+	Services []ServiceInfo
+	Err      error
+	Once     bool
+}
+
+// ServiceStepMsg delivers the result of one step of a service plan.
+type ServiceStepMsg struct {
+	Seq  int // plan the step belongs to
+	Step int
+	Err  error
+}
+
+// stepState is how far a plan step has got.
+type stepState int
+
+const (
+	stepPending stepState = iota
+	stepRunning
+	stepDone
+	stepFailed
+	stepIgnored // failed, but allowed to
+)
+
+// serviceStep is one gt command of a plan. A step that may fail, such as
+// stopping something that may already be down, doesn't end the plan.
+type serviceStep struct {
+	Label   string
+	Args    []string // gt arguments
+	MayFail bool
+	State   stepState
+	Err     error
+}
+
+// planState is where a plan is between confirmation and completion.
+type planState int
+
+const (
+	planNone planState = iota
+	planConfirm
+	planRunning
+	planFinished
+)
+
+// stopAllSteps and startAllSteps follow the documented shutdown and boot
+// order: rig agents go down before the Mayor and the Deacon, and come up
+// after them.
+func stopAllSteps(rigs []string) []serviceStep {
+	var steps []serviceStep
+	for _, rig := range rigs {
+		steps = append(steps, serviceStep{Label: "Shut down " + rig, Args: []string{"rig", "shutdown", rig}, MayFail: true})
+	}
+	return append(steps,
+		serviceStep{Label: "Stop the Mayor", Args: []string{"mayor", "stop"}, MayFail: true},
+		serviceStep{Label: "Stop the Deacon", Args: []string{"deacon", "stop"}, MayFail: true})
+}
+
+func startAllSteps(rigs []string) []serviceStep {
+	steps := []serviceStep{
+		{Label: "Start the Deacon", Args: []string{"deacon", "start"}},
+		{Label: "Start the Mayor", Args: []string{"mayor", "start"}},
+	}
+	for _, rig := range rigs {
+		steps = append(steps, serviceStep{Label: "Boot " + rig, Args: []string{"rig", "boot", rig}})
+	}
+	return steps
+}
+
+// ServicesPane shows the Mayor and the Deacon and runs their lifecycle
+// commands, one at a time or as the guided stop and start everything
+// sequences.
+type ServicesPane struct {
+	services []ServiceInfo
+	err      error
+	rigs     []string
+	cursor   int
+	width    int
+	height   int
+	townRoot string // where gt runs; "" = current directory
+	keys     servicesKeys
+	th       *theme.Theme
+
+	plan      []serviceStep
+	planTitle string
+	planState planState
+	planSeq   int // incremented per plan so a cancelled plan's results are ignored
+}
+
+type servicesKeys struct {
+	Up       key.Binding
+	Down     key.Binding
+	Start    key.Binding
+	Stop     key.Binding
+	Restart  key.Binding
+	StartAll key.Binding
+	StopAll  key.Binding
+	Confirm  key.Binding
+	Back     key.Binding
+}
+
+// NewServicesPane creates a new Town Services pane.
+func NewServicesPane() *ServicesPane {
+	return &ServicesPane{
+		keys: newServicesKeys(keymap.Default()),
+		th:   theme.Default(),
+	}
+}
+
+func newServicesKeys(km keymap.Map) servicesKeys {
+	return servicesKeys{
+		Up:       km.Binding("services", "up"),
+		Down:     km.Binding("services", "down"),
+		Start:    km.Binding("services", "start"),
+		Stop:     km.Binding("services", "stop"),
+		Restart:  km.Binding("services", "restart"),
+		StartAll: km.Binding("services", "start_all"),
+		StopAll:  km.Binding("services", "stop_all"),
+		Confirm:  km.Binding("services", "confirm"),
+		Back:     km.Binding("services", "back"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *ServicesPane) SetKeyMap(km keymap.Map) {
+	p.keys = newServicesKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *ServicesPane) KeyBindings() []key.Binding {
+	k := p.keys
+	return []key.Binding{k.Up, k.Down, k.Start, k.Stop, k.Restart, k.StartAll, k.StopAll, k.Confirm, k.Back}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *ServicesPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
+// SetTownRoot makes gt run in the town at dir.
+func (p *ServicesPane) SetTownRoot(dir string) {
+	p.townRoot = dir
+}
+
+func (p *ServicesPane) ID() PaneID         { return PaneServices }
+func (p *ServicesPane) Title() string      { return "Services" }
+func (p *ServicesPane) ShortTitle() string { return "⚙" }
+
+// Badge returns the number of services that are down.
+func (p *ServicesPane) Badge() int {
+	n := 0
+	for _, s := range p.services {
+		if !s.Running {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *ServicesPane) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+}
+
+func (p *ServicesPane) Init() tea.Cmd {
+	return nil
+}
+
+func (p *ServicesPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ServicesUpdateMsg:
+		if msg.Err == nil || p.services == nil {
+			p.services = msg.Services
+		}
+		p.err = msg.Err
+		if p.cursor >= len(p.services) {
+			p.cursor = 0
+		}
+
+	case RigListMsg:
+		if msg.Err == nil {
+			p.rigs = msg.Rigs
+		}
+
+	case ServiceStepMsg:
+		if msg.Seq != p.planSeq || p.planState != planRunning || msg.Step >= len(p.plan) {
+			break
+		}
+		step := &p.plan[msg.Step]
+		switch {
+		case msg.Err != nil && !step.MayFail:
+			step.State, step.Err = stepFailed, msg.Err
+			p.planState = planFinished
+			return p, nil
+		case msg.Err != nil:
+			step.State, step.Err = stepIgnored, msg.Err
+		default:
+			step.State = stepDone
+		}
+		if next := msg.Step + 1; next < len(p.plan) {
+			return p, p.runStep(next)
+		}
+		p.planState = planFinished
+
+	case tea.KeyMsg:
+		if p.planState != planNone {
+			return p.handlePlanKey(msg)
+		}
+		return p.handleListKey(msg)
+	}
+	return p, nil
+}
+
+func (p *ServicesPane) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.cursor < len(p.services)-1 {
+			p.cursor++
+		}
+	case key.Matches(msg, p.keys.Start):
+		if s, ok := p.selected(); ok {
+			p.propose("Start the "+capitalize(s), []serviceStep{
+				{Label: "Start the " + capitalize(s), Args: []string{s, "start"}},
+			})
+		}
+	case key.Matches(msg, p.keys.Stop):
+		if s, ok := p.selected(); ok {
+			p.propose("Stop the "+capitalize(s), []serviceStep{
+				{Label: "Stop the " + capitalize(s), Args: []string{s, "stop"}},
+			})
+		}
+	case key.Matches(msg, p.keys.Restart):
+		if s, ok := p.selected(); ok {
+			// Restarting a stopped service just starts it.
+			var steps []serviceStep
+			if p.services[p.cursor].Running {
+				steps = append(steps, serviceStep{Label: "Stop the " + capitalize(s), Args: []string{s, "stop"}, MayFail: true})
+			}
+			p.propose("Restart the "+capitalize(s), append(steps,
+				serviceStep{Label: "Start the " + capitalize(s), Args: []string{s, "start"}}))
+		}
+	case key.Matches(msg, p.keys.StopAll):
+		p.propose("Stop everything", stopAllSteps(p.rigs))
+	case key.Matches(msg, p.keys.StartAll):
+		p.propose("Start everything", startAllSteps(p.rigs))
+	}
+	return p, nil
+}
+
+func (p *ServicesPane) handlePlanKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch p.planState {
+	case planConfirm:
+		switch {
+		case key.Matches(msg, p.keys.Confirm):
+			p.planState = planRunning
+			return p, p.runStep(0)
+		case key.Matches(msg, p.keys.Back):
+			p.planState = planNone
+		}
+	case planFinished:
+		if key.Matches(msg, p.keys.Back) || key.Matches(msg, p.keys.Confirm) {
+			p.planState = planNone
+		}
+	}
+	// A running plan can't be interrupted part way.
+	return p, nil
+}
+
+// selected returns the name of the service under the cursor.
+func (p *ServicesPane) selected() (string, bool) {
+	if p.cursor < len(p.services) {
+		return p.services[p.cursor].Name, true
+	}
+	return "", false
+}
+
+// propose shows steps for confirmation.
+func (p *ServicesPane) propose(title string, steps []serviceStep) {
+	p.planSeq++
+	p.planTitle = title
+	p.plan = steps
+	p.planState = planConfirm
+}
+
+// runStep marks step i running and returns the command that runs it.
+func (p *ServicesPane) runStep(i int) tea.Cmd {
+	p.plan[i].State = stepRunning
+	dir, seq, args := p.townRoot, p.planSeq, p.plan[i].Args
+	return func() tea.Msg {
+		_, err := runTownCmd(dir, serviceTimeout, "gt", args...)
+		return ServiceStepMsg{Seq: seq, Step: i, Err: err}
+	}
+}
+
+func (p *ServicesPane) View() string {
+	if p.width == 0 || p.height == 0 {
+		return ""
+	}
+	if p.planState != planNone {
+		return p.renderPlan()
+	}
+	return p.renderList()
+}
+
+func (p *ServicesPane) renderList() string {
+	var b strings.Builder
+	up := len(p.services) - p.Badge()
+	header := fmt.Sprintf("─── TOWN SERVICES (%d/%d up) ───", up, len(p.services))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+
+	if p.err != nil && len(p.services) == 0 {
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
+		return b.String()
+	}
+	if len(p.services) == 0 {
+		b.WriteString(p.th.MutedStyle.Render("  Loading..."))
+		return b.String()
+	}
+
+	lines := 1
+	for i, s := range p.services {
+		for _, row := range p.renderService(i, s) {
+			b.WriteString(row)
+			b.WriteString("\n")
+			lines++
+		}
+	}
+	for ; lines < p.height-1; lines++ {
+		b.WriteString("\n")
+	}
+
+	k := p.keys
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s start  %s stop  %s restart  %s start all  %s stop all",
+		keymap.Label(k.Start), keymap.Label(k.Stop), keymap.Label(k.Restart),
+		keymap.Label(k.StartAll), keymap.Label(k.StopAll)))
+	b.WriteString(TruncateWithEllipsis(footer, p.width))
+	return b.String()
+}
+
+// renderService renders a service's row and its detail lines.
+func (p *ServicesPane) renderService(i int, s ServiceInfo) []string {
+	icon, state := p.th.IconStuck, "stopped"
+	if s.Running {
+		icon, state = p.th.IconWorking, "running"
+	}
+	var parts []string
+	if s.Uptime > 0 {
+		parts = append(parts, "up "+formatUptime(s.Uptime))
+	}
+	if s.HasSession && s.LastActive > 0 {
+		parts = append(parts, "active "+FormatAge(s.LastActive))
+	}
+	line := fmt.Sprintf("  %s %s %s%s%s", icon, RoleIcon(s.Name),
+		padOrTruncate(capitalize(s.Name), 8), padOrTruncate(state, 9), strings.Join(parts, "  "))
+	line = TruncateWithEllipsis(line, p.width)
+	switch {
+	case i == p.cursor:
+		line = p.th.AccentStyle.Bold(true).Render(line)
+	case !s.Running:
+		line = p.th.FailStyle.Render(line)
+	}
+
+	rows := []string{line}
+	switch {
+	case s.Err != "":
+		rows = append(rows, p.th.FailStyle.Render(TruncateWithEllipsis("      status: "+s.Err, p.width)))
+	case s.Status != "":
+		rows = append(rows, p.th.MutedStyle.Render(TruncateWithEllipsis("      "+s.Status, p.width)))
+	}
+	return rows
+}
+
+func (p *ServicesPane) renderPlan() string {
+	var b strings.Builder
+	header := fmt.Sprintf("─── %s ───", strings.ToUpper(p.planTitle))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+
+	done := 0
+	for i, step := range p.plan {
+		var mark string
+		style := p.th.MutedStyle
+		switch step.State {
+		case stepRunning:
+			mark, style = "▸", p.th.AccentStyle
+		case stepDone:
+			mark, style = "✓", p.th.PassStyle
+			done++
+		case stepFailed:
+			mark, style = "✗", p.th.FailStyle
+		case stepIgnored:
+			mark, style = "–", p.th.WarnStyle
+			done++
+		default:
+			mark = "·"
+		}
+		line := fmt.Sprintf("  %s %d. %s  %s", mark, i+1, padOrTruncate(step.Label, 24),
+			"gt "+strings.Join(step.Args, " "))
+		b.WriteString(style.Render(TruncateWithEllipsis(line, p.width)))
+		b.WriteString("\n")
+		switch {
+		case step.State == stepIgnored:
+			b.WriteString(p.th.MutedStyle.Render(TruncateWithEllipsis("      ignored: "+step.Err.Error(), p.width)))
+			b.WriteString("\n")
+		case step.Err != nil:
+			b.WriteString(p.th.FailStyle.Render(TruncateWithEllipsis("      "+step.Err.Error(), p.width)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	var status string
+	switch p.planState {
+	case planConfirm:
+		status = fmt.Sprintf("%s run %d steps  %s cancel",
+			keymap.Label(p.keys.Confirm), len(p.plan), keymap.Label(p.keys.Back))
+	case planRunning:
+		status = fmt.Sprintf("running step %d of %d...", done+1, len(p.plan))
+	case planFinished:
+		if done == len(p.plan) {
+			status = fmt.Sprintf("✓ done — %s to close", keymap.Label(p.keys.Back))
+		} else {
+			status = fmt.Sprintf("✗ stopped after %d of %d steps — %s to close",
+				done, len(p.plan), keymap.Label(p.keys.Back))
+		}
+	}
+	b.WriteString(p.th.AccentStyle.Render(TruncateWithEllipsis("  "+status, p.width)))
+	return b.String()
+}
+
+// Ensure ServicesPane implements Pane at compile time.
+var _ Pane = (*ServicesPane)(nil)
+
+// Ensure message types implement tea.Msg.
+var (
+	_ tea.Msg = ServicesUpdateMsg{}
+	_ tea.Msg = ServiceStepMsg{}
+)
@@ -0,0 +1,153 @@
+package pane
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func servicesWithData() *ServicesPane {
+	p := NewServicesPane()
+	p.SetSize(100, 24)
+	p.Update(ServicesUpdateMsg{Services: []ServiceInfo{
+		{Name: "mayor", Running: true, Status: "Mayor: running", Uptime: 3 * time.Hour, LastActive: 2 * time.Minute, HasSession: true},
+		{Name: "deacon", Status: "Deacon is not running"},
+	}})
+	p.Update(RigListMsg{Rigs: []string{"kestral_tui", "beads"}})
+	return p
+}
+
+func TestNewServicesPane(t *testing.T) {
+	p := NewServicesPane()
+	if p.ID() != PaneServices {
+		t.Errorf("ID() = %d, want %d", p.ID(), PaneServices)
+	}
+	if p.Title() != "Services" {
+		t.Errorf("Title() = %q, want %q", p.Title(), "Services")
+	}
+}
+
+func TestServicesPaneView(t *testing.T) {
+	p := servicesWithData()
+	if p.Badge() != 1 {
+		t.Errorf("Badge() = %d, want 1 service down", p.Badge())
+	}
+	view := p.View()
+	for _, want := range []string{"TOWN SERVICES (1/2 up)", "Mayor", "running", "up 3h", "active 2m ago", "Deacon", "stopped", "Deacon is not running"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestStopAllFollowsShutdownOrder(t *testing.T) {
+	var got []string
+	for _, s := range stopAllSteps([]string{"kestral_tui"}) {
+		got = append(got, strings.Join(s.Args, " "))
+	}
+	want := "rig shutdown kestral_tui|mayor stop|deacon stop"
+	if strings.Join(got, "|") != want {
+		t.Errorf("stop everything = %q, want %q", strings.Join(got, "|"), want)
+	}
+
+	got = nil
+	for _, s := range startAllSteps([]string{"kestral_tui"}) {
+		got = append(got, strings.Join(s.Args, " "))
+	}
+	want = "deacon start|mayor start|rig boot kestral_tui"
+	if strings.Join(got, "|") != want {
+		t.Errorf("start everything = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestServicesPanePlanProgress(t *testing.T) {
+	p := servicesWithData()
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if p.planState != planConfirm || len(p.plan) != 4 {
+		t.Fatalf("X should propose stopping everything, state = %d steps = %d", p.planState, len(p.plan))
+	}
+	if view := p.View(); !strings.Contains(view, "STOP EVERYTHING") || !strings.Contains(view, "gt rig shutdown beads") {
+		t.Errorf("plan view:\n%s", view)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || p.plan[0].State != stepRunning {
+		t.Fatal("enter should run the first step")
+	}
+	seq := p.planSeq
+
+	_, cmd = p.Update(ServiceStepMsg{Seq: seq, Step: 0})
+	if cmd == nil || p.plan[0].State != stepDone || p.plan[1].State != stepRunning {
+		t.Fatal("a finished step should start the next one")
+	}
+	if view := p.View(); !strings.Contains(view, "running step 2 of 4") {
+		t.Errorf("view should show progress:\n%s", view)
+	}
+
+	// Keys don't interrupt a running plan.
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.planState != planRunning {
+		t.Error("esc should not abandon a running plan")
+	}
+
+	// A rig that is already down doesn't stop the shutdown.
+	_, cmd = p.Update(ServiceStepMsg{Seq: seq, Step: 1, Err: errors.New("gt rig shutdown: witness not running")})
+	if cmd == nil || p.plan[1].State != stepIgnored || p.plan[2].State != stepRunning {
+		t.Fatal("a failed stop step should be ignored")
+	}
+	if view := p.View(); !strings.Contains(view, "ignored: gt rig shutdown: witness not running") {
+		t.Errorf("view should show the ignored failure:\n%s", view)
+	}
+	p.Update(ServiceStepMsg{Seq: seq, Step: 2})
+	p.Update(ServiceStepMsg{Seq: seq, Step: 3})
+	if view := p.View(); p.planState != planFinished || !strings.Contains(view, "✓ done") {
+		t.Errorf("the plan should finish:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.planState != planNone {
+		t.Error("esc should close a finished plan")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	seq = p.planSeq
+	_, cmd = p.Update(ServiceStepMsg{Seq: seq, Step: 0, Err: errors.New("gt deacon start: exit status 1")})
+	if cmd != nil || p.planState != planFinished || p.plan[1].State != stepPending {
+		t.Fatal("a failed start step should stop the plan")
+	}
+	if view := p.View(); !strings.Contains(view, "stopped after 0 of 4 steps") || !strings.Contains(view, "exit status 1") {
+		t.Errorf("view should explain the failure:\n%s", view)
+	}
+}
+
+func TestServicesPaneRestartStoppedServiceJustStarts(t *testing.T) {
+	p := servicesWithData()
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if len(p.plan) != 1 || p.plan[0].Label != "Start the Deacon" {
+		t.Fatalf("restarting the stopped Deacon should only start it, plan = %+v", p.plan)
+	}
+}
+
+func TestServicesPaneRestartIgnoresCancelledPlan(t *testing.T) {
+	p := servicesWithData()
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if len(p.plan) != 2 || p.plan[0].Label != "Stop the Mayor" || p.plan[1].Label != "Start the Mayor" {
+		t.Fatalf("restart plan = %+v", p.plan)
+	}
+	old := p.planSeq
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	p.Update(ServiceStepMsg{Seq: old, Step: 0, Err: errTest})
+	if p.planState != planRunning || p.plan[0].Label != "Start the Deacon" {
+		t.Error("a result for a cancelled plan should be ignored")
+	}
+}
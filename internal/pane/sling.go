@@ -0,0 +1,80 @@
+package pane
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// slingTimeout allows for gt sling spawning a polecat before it returns.
+const slingTimeout = 60 * time.Second
+
+// SlingResultMsg delivers the result of a gt sling call.
+type SlingResultMsg struct {
+	BeadID string
+	Target string // as passed to gt sling, e.g. "kestral_tui/quartz"
+	Seq    int    // sling the result belongs to; see DispatchPane.slingSeq
+	Err    error
+}
+
+// slingCmd returns a tea.Cmd that slings beadID to target with gt sling,
+// run in the town at dir. seq is echoed back so the caller can tell its
+// own slings apart.
+func slingCmd(dir, beadID, target string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		err := runSling(dir, beadID, target)
+		return SlingResultMsg{BeadID: beadID, Target: target, Seq: seq, Err: err}
+	}
+}
+
+func runSling(dir, beadID, target string) error {
+	_, err := runTownCmd(dir, slingTimeout, "gt", "sling", beadID, target)
+	return err
+}
+
+// slingTarget is somewhere a bead can be slung: a rig, which spawns a new
+// polecat, or a particular polecat or crew member.
+type slingTarget struct {
+	Target string // gt sling argument
+	Label  string
+	Agent  *AgentInfo // nil for a rig
+}
+
+// slingTargets lists each rig followed by its polecats and crew. Rigs only
+// seen through their agents are included after the listed ones.
+func slingTargets(rigs []string, agents []AgentInfo) []slingTarget {
+	order := append([]string(nil), rigs...)
+	seen := make(map[string]bool, len(rigs))
+	for _, r := range rigs {
+		seen[r] = true
+	}
+	for _, a := range agents {
+		if (a.Role == "polecat" || a.Role == "crew") && !seen[a.Rig] {
+			seen[a.Rig] = true
+			order = append(order, a.Rig)
+		}
+	}
+
+	var targets []slingTarget
+	for _, rig := range order {
+		targets = append(targets, slingTarget{Target: rig, Label: rig + " (new polecat)"})
+		for _, role := range []string{"polecat", "crew"} {
+			for i := range agents {
+				a := &agents[i]
+				if a.Rig != rig || a.Role != role {
+					continue
+				}
+				t := slingTarget{Target: rig + "/" + a.Name, Label: rig + "/" + a.Name, Agent: a}
+				if role == "crew" {
+					t.Target = rig + "/crew/" + a.Name
+					t.Label = rig + "/crew/" + a.Name
+				}
+				targets = append(targets, t)
+			}
+		}
+	}
+	return targets
+}
+
+// Ensure SlingResultMsg implements tea.Msg.
+var _ tea.Msg = SlingResultMsg{}
@@ -0,0 +1,302 @@
+package pane
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PaneState is a pane's saved UI state: selections, filters, open detail
+// views and form drafts. Values are strings so it serializes as plain JSON.
+type PaneState map[string]string
+
+// Stateful is implemented by panes whose UI state survives reconnects.
+type Stateful interface {
+	// SaveState captures the pane's current UI state.
+	SaveState() PaneState
+	// RestoreState applies s and reports whether it is done. Selections
+	// refer to entities by key, so most panes cannot restore until their
+	// data has arrived; the caller retries after each update until
+	// RestoreState returns true. The returned command, if any, should be
+	// run as if the user had made the selection.
+	RestoreState(s PaneState) (bool, tea.Cmd)
+}
+
+// Saved state keys shared between panes.
+const (
+	stateSelected = "selected"
+	stateDetail   = "detail"
+)
+
+func (p *AgentsPane) SaveState() PaneState {
+	s := PaneState{}
+	if p.detailMode {
+		s[stateSelected] = p.selectedAgent.Rig + "/" + p.selectedAgent.Name
+		s[stateDetail] = "true"
+	} else if p.cursor < len(p.agents) {
+		s[stateSelected] = p.agents[p.cursor].Rig + "/" + p.agents[p.cursor].Name
+	}
+	return s
+}
+
+func (p *AgentsPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.agents) == 0 && p.err == nil {
+		return false, nil
+	}
+	if !p.Focus(s[stateSelected]) || s[stateDetail] != "true" {
+		return true, nil
+	}
+	return true, p.openDetail()
+}
+
+func (p *PRsPane) SaveState() PaneState {
+	s := PaneState{}
+	if p.cursor < len(p.prs) {
+		s[stateSelected] = fmt.Sprint(p.prs[p.cursor].Number)
+		if p.detail {
+			s[stateDetail] = "true"
+		}
+	}
+	return s
+}
+
+func (p *PRsPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.prs) == 0 && p.err == nil {
+		return false, nil
+	}
+	if p.Focus(s[stateSelected]) {
+		p.detail = s[stateDetail] == "true"
+	}
+	return true, nil
+}
+
+func (p *ConvoysPane) SaveState() PaneState {
+	s := PaneState{}
+	switch {
+	case p.expanded >= 0 && p.expanded < len(p.convoys):
+		s[stateSelected] = p.convoys[p.expanded].ID
+		s[stateDetail] = "true"
+	case p.cursor < len(p.convoys):
+		s[stateSelected] = p.convoys[p.cursor].ID
+	}
+	return s
+}
+
+func (p *ConvoysPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.convoys) == 0 && p.err == nil {
+		return false, nil
+	}
+	if p.Focus(s[stateSelected]) && s[stateDetail] == "true" {
+		p.expanded = p.cursor
+		p.cursor = 0
+		p.offset = 0
+	}
+	return true, nil
+}
+
+func (p *WitnessPane) SaveState() PaneState {
+	s := PaneState{}
+	if p.cursor < len(p.witnesses) {
+		s[stateSelected] = p.witnesses[p.cursor].Rig
+	}
+	return s
+}
+
+func (p *WitnessPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.witnesses) == 0 && p.err == nil {
+		return false, nil
+	}
+	p.Focus(s[stateSelected])
+	return true, nil
+}
+
+func (p *DispatchPane) SaveState() PaneState {
+	s := PaneState{}
+	if p.cursor < len(p.issues) {
+		s[stateSelected] = p.issues[p.cursor].ID
+	}
+	return s
+}
+
+func (p *DispatchPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.issues) == 0 && p.err == nil {
+		return false, nil
+	}
+	p.Focus(s[stateSelected])
+	return true, nil
+}
+
+func (p *RefineryPane) SaveState() PaneState {
+	s := PaneState{}
+	if p.rigIdx < len(p.statuses) {
+		s[stateSelected] = p.statuses[p.rigIdx].Rig
+	}
+	return s
+}
+
+func (p *RefineryPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.statuses) == 0 && p.err == nil {
+		return false, nil
+	}
+	p.Focus(s[stateSelected])
+	return true, nil
+}
+
+func (p *MailPane) SaveState() PaneState {
+	s := PaneState{}
+	if p.cursor < len(p.messages) {
+		s[stateSelected] = p.messages[p.cursor].ID
+		if p.view == mailViewMessage {
+			s[stateDetail] = "true"
+		}
+	}
+	return s
+}
+
+func (p *MailPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.messages) == 0 && p.err == nil {
+		return false, nil
+	}
+	if p.Focus(s[stateSelected]) && s[stateDetail] == "true" {
+		p.view = mailViewMessage
+		p.offset = 0
+	}
+	return true, nil
+}
+
+func (p *HistoryPane) SaveState() PaneState {
+	s := PaneState{
+		"date":  p.filter.dateRange,
+		"agent": p.filter.agent,
+		"type":  p.filter.issueType,
+	}
+	if p.cursor < len(p.entries) {
+		s[stateSelected] = p.entries[p.cursor].ID
+	}
+	return s
+}
+
+func (p *HistoryPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.closedBeads) == 0 && p.err == nil {
+		return false, nil
+	}
+	switch s["date"] {
+	case "all", "today", "7d", "30d":
+		p.filter.dateRange = s["date"]
+	}
+	p.filter.agent = s["agent"]
+	p.filter.issueType = s["type"]
+	p.rebuildEntries()
+	p.clampScroll()
+	p.Focus(s[stateSelected])
+	return true, nil
+}
+
+func (p *ResourcesPane) SaveState() PaneState {
+	s := PaneState{"sort": sortFieldNames[p.sortBy]}
+	if p.cursor < len(p.sessions) {
+		s[stateSelected] = p.sessions[p.cursor].Name
+	}
+	return s
+}
+
+func (p *ResourcesPane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	if len(p.sessions) == 0 && p.err == nil {
+		return false, nil
+	}
+	for i, name := range sortFieldNames {
+		if name == s["sort"] {
+			p.sortBy = sortField(i)
+			p.sortSessions()
+		}
+	}
+	for i, sess := range p.sessions {
+		if sess.Name == s[stateSelected] {
+			p.cursor = i
+			p.scrollToCursor()
+			break
+		}
+	}
+	return true, nil
+}
+
+// SaveState captures the unsent form draft. A submitted form has nothing
+// worth keeping.
+func (p *NewIssuePane) SaveState() PaneState {
+	if p.state != stateForm {
+		return PaneState{}
+	}
+	s := PaneState{
+		"title":       p.titleInput.Value(),
+		"description": strings.Join(p.description, "\n"),
+		"type":        issueTypes[p.typeIdx],
+		"priority":    priorityFlags[p.priorityIdx],
+		"field":       strconv.Itoa(int(p.activeField)),
+		"then":        submitModes[p.slingIdx],
+	}
+	if p.rigIdx < len(p.rigs) {
+		s["rig"] = p.rigs[p.rigIdx]
+	}
+	return s
+}
+
+// RestoreState refills the form from a saved draft. It is done once the
+// saved rig, if any, has been matched against the rig list.
+func (p *NewIssuePane) RestoreState(s PaneState) (bool, tea.Cmd) {
+	p.titleInput.SetValue(s["title"])
+	p.description = strings.Split(s["description"], "\n")
+	p.descLine = len(p.description) - 1
+	p.descCursor = len([]rune(p.description[p.descLine]))
+	if i := indexOf(issueTypes, s["type"]); i >= 0 {
+		p.typeIdx = i
+	}
+	if i := indexOf(priorityFlags, s["priority"]); i >= 0 {
+		p.priorityIdx = i
+	}
+	if i := indexOf(submitModes, s["then"]); i >= 0 {
+		p.slingIdx = i
+	}
+	if n, err := strconv.Atoi(s["field"]); err == nil && n >= 0 && n < int(fieldCount) {
+		p.activeField = formField(n)
+	}
+	if p.activeField == fieldTitle {
+		p.titleInput.Focus()
+	} else {
+		p.titleInput.Blur()
+	}
+
+	if s["rig"] == "" {
+		return true, nil
+	}
+	if i := indexOf(p.rigs, s["rig"]); i >= 0 {
+		p.rigIdx = i
+		return true, nil
+	}
+	return len(p.rigs) > 0, nil
+}
+
+// indexOf returns the index of v in list, or -1.
+func indexOf(list []string, v string) int {
+	for i, s := range list {
+		if s == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Ensure stateful panes implement Stateful at compile time.
+var (
+	_ Stateful = (*AgentsPane)(nil)
+	_ Stateful = (*PRsPane)(nil)
+	_ Stateful = (*ConvoysPane)(nil)
+	_ Stateful = (*WitnessPane)(nil)
+	_ Stateful = (*RefineryPane)(nil)
+	_ Stateful = (*MailPane)(nil)
+	_ Stateful = (*HistoryPane)(nil)
+	_ Stateful = (*ResourcesPane)(nil)
+	_ Stateful = (*NewIssuePane)(nil)
+	_ Stateful = (*DispatchPane)(nil)
+)
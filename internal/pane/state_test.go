@@ -0,0 +1,146 @@
+package pane
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+func TestAgentsPaneStateRoundTrip(t *testing.T) {
+	agents := []AgentInfo{
+		{Name: "amber", Rig: "kt", Status: "working"},
+		{Name: "quartz", Rig: "kt", Status: "stuck"},
+	}
+	p := NewAgentsPane()
+	p.SetSize(80, 24)
+	p.Update(AgentUpdateMsg{Agents: agents})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	saved := p.SaveState()
+	if saved["selected"] != "kt/quartz" || saved["detail"] != "true" {
+		t.Fatalf("unexpected saved state %v", saved)
+	}
+
+	q := NewAgentsPane()
+	q.SetSize(80, 24)
+	if done, _ := q.RestoreState(saved); done {
+		t.Fatal("restore should wait for agent data")
+	}
+	q.Update(AgentUpdateMsg{Agents: agents})
+	done, cmd := q.RestoreState(saved)
+	if !done {
+		t.Fatal("restore should complete once agents arrive")
+	}
+	if q.cursor != 1 || !q.detailMode {
+		t.Errorf("cursor = %d, detailMode = %v; want 1, true", q.cursor, q.detailMode)
+	}
+	if cmd == nil {
+		t.Fatal("restoring a detail view should announce the selection")
+	}
+	if msg, ok := cmd().(AgentSelectedMsg); !ok || msg.Agent.Name != "quartz" {
+		t.Errorf("expected AgentSelectedMsg for quartz, got %#v", msg)
+	}
+}
+
+func TestAgentsPaneRestoreMissingAgent(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 24)
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "amber", Rig: "kt"}}})
+
+	done, cmd := p.RestoreState(PaneState{"selected": "kt/gone", "detail": "true"})
+	if !done || cmd != nil || p.detailMode {
+		t.Error("a vanished agent should be skipped, not retried")
+	}
+}
+
+func TestHistoryPaneStateRoundTrip(t *testing.T) {
+	beads := []data.ClosedBeadInfo{
+		{ID: "kt-1", IssueType: "bug", Assignee: "kt/polecats/amber", ClosedAt: "2026-01-02T10:00:00Z"},
+		{ID: "kt-2", IssueType: "task", Assignee: "kt/polecats/quartz", ClosedAt: "2026-01-02T11:00:00Z"},
+		{ID: "kt-3", IssueType: "bug", Assignee: "kt/polecats/quartz", ClosedAt: "2026-01-02T12:00:00Z"},
+	}
+	saved := PaneState{"date": "all", "type": "bug", "selected": "kt-1"}
+
+	p := NewHistoryPane()
+	p.SetSize(80, 24)
+	p.Update(HistoryUpdateMsg{ClosedBeads: beads})
+	if done, _ := p.RestoreState(saved); !done {
+		t.Fatal("restore should complete with data loaded")
+	}
+	if p.filter.issueType != "bug" || len(p.entries) != 2 {
+		t.Errorf("type filter not applied: %q, %d entries", p.filter.issueType, len(p.entries))
+	}
+	if p.entries[p.cursor].ID != "kt-1" {
+		t.Errorf("selected %s, want kt-1", p.entries[p.cursor].ID)
+	}
+	if got := p.SaveState(); got["type"] != "bug" || got["selected"] != "kt-1" {
+		t.Errorf("SaveState = %v", got)
+	}
+}
+
+func TestResourcesPaneStateRoundTrip(t *testing.T) {
+	sessions := []data.SessionResource{
+		{Name: "gt-kt-amber", CPUPercent: 50},
+		{Name: "gt-kt-quartz", CPUPercent: 10},
+	}
+	p := NewResourcesPane()
+	p.SetSize(80, 24)
+	p.Update(ResourceUpdateMsg{Sessions: sessions})
+
+	done, _ := p.RestoreState(PaneState{"sort": "name", "selected": "gt-kt-quartz"})
+	if !done {
+		t.Fatal("restore should complete with data loaded")
+	}
+	if p.sortBy != sortByName || p.sessions[p.cursor].Name != "gt-kt-quartz" {
+		t.Errorf("sortBy = %d, selected %s", p.sortBy, p.sessions[p.cursor].Name)
+	}
+}
+
+func TestNewIssuePaneDraftRoundTrip(t *testing.T) {
+	p := NewNewIssuePane()
+	p.SetSize(80, 24)
+	p.Update(RigListMsg{Rigs: []string{"alpha", "beta"}})
+	p.titleInput.SetValue("Reconnect loses drafts")
+	p.description = []string{"Steps:", "drop wifi"}
+	p.typeIdx = 0
+	p.priorityIdx = 1
+	p.rigIdx = 1
+	p.activeField = fieldDescription
+
+	saved := p.SaveState()
+
+	q := NewNewIssuePane()
+	q.SetSize(80, 24)
+	if done, _ := q.RestoreState(saved); done {
+		t.Error("restore should wait for the rig list")
+	}
+	if q.titleInput.Value() != "Reconnect loses drafts" {
+		t.Errorf("title = %q", q.titleInput.Value())
+	}
+	if len(q.description) != 2 || q.description[1] != "drop wifi" {
+		t.Errorf("description = %q", q.description)
+	}
+	if q.activeField != fieldDescription || q.titleInput.Focused() {
+		t.Error("active field should be restored to the description")
+	}
+
+	q.Update(RigListMsg{Rigs: []string{"alpha", "beta"}})
+	if done, _ := q.RestoreState(saved); !done {
+		t.Error("restore should complete once rigs arrive")
+	}
+	if q.rigIdx != 1 || q.typeIdx != 0 || q.priorityIdx != 1 {
+		t.Errorf("rig/type/priority = %d/%d/%d, want 1/0/1", q.rigIdx, q.typeIdx, q.priorityIdx)
+	}
+}
+
+func TestNewIssuePaneSubmittedFormSavesNothing(t *testing.T) {
+	p := NewNewIssuePane()
+	p.titleInput.SetValue("done")
+	p.Update(IssueSubmitMsg{BeadID: "kt-9"})
+	if s := p.SaveState(); len(s) != 0 {
+		t.Errorf("submitted form should not be saved, got %v", s)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/keymap"
 	"github.com/tnguyen21/kestral-tui/internal/theme"
 )
 
@@ -27,7 +29,29 @@ type WitnessUpdateMsg struct {
 	Err       error
 }
 
-// WitnessPane displays witness heartbeat status per rig.
+// WitnessSelectedMsg signals that the user opened a rig's witness detail.
+type WitnessSelectedMsg struct {
+	Rig string
+}
+
+// WitnessDeselectedMsg signals that the user left the witness detail.
+type WitnessDeselectedMsg struct{}
+
+// WitnessDetailMsg carries a rig's polecats and its witness's recent
+// output for the detail view.
+type WitnessDetailMsg struct {
+	Rig      string
+	Polecats []data.PolecatStatus
+	Output   string
+	Err      error
+}
+
+// maxWitnessOutput is how many lines of witness output the detail view
+// shows.
+const maxWitnessOutput = 15
+
+// WitnessPane displays witness heartbeat status per rig, and drills down
+// into a rig's polecats and what its witness last printed.
 type WitnessPane struct {
 	witnesses []WitnessInfo
 	cursor    int
@@ -36,27 +60,65 @@ type WitnessPane struct {
 	height    int
 	err       error
 	keys      witnessKeys
+	th        *theme.Theme
+	limits    data.Thresholds // status cutoffs for the drill-down's polecats
+
+	detailRig     string // rig shown in detail; "" in the list
+	polecats      []data.PolecatStatus
+	witnessOutput string
+	detailErr     error
+	detailLoaded  bool
+	detailCursor  int // selected polecat
+	detailOffset  int
 }
 
 type witnessKeys struct {
-	Up   key.Binding
-	Down key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Nudge  key.Binding
+	Select key.Binding
+	Back   key.Binding
 }
 
 // NewWitnessPane creates a new Witness Heartbeat pane.
 func NewWitnessPane() *WitnessPane {
 	return &WitnessPane{
-		keys: witnessKeys{
-			Up: key.NewBinding(
-				key.WithKeys("k", "up"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("j", "down"),
-			),
-		},
+		keys:   newWitnessKeys(keymap.Default()),
+		th:     theme.Default(),
+		limits: data.DefaultThresholds(),
 	}
 }
 
+func newWitnessKeys(km keymap.Map) witnessKeys {
+	return witnessKeys{
+		Up:     km.Binding("witness", "up"),
+		Down:   km.Binding("witness", "down"),
+		Nudge:  km.Binding("witness", "nudge"),
+		Select: km.Binding("witness", "select"),
+		Back:   km.Binding("witness", "back"),
+	}
+}
+
+// SetKeyMap applies remapped keys from the keybindings config.
+func (p *WitnessPane) SetKeyMap(km keymap.Map) {
+	p.keys = newWitnessKeys(km)
+}
+
+// KeyBindings lists the pane's keys for the help view.
+func (p *WitnessPane) KeyBindings() []key.Binding {
+	return []key.Binding{p.keys.Up, p.keys.Down, p.keys.Select, p.keys.Back, p.keys.Nudge}
+}
+
+// SetTheme switches the pane to the session's theme.
+func (p *WitnessPane) SetTheme(th *theme.Theme) {
+	p.th = th
+}
+
+// SetThresholds sets the cutoffs the drill-down rates polecats by.
+func (p *WitnessPane) SetThresholds(t data.Thresholds) {
+	p.limits = t
+}
+
 func (p *WitnessPane) ID() PaneID        { return PaneWitness }
 func (p *WitnessPane) Title() string      { return "Witnesses" }
 func (p *WitnessPane) ShortTitle() string { return "👁" }
@@ -76,6 +138,7 @@ func (p *WitnessPane) SetSize(w, h int) {
 	p.width = w
 	p.height = h
 	p.clampScroll()
+	p.clampDetail()
 }
 
 func (p *WitnessPane) Init() tea.Cmd {
@@ -88,8 +151,27 @@ func (p *WitnessPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		p.witnesses = msg.Witnesses
 		p.err = msg.Err
 		p.clampScroll()
+		p.clampDetail()
+
+	case WitnessDetailMsg:
+		if msg.Rig != p.detailRig {
+			break
+		}
+		if msg.Err == nil || p.polecats == nil {
+			p.polecats = msg.Polecats
+		}
+		p.witnessOutput = msg.Output
+		p.detailErr = msg.Err
+		p.detailLoaded = true
+		if p.detailCursor >= len(p.polecats) {
+			p.detailCursor = max(len(p.polecats)-1, 0)
+		}
+		p.clampDetail()
 
 	case tea.KeyMsg:
+		if p.detailRig != "" {
+			return p.updateDetail(msg)
+		}
 		switch {
 		case key.Matches(msg, p.keys.Up):
 			if p.cursor > 0 {
@@ -101,31 +183,246 @@ func (p *WitnessPane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.cursor++
 				p.scrollToCursor()
 			}
+		case key.Matches(msg, p.keys.Nudge):
+			// The exchange is shown in the witness's agent detail view.
+			if p.cursor < len(p.witnesses) && p.witnesses[p.cursor].HasSession {
+				agent := p.witnesses[p.cursor].Rig + "/witness"
+				return p, func() tea.Msg { return NudgeOpenMsg{Agent: agent} }
+			}
+		case key.Matches(msg, p.keys.Select):
+			if p.cursor < len(p.witnesses) {
+				return p, p.openDetail(p.witnesses[p.cursor].Rig)
+			}
+		}
+	}
+	return p, nil
+}
+
+// openDetail shows rig's polecats and witness output.
+func (p *WitnessPane) openDetail(rig string) tea.Cmd {
+	p.detailRig = rig
+	p.polecats, p.witnessOutput, p.detailErr = nil, "", nil
+	p.detailLoaded = false
+	p.detailCursor, p.detailOffset = 0, 0
+	return func() tea.Msg { return WitnessSelectedMsg{Rig: rig} }
+}
+
+func (p *WitnessPane) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.keys.Back):
+		p.detailRig = ""
+		return p, func() tea.Msg { return WitnessDeselectedMsg{} }
+	case key.Matches(msg, p.keys.Up):
+		if p.detailCursor > 0 {
+			p.detailCursor--
+		}
+		p.scrollDetail(-1)
+	case key.Matches(msg, p.keys.Down):
+		if p.detailCursor < len(p.polecats)-1 {
+			p.detailCursor++
+		}
+		p.scrollDetail(1)
+	case key.Matches(msg, p.keys.Select):
+		// Open the polecat in the Agents pane, which has its detail view.
+		if p.detailCursor < len(p.polecats) && p.polecats[p.detailCursor].HasSession {
+			link := EventLink{Pane: PaneAgents, Key: p.detailRig + "/" + p.polecats[p.detailCursor].Name}
+			return p, func() tea.Msg { return EventSelectedMsg{Link: link} }
+		}
+	case key.Matches(msg, p.keys.Nudge):
+		if w, ok := p.detailWitness(); ok && w.HasSession {
+			agent := p.detailRig + "/witness"
+			return p, func() tea.Msg { return NudgeOpenMsg{Agent: agent} }
 		}
 	}
 	return p, nil
 }
 
+// scrollDetail keeps the selected polecat in view, or scrolls through the
+// witness output below the last one.
+func (p *WitnessPane) scrollDetail(dir int) {
+	rows, cursorRow := p.detailRows()
+	h := p.detailHeight()
+	switch {
+	case cursorRow >= 0 && cursorRow < p.detailOffset:
+		p.detailOffset = cursorRow
+	case cursorRow >= p.detailOffset+h:
+		p.detailOffset = cursorRow - h + 1
+	case dir > 0 && p.detailCursor >= len(p.polecats)-1 && p.detailOffset+h < len(rows):
+		p.detailOffset++
+	case dir < 0 && p.detailCursor == 0 && p.detailOffset > 0:
+		p.detailOffset--
+	}
+}
+
+// clampDetail keeps the detail view's scroll offset within its rows, which
+// shrink when a poll brings fewer polecats or less output.
+func (p *WitnessPane) clampDetail() {
+	if p.detailRig == "" {
+		return
+	}
+	rows, _ := p.detailRows()
+	p.detailOffset = max(min(p.detailOffset, len(rows)-p.detailHeight()), 0)
+}
+
+// CloseDetail leaves the detail view, for when a link leads elsewhere.
+func (p *WitnessPane) CloseDetail() {
+	p.detailRig = ""
+}
+
+// detailWitness returns the witness of the rig in the detail view.
+func (p *WitnessPane) detailWitness() (WitnessInfo, bool) {
+	for _, w := range p.witnesses {
+		if w.Rig == p.detailRig {
+			return w, true
+		}
+	}
+	return WitnessInfo{}, false
+}
+
+// viewDetail renders a rig's polecats and its witness's recent output.
+func (p *WitnessPane) viewDetail() string {
+	var b strings.Builder
+	header := fmt.Sprintf("─── WITNESS %s ───", p.detailRig)
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString("\n")
+
+	rows, _ := p.detailRows()
+	h := p.detailHeight()
+	start := min(p.detailOffset, len(rows))
+	end := min(start+h, len(rows))
+	for _, row := range rows[start:end] {
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+	for i := end - start; i < h; i++ {
+		b.WriteString("\n")
+	}
+
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s back  %s select  %s open in Agents  %s nudge witness",
+		keymap.Label(p.keys.Back), scrollKeys(p.keys.Down, p.keys.Up),
+		keymap.Label(p.keys.Select), keymap.Label(p.keys.Nudge)))
+	b.WriteString(TruncateWithEllipsis(footer, p.width))
+	return b.String()
+}
+
+// detailRows lays out the detail view below its header, and returns the
+// row of the selected polecat, or -1.
+func (p *WitnessPane) detailRows() ([]string, int) {
+	var rows []string
+	cursorRow := -1
+	if w, ok := p.detailWitness(); ok {
+		heartbeat := "no session"
+		if w.HasSession && w.LastHeartbeat > 0 {
+			heartbeat = "heartbeat " + FormatAge(w.LastHeartbeat)
+		}
+		line := fmt.Sprintf("  %s %s  %s", witnessStatusIcon(p.th, w.Status), w.Status, heartbeat)
+		if w.Uptime > 0 {
+			line += "  uptime " + formatUptime(w.Uptime)
+		}
+		rows = append(rows, TruncateWithEllipsis(line, p.width), "")
+	}
+
+	rows = append(rows, p.th.AccentStyle.Render(fmt.Sprintf("  POLECATS (%d)", len(p.polecats))))
+	switch {
+	case !p.detailLoaded:
+		rows = append(rows, p.th.MutedStyle.Render("  Loading..."))
+	case p.detailErr != nil && len(p.polecats) == 0:
+		rows = append(rows, p.th.FailStyle.Render(TruncateWithEllipsis("  Error: "+p.detailErr.Error(), p.width)))
+	case len(p.polecats) == 0:
+		rows = append(rows, p.th.MutedStyle.Render("  No polecats in this rig"))
+	}
+	now := time.Now()
+	for i, pc := range p.polecats {
+		if i == p.detailCursor {
+			cursorRow = len(rows)
+		}
+		rows = append(rows, formatPolecatRow(p.th, p.limits, pc, now, p.width, i == p.detailCursor))
+	}
+
+	if p.detailLoaded {
+		rows = append(rows, "", p.th.AccentStyle.Render("  WITNESS OUTPUT"))
+		lines := strings.Split(strings.TrimRight(p.witnessOutput, "\n"), "\n")
+		var nonBlank []string
+		for _, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				nonBlank = append(nonBlank, strings.TrimRight(line, " "))
+			}
+		}
+		if len(nonBlank) > maxWitnessOutput {
+			nonBlank = nonBlank[len(nonBlank)-maxWitnessOutput:]
+		}
+		if len(nonBlank) == 0 {
+			rows = append(rows, p.th.MutedStyle.Render("  (no output)"))
+		}
+		for _, line := range nonBlank {
+			rows = append(rows, TruncateWithEllipsis("  "+line, p.width))
+		}
+	}
+	return rows, cursorRow
+}
+
+// formatPolecatRow renders a polecat with its state, hooked issue and last
+// activity. The icon reflects how recently its session was active.
+func formatPolecatRow(th *theme.Theme, limits data.Thresholds, pc data.PolecatStatus, now time.Time, width int, selected bool) string {
+	icon := th.IconIdle
+	activity := "no session"
+	if pc.HasSession && pc.Activity > 0 {
+		age := now.Sub(time.Unix(pc.Activity, 0))
+		icon = statusIcon(th, limits.AgentStatus(age))
+		activity = FormatAge(age)
+	}
+	state := pc.State
+	if state == "" {
+		state = "unlisted"
+	}
+	issue := "—"
+	if pc.Issue != "" {
+		issue = pc.Issue
+		if pc.IssueTitle != "" {
+			issue += " " + pc.IssueTitle
+		}
+	}
+	issueWidth := width - 2 - 2 - 14 - 10 - 12
+	if issueWidth < 8 {
+		issueWidth = 8
+	}
+	line := fmt.Sprintf("  %s %s%s%s  %s", icon, padOrTruncate(pc.Name, 14), padOrTruncate(state, 10),
+		padOrTruncate(TruncateWithEllipsis(issue, issueWidth), issueWidth), activity)
+	line = TruncateWithEllipsis(line, width)
+	if selected {
+		return th.AccentStyle.Bold(true).Render(line)
+	}
+	return line
+}
+
+// detailHeight is the number of detail rows between the header and footer.
+func (p *WitnessPane) detailHeight() int {
+	return max(p.height-2, 1)
+}
+
 func (p *WitnessPane) View() string {
 	if p.width == 0 || p.height == 0 {
 		return ""
 	}
+	if p.detailRig != "" {
+		return p.viewDetail()
+	}
 
 	var b strings.Builder
 
 	// Header line
 	total := len(p.witnesses)
 	header := fmt.Sprintf("─── WITNESS HEARTBEAT (%d rigs) ───", total)
-	b.WriteString(theme.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
+	b.WriteString(p.th.PaneHeaderStyle.Render(TruncateWithEllipsis(header, p.width)))
 	b.WriteString("\n")
 
 	if p.err != nil {
-		b.WriteString(theme.FailStyle.Render("  Error: " + p.err.Error()))
+		b.WriteString(p.th.FailStyle.Render("  Error: " + p.err.Error()))
 		return b.String()
 	}
 
 	if len(p.witnesses) == 0 {
-		b.WriteString(theme.MutedStyle.Render("  No witness sessions detected"))
+		b.WriteString(p.th.MutedStyle.Render("  No witness sessions detected"))
 		return b.String()
 	}
 
@@ -153,7 +450,8 @@ func (p *WitnessPane) View() string {
 	}
 
 	// Footer
-	footer := theme.MutedStyle.Render("j/k to scroll")
+	footer := p.th.MutedStyle.Render(fmt.Sprintf("%s to scroll  %s for polecats  %s to nudge",
+		scrollKeys(p.keys.Down, p.keys.Up), keymap.Label(p.keys.Select), keymap.Label(p.keys.Nudge)))
 	b.WriteString(TruncateWithEllipsis(footer, p.width))
 
 	return b.String()
@@ -164,7 +462,7 @@ func (p *WitnessPane) renderRows() []string {
 	var rows []string
 	for i, w := range p.witnesses {
 		selected := i == p.cursor
-		icon := witnessStatusIcon(w.Status)
+		icon := witnessStatusIcon(p.th, w.Status)
 
 		// Format heartbeat age
 		heartbeat := "no session"
@@ -178,19 +476,19 @@ func (p *WitnessPane) renderRows() []string {
 			polecats = "1 polecat"
 		}
 
-		line := formatWitnessRow(icon, w.Rig, w.Status, heartbeat, polecats, p.width, selected)
+		line := formatWitnessRow(p.th, icon, w.Rig, w.Status, heartbeat, polecats, p.width, selected)
 		rows = append(rows, line)
 
 		// Show detail line for selected witness
 		if selected && w.HasSession {
-			detail := formatWitnessDetail(w, p.width)
+			detail := formatWitnessDetail(p.th, w, p.width)
 			rows = append(rows, detail)
 		}
 	}
 	return rows
 }
 
-func formatWitnessRow(icon, rig, status, heartbeat, polecats string, width int, selected bool) string {
+func formatWitnessRow(th *theme.Theme, icon, rig, status, heartbeat, polecats string, width int, selected bool) string {
 	rigCol := 14
 	statusCol := 8
 	heartbeatCol := 12
@@ -202,18 +500,18 @@ func formatWitnessRow(icon, rig, status, heartbeat, polecats string, width int,
 	line := fmt.Sprintf("  %s %s%s%s%s", icon, rigStr, statusStr, heartbeatStr, polecats)
 
 	if selected {
-		return theme.AccentStyle.Bold(true).Render(line)
+		return th.AccentStyle.Bold(true).Render(line)
 	}
 
 	// Bold dead witnesses for visual alert
 	if status == "dead" {
-		return theme.FailStyle.Bold(true).Render(line)
+		return th.FailStyle.Bold(true).Render(line)
 	}
 
 	return line
 }
 
-func formatWitnessDetail(w WitnessInfo, width int) string {
+func formatWitnessDetail(th *theme.Theme, w WitnessInfo, width int) string {
 	var parts []string
 	if w.Uptime > 0 {
 		parts = append(parts, fmt.Sprintf("uptime: %s", formatUptime(w.Uptime)))
@@ -222,31 +520,24 @@ func formatWitnessDetail(w WitnessInfo, width int) string {
 		return ""
 	}
 	line := "      " + strings.Join(parts, "  ")
-	return theme.MutedStyle.Render(TruncateWithEllipsis(line, width))
+	return th.MutedStyle.Render(TruncateWithEllipsis(line, width))
 }
 
-func witnessStatusIcon(status string) string {
+func witnessStatusIcon(th *theme.Theme, status string) string {
 	switch status {
 	case "alive":
-		return theme.IconWorking
+		return th.IconWorking
 	case "stale":
-		return theme.IconStale
+		return th.IconStale
 	default: // dead
-		return theme.IconStuck
+		return th.IconStuck
 	}
 }
 
-// WitnessStatusFromAge returns "alive", "stale", or "dead" based on heartbeat age.
-// <5m = alive (green), <15m = stale (yellow), >15m = dead (red).
+// WitnessStatusFromAge returns "alive", "stale", or "dead" based on
+// heartbeat age, with the default thresholds.
 func WitnessStatusFromAge(age time.Duration) string {
-	switch {
-	case age < 5*time.Minute:
-		return "alive"
-	case age < 15*time.Minute:
-		return "stale"
-	default:
-		return "dead"
-	}
+	return data.DefaultThresholds().WitnessStatus(age)
 }
 
 // scrollToCursor ensures the cursor row is visible in the viewport.
@@ -304,8 +595,27 @@ func (p *WitnessPane) clampScroll() {
 	}
 }
 
+// Focus moves the cursor to the witness for the given rig, leaving detail
+// mode if necessary.
+func (p *WitnessPane) Focus(key string) bool {
+	for i, w := range p.witnesses {
+		if w.Rig == key {
+			p.detailRig = ""
+			p.cursor = i
+			p.scrollToCursor()
+			return true
+		}
+	}
+	return false
+}
+
 // Ensure WitnessPane implements Pane at compile time.
 var _ Pane = (*WitnessPane)(nil)
 
-// Ensure WitnessUpdateMsg implements tea.Msg.
-var _ tea.Msg = WitnessUpdateMsg{}
+// Ensure witness message types implement tea.Msg.
+var (
+	_ tea.Msg = WitnessUpdateMsg{}
+	_ tea.Msg = WitnessSelectedMsg{}
+	_ tea.Msg = WitnessDeselectedMsg{}
+	_ tea.Msg = WitnessDetailMsg{}
+)
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
 )
 
 func TestNewWitnessPane(t *testing.T) {
@@ -189,3 +191,119 @@ func TestWitnessPaneViewZeroSize(t *testing.T) {
 }
 
 
+
+func TestWitnessPaneNudgeOpensAgent(t *testing.T) {
+	p := NewWitnessPane()
+	p.SetSize(80, 24)
+	p.Update(WitnessUpdateMsg{Witnesses: []WitnessInfo{
+		{Rig: "gastown", Status: "alive", HasSession: true},
+		{Rig: "beads", Status: "dead"},
+	}})
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd == nil {
+		t.Fatal("n should nudge the selected witness")
+	}
+	if msg, ok := cmd().(NudgeOpenMsg); !ok || msg.Agent != "gastown/witness" {
+		t.Errorf("cmd() = %#v, want NudgeOpenMsg for gastown/witness", msg)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); cmd != nil {
+		t.Error("a witness without a session can't be nudged")
+	}
+}
+
+func TestWitnessPaneDetailListsPolecats(t *testing.T) {
+	p := NewWitnessPane()
+	p.SetSize(100, 24)
+	p.Update(WitnessUpdateMsg{Witnesses: []WitnessInfo{
+		{Rig: "gastown", Status: "alive", HasSession: true, Uptime: time.Hour},
+	}})
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should open the witness detail")
+	}
+	if msg, ok := cmd().(WitnessSelectedMsg); !ok || msg.Rig != "gastown" {
+		t.Fatalf("cmd() = %#v, want WitnessSelectedMsg for gastown", msg)
+	}
+	if !strings.Contains(p.View(), "Loading") {
+		t.Error("detail should show loading before data arrives")
+	}
+
+	now := time.Now().Unix()
+	p.Update(WitnessDetailMsg{Rig: "beads", Polecats: []data.PolecatStatus{{Name: "stray"}}})
+	p.Update(WitnessDetailMsg{
+		Rig: "gastown",
+		Polecats: []data.PolecatStatus{
+			{Rig: "gastown", Name: "ace", State: "done", Issue: "gt-1", IssueTitle: "Fix login"},
+			{Rig: "gastown", Name: "toast", State: "working", Issue: "gt-2", HasSession: true, Activity: now},
+		},
+		Output: "patrol: checked toast\n\n",
+	})
+
+	view := p.View()
+	for _, want := range []string{"WITNESS gastown", "POLECATS (2)", "ace", "gt-1 Fix login", "no session", "toast", "patrol: checked toast"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "stray") {
+		t.Error("detail for another rig should be ignored")
+	}
+
+	// ace has no session, so there's nothing to open in Agents.
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("a polecat without a session can't be opened")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should open the selected polecat")
+	}
+	link := EventLink{Pane: PaneAgents, Key: "gastown/toast"}
+	if msg, ok := cmd().(EventSelectedMsg); !ok || msg.Link != link {
+		t.Errorf("cmd() = %#v, want EventSelectedMsg for %+v", msg, link)
+	}
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("esc should leave the detail")
+	}
+	if _, ok := cmd().(WitnessDeselectedMsg); !ok {
+		t.Error("esc should send WitnessDeselectedMsg")
+	}
+	if strings.Contains(p.View(), "POLECATS") {
+		t.Error("esc should return to the witness list")
+	}
+}
+
+func TestWitnessPaneDetailOffsetClampedOnUpdate(t *testing.T) {
+	p := NewWitnessPane()
+	p.SetSize(100, 10)
+	p.Update(WitnessUpdateMsg{Witnesses: []WitnessInfo{{Rig: "gastown", HasSession: true}}})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(WitnessDetailMsg{
+		Rig:      "gastown",
+		Polecats: []data.PolecatStatus{{Rig: "gastown", Name: "ace"}},
+		Output:   strings.Repeat("patrol line\n", 30),
+	})
+	for i := 0; i < 30; i++ {
+		p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if p.detailOffset == 0 {
+		t.Fatal("down past the last polecat should scroll the output")
+	}
+
+	p.Update(WitnessDetailMsg{Rig: "gastown", Polecats: []data.PolecatStatus{{Rig: "gastown", Name: "ace"}}, Output: "quiet\n"})
+	rows, _ := p.detailRows()
+	if want := max(len(rows)-p.detailHeight(), 0); p.detailOffset != want {
+		t.Errorf("detailOffset = %d after the output shrank, want %d", p.detailOffset, want)
+	}
+	before := p.detailOffset
+	p.View()
+	if p.detailOffset != before {
+		t.Error("View should not move the detail offset")
+	}
+}
@@ -0,0 +1,153 @@
+package pane
+
+import "fmt"
+
+// Yank is a value the yank key can copy to the client clipboard.
+type Yank struct {
+	Label string // what the value is, for the confirmation toast
+	Value string
+}
+
+// Yanker is implemented by panes with copyable identifiers.
+type Yanker interface {
+	// Yanks lists the copyable values of the focused entity, most useful
+	// first. Pressing yank again moves on to the next one.
+	Yanks() []Yank
+}
+
+// yanks drops candidates with empty values.
+func yanks(candidates ...Yank) []Yank {
+	var out []Yank
+	for _, y := range candidates {
+		if y.Value != "" {
+			out = append(out, y)
+		}
+	}
+	return out
+}
+
+func (p *AgentsPane) Yanks() []Yank {
+	if p.detailMode {
+		a := p.selectedAgent
+		ys := []Yank{{"issue ID", a.IssueID}}
+		if p.detailData != nil {
+			ys = append(ys, Yank{"branch", p.detailData.Branch})
+			for _, c := range p.detailData.Commits {
+				ys = append(ys, Yank{"commit", c.Hash})
+			}
+		}
+		return yanks(ys...)
+	}
+	if p.cursor >= len(p.agents) {
+		return nil
+	}
+	a := p.agents[p.cursor]
+	return yanks(Yank{"issue ID", a.IssueID}, Yank{"agent", a.Rig + "/" + a.Name})
+}
+
+func (p *PRsPane) Yanks() []Yank {
+	if p.cursor >= len(p.prs) {
+		return nil
+	}
+	pr := p.prs[p.cursor]
+	return yanks(
+		Yank{"PR URL", pr.URL},
+		Yank{"branch", pr.HeadRefName},
+		Yank{"PR number", fmt.Sprint(pr.Number)},
+	)
+}
+
+func (p *ConvoysPane) Yanks() []Yank {
+	if p.expanded >= 0 && p.expanded < len(p.convoys) {
+		c := p.convoys[p.expanded]
+		issues := p.issues[c.ID]
+		if p.cursor < len(issues) {
+			return yanks(Yank{"issue ID", issues[p.cursor].ID}, Yank{"convoy ID", c.ID})
+		}
+		return yanks(Yank{"convoy ID", c.ID})
+	}
+	if p.cursor >= len(p.convoys) {
+		return nil
+	}
+	return yanks(Yank{"convoy ID", p.convoys[p.cursor].ID})
+}
+
+func (p *RefineryPane) Yanks() []Yank {
+	_, mr := p.shownMR()
+	if mr == nil {
+		return nil
+	}
+	return yanks(
+		Yank{"branch", mr.Branch},
+		Yank{"issue ID", mr.BeadID},
+		Yank{"PR URL", mr.PRURL},
+	)
+}
+
+func (p *HistoryPane) Yanks() []Yank {
+	if p.cursor >= len(p.entries) {
+		return nil
+	}
+	return yanks(Yank{"issue ID", p.entries[p.cursor].ID})
+}
+
+func (p *MailPane) Yanks() []Yank {
+	if p.cursor >= len(p.messages) {
+		return nil
+	}
+	m := p.messages[p.cursor]
+	return yanks(Yank{"message ID", m.ID}, Yank{"sender", m.From})
+}
+
+func (p *ResourcesPane) Yanks() []Yank {
+	if p.cursor >= len(p.sessions) {
+		return nil
+	}
+	return yanks(Yank{"session", p.sessions[p.cursor].Name})
+}
+
+func (p *WitnessPane) Yanks() []Yank {
+	if p.detailRig != "" {
+		if p.detailCursor >= len(p.polecats) {
+			return yanks(Yank{"rig", p.detailRig})
+		}
+		pc := p.polecats[p.detailCursor]
+		return yanks(Yank{"issue ID", pc.Issue}, Yank{"agent", p.detailRig + "/" + pc.Name})
+	}
+	if p.cursor >= len(p.witnesses) {
+		return nil
+	}
+	return yanks(Yank{"rig", p.witnesses[p.cursor].Rig})
+}
+
+func (p *DispatchPane) Yanks() []Yank {
+	if p.mode != dispatchList {
+		return yanks(Yank{"issue ID", p.picked.ID})
+	}
+	if p.cursor >= len(p.issues) {
+		return nil
+	}
+	return yanks(Yank{"issue ID", p.issues[p.cursor].ID})
+}
+
+func (p *EventsPane) Yanks() []Yank {
+	if p.cursor >= len(p.events) {
+		return nil
+	}
+	e := p.events[p.cursor]
+	return yanks(Yank{"subject", e.Subject}, Yank{"event", e.Message})
+}
+
+// Ensure list panes implement Yanker at compile time.
+var (
+	_ Yanker = (*AgentsPane)(nil)
+	_ Yanker = (*PRsPane)(nil)
+	_ Yanker = (*ConvoysPane)(nil)
+	_ Yanker = (*RefineryPane)(nil)
+	_ Yanker = (*HistoryPane)(nil)
+	_ Yanker = (*MailPane)(nil)
+	_ Yanker = (*ResourcesPane)(nil)
+	_ Yanker = (*WitnessPane)(nil)
+	_ Yanker = (*EventsPane)(nil)
+	_ Yanker = (*DispatchPane)(nil)
+)
@@ -0,0 +1,108 @@
+package pane
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tnguyen21/kestral-tui/internal/data"
+)
+
+func yankValues(ys []Yank) []string {
+	var vs []string
+	for _, y := range ys {
+		vs = append(vs, y.Value)
+	}
+	return vs
+}
+
+func TestAgentsPaneYanksDetail(t *testing.T) {
+	p := NewAgentsPane()
+	p.SetSize(80, 24)
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "amber", Rig: "kt", IssueID: "kt-7"}}})
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	p.Update(AgentDetailDataMsg{
+		Name:    "amber",
+		Branch:  "polecat/amber",
+		Commits: []data.CommitInfo{{Hash: "abc1234", Message: "fix"}, {Hash: "def5678", Message: "wip"}},
+	})
+
+	got := yankValues(p.Yanks())
+	want := []string{"kt-7", "polecat/amber", "abc1234", "def5678"}
+	if len(got) != len(want) {
+		t.Fatalf("Yanks = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Yanks[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAgentsPaneYanksSkipEmpty(t *testing.T) {
+	p := NewAgentsPane()
+	p.Update(AgentUpdateMsg{Agents: []AgentInfo{{Name: "amber", Rig: "kt"}}})
+	ys := p.Yanks()
+	if len(ys) != 1 || ys[0].Value != "kt/amber" {
+		t.Errorf("an agent without an issue should only offer its address, got %v", ys)
+	}
+}
+
+func TestWitnessPaneYanksDetailPolecat(t *testing.T) {
+	p := NewWitnessPane()
+	p.SetSize(100, 24)
+	p.Update(WitnessUpdateMsg{Witnesses: []WitnessInfo{{Rig: "gastown", Status: "alive"}}})
+	if ys := p.Yanks(); len(ys) != 1 || ys[0].Value != "gastown" {
+		t.Errorf("list Yanks = %v, want the rig", ys)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if ys := p.Yanks(); len(ys) != 1 || ys[0].Value != "gastown" {
+		t.Errorf("loading detail Yanks = %v, want the rig", ys)
+	}
+	p.Update(WitnessDetailMsg{Rig: "gastown", Polecats: []data.PolecatStatus{
+		{Rig: "gastown", Name: "ace"},
+		{Rig: "gastown", Name: "toast", Issue: "gt-2"},
+	}})
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	got := yankValues(p.Yanks())
+	if len(got) != 2 || got[0] != "gt-2" || got[1] != "gastown/toast" {
+		t.Errorf("detail Yanks = %v, want [gt-2 gastown/toast]", got)
+	}
+}
+
+func TestRefineryPaneYanksSelectedMR(t *testing.T) {
+	p := NewRefineryPane()
+	p.SetSize(80, 40)
+	p.Update(RefineryUpdateMsg{Statuses: []data.RefineryStatus{{
+		Rig:     "kt",
+		Running: true,
+		Current: &data.MergeRequest{BeadID: "kt-1", Branch: "polecat/amber", PRURL: "https://example.com/pr/1"},
+		Queue:   []data.MergeRequest{{BeadID: "kt-2", Branch: "polecat/quartz"}},
+	}}})
+
+	if ys := p.Yanks(); len(ys) != 0 {
+		t.Errorf("status row has nothing to copy, got %v", ys)
+	}
+
+	// Rows: status, blank, CURRENT, MR, branch, pr, blank, QUEUE, #1.
+	for _, tc := range []struct {
+		cursor int
+		want   string
+	}{{3, "polecat/amber"}, {5, "polecat/amber"}, {8, "polecat/quartz"}} {
+		p.cursor = tc.cursor
+		if ys := p.Yanks(); len(ys) == 0 || ys[0].Value != tc.want {
+			t.Errorf("cursor %d: Yanks = %v, want branch %s first", tc.cursor, ys, tc.want)
+		}
+	}
+}
+
+func TestPRsPaneYanksURLFirst(t *testing.T) {
+	p := NewPRsPane()
+	p.Update(PRUpdateMsg{PRs: []data.PRInfo{{Number: 12, URL: "https://example.com/pull/12", HeadRefName: "feat"}}})
+	got := yankValues(p.Yanks())
+	if len(got) != 3 || got[0] != "https://example.com/pull/12" || got[1] != "feat" || got[2] != "12" {
+		t.Errorf("Yanks = %v", got)
+	}
+}
@@ -2,33 +2,74 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net"
+	"net/http"
 	"path/filepath"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/activeterm"
 	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/muesli/termenv"
 
+	"github.com/tnguyen21/kestral-tui/internal/api"
 	"github.com/tnguyen21/kestral-tui/internal/app"
 	"github.com/tnguyen21/kestral-tui/internal/config"
+	"github.com/tnguyen21/kestral-tui/internal/data"
+	"github.com/tnguyen21/kestral-tui/internal/feed"
+	"github.com/tnguyen21/kestral-tui/internal/metrics"
+	"github.com/tnguyen21/kestral-tui/internal/notify"
+	"github.com/tnguyen21/kestral-tui/internal/session"
 )
 
-// Server wraps a wish SSH server that serves the Kestral TUI.
+// Server wraps a wish SSH server that serves the Kestral TUI, plus the
+// optional HTTP API and webhook notifier. All of them read through one
+// shared fetch cache per town; the API, metrics and notifier cover the
+// first configured town.
 type Server struct {
-	config *config.Config
-	wish   *ssh.Server
+	config   *config.Config // pointed at the first town, for the notifier
+	wish     *ssh.Server
+	source   data.Source
+	sessions *session.Store
+	http     *http.Server     // nil when the API is disabled
+	metrics  *http.Server     // nil when the exporter is disabled
+	notifier *notify.Notifier // nil when no webhooks are configured
+	watchCtx context.Context  // scopes the notification watcher
+	stop     context.CancelFunc
 }
 
 // New creates a Server configured from cfg.
 func New(cfg *config.Config) (*Server, error) {
-	teaHandler := func(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
-		model := app.New(*cfg)
-		return model, []tea.ProgramOption{
+	sources := app.TownSources(*cfg)
+	first := cfg.TownList()[0]
+	source := sources[first.Name]
+	watchCfg := cfg.ForTown(first)
+
+	sessions := session.NewStore(cfg.StateDir)
+
+	teaHandler := func(sess ssh.Session) *tea.Program {
+		// The server doesn't allocate PTYs, so the session is the client's
+		// terminal. The program and the model share one writer to it.
+		out := app.NewTerminalWriter(sess)
+		fp, hasKey := fingerprint(sess)
+		model := app.New(cfg.ForUser(sess.User(), fp)).WithTownSources(sources).WithOutput(out)
+		if hasKey {
+			st, _ := sessions.Load(fp)
+			model = model.WithSession(st, func(st session.State) {
+				sessions.Save(fp, st)
+			})
+		}
+		opts := append(bubbletea.MakeOptions(sess),
+			tea.WithOutput(out),
 			tea.WithAltScreen(),
 			tea.WithMouseCellMotion(),
-		}
+		)
+		return tea.NewProgram(model, opts...)
 	}
 
 	s, err := wish.NewServer(
@@ -36,7 +77,8 @@ func New(cfg *config.Config) (*Server, error) {
 		wish.WithHostKeyPath(filepath.Join(cfg.HostKeyDir, "kestral_host_key")),
 		wish.WithPublicKeyAuth(publicKeyHandler),
 		wish.WithMiddleware(
-			bubbletea.Middleware(teaHandler),
+			bubbletea.MiddlewareWithProgramHandler(teaHandler, termenv.Ascii),
+			flushSession(sessions),
 			activeterm.Middleware(),
 		),
 	)
@@ -44,21 +86,112 @@ func New(cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("creating wish server: %w", err)
 	}
 
-	return &Server{config: cfg, wish: s}, nil
+	srv := &Server{config: &watchCfg, wish: s, source: source, sessions: sessions}
+	if cfg.API.Listen != "" {
+		srv.http = &http.Server{
+			Addr:              cfg.API.Listen,
+			Handler:           api.NewHandler(source, cfg.API.Token),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+	if cfg.Metrics.Listen != "" {
+		srv.metrics = &http.Server{
+			Addr:              cfg.Metrics.Listen,
+			Handler:           metrics.NewHandler(source),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+	srv.watchCtx, srv.stop = context.WithCancel(context.Background())
+	if len(cfg.Notify.Webhooks) > 0 {
+		n, err := notify.New(cfg.Notify)
+		if err != nil {
+			return nil, err
+		}
+		srv.notifier = n
+	}
+	return srv, nil
 }
 
-// Start begins listening for SSH connections. It blocks until the server
-// is shut down or encounters a fatal error. Returns nil on graceful shutdown.
+// Start begins listening for SSH connections and, if configured, API and
+// metrics requests. It blocks until the server is shut down or encounters
+// a fatal error. Returns nil on graceful shutdown.
 func (s *Server) Start() error {
+	if err := serveHTTP("API", s.http); err != nil {
+		return err
+	}
+	if err := serveHTTP("metrics", s.metrics); err != nil {
+		return err
+	}
+	if s.notifier != nil {
+		go feed.Watch(s.watchCtx, s.source, *s.config, s.notifier.Notify)
+	}
 	if err := s.wish.ListenAndServe(); err != nil && err != ssh.ErrServerClosed {
 		return err
 	}
 	return nil
 }
 
-// Shutdown gracefully shuts down the server.
+// Shutdown gracefully shuts down the server, flushing pending
+// notifications and saved session state.
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.wish.Shutdown(ctx)
+	err := s.wish.Shutdown(ctx)
+	for _, hs := range []*http.Server{s.http, s.metrics} {
+		if hs == nil {
+			continue
+		}
+		if herr := hs.Shutdown(ctx); err == nil {
+			err = herr
+		}
+	}
+	s.stop()
+	s.notifier.Close()
+	if serr := s.sessions.FlushAll(); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// serveHTTP binds hs's address and serves it in the background, so bind
+// errors are reported to the caller. A nil hs is disabled.
+func serveHTTP(name string, hs *http.Server) error {
+	if hs == nil {
+		return nil
+	}
+	ln, err := net.Listen("tcp", hs.Addr)
+	if err != nil {
+		return fmt.Errorf("listening for %s on %s: %w", name, hs.Addr, err)
+	}
+	log.Printf("Kestral %s listening on %s", name, ln.Addr())
+	go func() {
+		if err := hs.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("%s server error: %v", name, err)
+		}
+	}()
+	return nil
+}
+
+// flushSession writes a user's UI state to disk when their connection
+// closes, so it survives a server restart.
+func flushSession(store *session.Store) wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			next(sess)
+			if fp, ok := fingerprint(sess); ok {
+				if err := store.Flush(fp); err != nil {
+					log.Printf("saving session state: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// fingerprint identifies the user behind sess by their public key.
+func fingerprint(sess ssh.Session) (string, bool) {
+	pk := sess.PublicKey()
+	if pk == nil {
+		return "", false
+	}
+	return session.Fingerprint(pk.Marshal()), true
 }
 
 // publicKeyHandler accepts all SSH public keys. Kestral is designed for
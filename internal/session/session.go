@@ -0,0 +1,139 @@
+// Package session persists each user's TUI state between SSH connections,
+// keyed by the fingerprint of the public key they authenticated with.
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+// State is one user's saved UI state.
+type State struct {
+	ActivePane string                    `json:"active_pane"`     // pane title
+	Theme      string                    `json:"theme,omitempty"` // theme name
+	Town       string                    `json:"town,omitempty"`  // town name, with several configured
+	Panes      map[string]pane.PaneState `json:"panes"`           // keyed by pane title
+	SavedAt    time.Time                 `json:"saved_at"`
+}
+
+// Fingerprint returns the OpenSSH SHA256 fingerprint of a public key in
+// wire format, e.g. "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8".
+func Fingerprint(wireKey []byte) string {
+	sum := sha256.Sum256(wireKey)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// Store keeps the latest state per fingerprint in memory and writes it to
+// one JSON file per user in dir. Saves are cheap and happen on every
+// keypress; Flush writes to disk, typically when a connection closes.
+type Store struct {
+	dir string
+
+	mu     sync.Mutex
+	states map[string]State
+	dirty  map[string]bool
+}
+
+// NewStore creates a Store that persists to dir. The directory is created
+// on first flush.
+func NewStore(dir string) *Store {
+	return &Store{
+		dir:    dir,
+		states: make(map[string]State),
+		dirty:  make(map[string]bool),
+	}
+}
+
+// Load returns the saved state for fp, from memory if this server has
+// seen the user, otherwise from disk.
+func (s *Store) Load(fp string) (State, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st, ok := s.states[fp]; ok {
+		return st, true
+	}
+	raw, err := os.ReadFile(s.path(fp))
+	if err != nil {
+		return State{}, false
+	}
+	var st State
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return State{}, false
+	}
+	s.states[fp] = st
+	return st, true
+}
+
+// Save records st as the latest state for fp without touching disk.
+func (s *Store) Save(fp string, st State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[fp] = st
+	s.dirty[fp] = true
+}
+
+// Flush writes fp's state to disk if it changed since the last flush.
+func (s *Store) Flush(fp string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flush(fp)
+}
+
+// FlushAll writes every changed state to disk.
+func (s *Store) FlushAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for fp := range s.dirty {
+		if err := s.flush(fp); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// flush writes one state atomically. The caller holds s.mu.
+func (s *Store) flush(fp string) error {
+	if !s.dirty[fp] {
+		return nil
+	}
+	raw, err := json.MarshalIndent(s.states[fp], "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session state: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("creating state dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".session-*")
+	if err != nil {
+		return fmt.Errorf("writing session state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing session state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing session state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(fp)); err != nil {
+		return fmt.Errorf("writing session state: %w", err)
+	}
+	delete(s.dirty, fp)
+	return nil
+}
+
+// path maps a fingerprint to its state file. Fingerprints contain "/" and
+// "+" from base64, so the file is named by the URL-safe encoding of the
+// fingerprint instead.
+func (s *Store) path(fp string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(fp))+".json")
+}
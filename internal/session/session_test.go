@@ -0,0 +1,80 @@
+package session
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tnguyen21/kestral-tui/internal/pane"
+)
+
+func TestFingerprint(t *testing.T) {
+	fp := Fingerprint([]byte("ssh-ed25519 key"))
+	if !strings.HasPrefix(fp, "SHA256:") {
+		t.Errorf("fingerprint %q should start with SHA256:", fp)
+	}
+	if strings.HasSuffix(fp, "=") {
+		t.Errorf("fingerprint %q should not be padded", fp)
+	}
+	if fp == Fingerprint([]byte("other key")) {
+		t.Error("different keys should have different fingerprints")
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	st := State{
+		ActivePane: "New Issue",
+		Panes: map[string]pane.PaneState{
+			"New Issue": {"title": "Crash on/reconnect", "description": "line 1\nline 2"},
+		},
+		SavedAt: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+
+	s := NewStore(dir)
+	s.Save("SHA256:ab/c+d", st)
+	if err := s.Flush("SHA256:ab/c+d"); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	// A fresh store, as after a server restart, reads it back from disk.
+	got, ok := NewStore(dir).Load("SHA256:ab/c+d")
+	if !ok {
+		t.Fatal("state should load from disk")
+	}
+	if got.ActivePane != "New Issue" || !got.SavedAt.Equal(st.SavedAt) {
+		t.Errorf("unexpected state %+v", got)
+	}
+	if got.Panes["New Issue"]["description"] != "line 1\nline 2" {
+		t.Errorf("draft not restored: %+v", got.Panes)
+	}
+}
+
+func TestStoreSaveDoesNotWrite(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	s.Save("SHA256:x", State{ActivePane: "Agents"})
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("Save should not touch disk, found %d files", len(entries))
+	}
+	if st, ok := s.Load("SHA256:x"); !ok || st.ActivePane != "Agents" {
+		t.Error("saved state should be served from memory")
+	}
+
+	if err := s.FlushAll(); err != nil {
+		t.Fatalf("FlushAll: %v", err)
+	}
+	entries, _ = os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected 1 state file after FlushAll, got %d", len(entries))
+	}
+}
+
+func TestStoreLoadMissing(t *testing.T) {
+	if _, ok := NewStore(t.TempDir()).Load("SHA256:nobody"); ok {
+		t.Error("unknown user should have no state")
+	}
+}
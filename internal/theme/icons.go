@@ -1,19 +1,5 @@
 package theme
 
-// Agent status icons with semantic colors.
-var (
-	IconWorking = PassStyle.Render("●")
-	IconStale   = WarnStyle.Render("◐")
-	IconStuck   = FailStyle.Render("○")
-	IconIdle    = MutedStyle.Render("○")
-)
-
-// Mail status icons.
-var (
-	IconUnread = AccentStyle.Render("●")
-	IconRead   = MutedStyle.Render("○")
-)
-
 // Role icons for agent types.
 const (
 	RoleMayor    = "👑"
@@ -21,4 +7,5 @@ const (
 	RoleRefinery = "🔧"
 	RolePolecat  = "🦨"
 	RoleCrew     = "👷"
+	RoleDeacon   = "⛪"
 )
@@ -0,0 +1,163 @@
+package theme
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultName is the theme used when none is configured.
+const DefaultName = "ayu"
+
+// Built-in palettes, in picker order.
+var builtins = []*Theme{
+	New(DefaultName, Palette{
+		Pass:   lipgloss.AdaptiveColor{Light: "#86b300", Dark: "#c2d94c"},
+		Warn:   lipgloss.AdaptiveColor{Light: "#f2ae49", Dark: "#ffb454"},
+		Fail:   lipgloss.AdaptiveColor{Light: "#f07171", Dark: "#f07178"},
+		Muted:  lipgloss.AdaptiveColor{Light: "#828c99", Dark: "#6c7680"},
+		Accent: lipgloss.AdaptiveColor{Light: "#399ee6", Dark: "#59c2ff"},
+		Bar:    lipgloss.AdaptiveColor{Light: "#e7e8e9", Dark: "#1f2430"},
+	}),
+	New("dracula", Palette{
+		Pass:   lipgloss.Color("#50fa7b"),
+		Warn:   lipgloss.Color("#f1fa8c"),
+		Fail:   lipgloss.Color("#ff5555"),
+		Muted:  lipgloss.Color("#6272a4"),
+		Accent: lipgloss.Color("#bd93f9"),
+		Bar:    lipgloss.Color("#44475a"),
+	}),
+	New("solarized", Palette{
+		Pass:   lipgloss.Color("#859900"),
+		Warn:   lipgloss.Color("#b58900"),
+		Fail:   lipgloss.Color("#dc322f"),
+		Muted:  lipgloss.AdaptiveColor{Light: "#93a1a1", Dark: "#586e75"},
+		Accent: lipgloss.Color("#268bd2"),
+		Bar:    lipgloss.AdaptiveColor{Light: "#eee8d5", Dark: "#073642"},
+	}),
+	// High contrast sticks to the 16 ANSI colors so it follows the
+	// terminal's own (often accessibility-tuned) palette.
+	New("high-contrast", Palette{
+		Pass:   lipgloss.Color("10"),
+		Warn:   lipgloss.Color("11"),
+		Fail:   lipgloss.Color("9"),
+		Muted:  lipgloss.Color("7"),
+		Accent: lipgloss.Color("14"),
+		Bar:    lipgloss.Color("0"),
+	}),
+	monochrome(),
+}
+
+// monochrome uses no colors at all and tells states apart by text
+// attributes instead.
+func monochrome() *Theme {
+	none := lipgloss.NoColor{}
+	t := New("monochrome", Palette{Pass: none, Warn: none, Fail: none, Muted: none, Accent: none, Bar: none})
+	t.WarnStyle = t.WarnStyle.Underline(true)
+	t.FailStyle = t.FailStyle.Bold(true).Reverse(true)
+	t.MutedStyle = t.MutedStyle.Faint(true)
+	t.AccentStyle = t.AccentStyle.Bold(true)
+	t.TabInactiveStyle = t.TabInactiveStyle.Faint(true)
+	t.StatusBarStyle = t.StatusBarStyle.Reverse(true)
+	t.HeaderBarStyle = t.HeaderBarStyle.Reverse(true)
+	t.HeaderHintStyle = t.HeaderHintStyle.Reverse(true)
+	t.PickerRowStyle = t.PickerRowStyle.Faint(true)
+	t.renderIcons()
+	return t
+}
+
+// Default returns the default theme.
+func Default() *Theme {
+	return builtins[0]
+}
+
+// Builtins returns the built-in themes in picker order.
+func Builtins() []*Theme {
+	return append([]*Theme(nil), builtins...)
+}
+
+// Colors is a user-defined palette from the themes config section. Each
+// color is a hex value like "#88c0d0" or an ANSI color number from 0 to
+// 255; unset colors fall back to the default theme.
+type Colors struct {
+	Pass   string `yaml:"pass"`
+	Warn   string `yaml:"warn"`
+	Fail   string `yaml:"fail"`
+	Muted  string `yaml:"muted"`
+	Accent string `yaml:"accent"`
+	Bar    string `yaml:"bar"`
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Validate reports the first color that is neither hex nor ANSI.
+func (c Colors) Validate() error {
+	for _, f := range []struct{ name, value string }{
+		{"pass", c.Pass}, {"warn", c.Warn}, {"fail", c.Fail},
+		{"muted", c.Muted}, {"accent", c.Accent}, {"bar", c.Bar},
+	} {
+		if f.value == "" || hexColor.MatchString(f.value) {
+			continue
+		}
+		if n, err := strconv.Atoi(f.value); err == nil && n >= 0 && n <= 255 {
+			continue
+		}
+		return fmt.Errorf("%s: %q is not a hex color or ANSI number", f.name, f.value)
+	}
+	return nil
+}
+
+// Custom builds a theme from user-defined colors.
+func Custom(name string, c Colors) *Theme {
+	p := Default().Palette
+	set := func(dst *lipgloss.TerminalColor, v string) {
+		if v != "" {
+			*dst = lipgloss.Color(v)
+		}
+	}
+	set(&p.Pass, c.Pass)
+	set(&p.Warn, c.Warn)
+	set(&p.Fail, c.Fail)
+	set(&p.Muted, c.Muted)
+	set(&p.Accent, c.Accent)
+	set(&p.Bar, c.Bar)
+	return New(name, p)
+}
+
+// All returns the built-in themes followed by the custom ones sorted by
+// name. A custom theme with a built-in name replaces the built-in.
+func All(custom map[string]Colors) []*Theme {
+	themes := Builtins()
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := Custom(name, custom[name])
+		replaced := false
+		for i, b := range themes {
+			if b.Name == name {
+				themes[i] = t
+				replaced = true
+			}
+		}
+		if !replaced {
+			themes = append(themes, t)
+		}
+	}
+	return themes
+}
+
+// Find returns the theme called name from themes.
+func Find(themes []*Theme, name string) (*Theme, bool) {
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
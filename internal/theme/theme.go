@@ -1,85 +1,132 @@
+// Package theme holds the color palettes and the lipgloss styles built from
+// them. Each SSH session renders with its own *Theme, so styles are fields
+// of a Theme rather than package globals.
 package theme
 
 import "github.com/charmbracelet/lipgloss"
 
-// Ayu color palette — AdaptiveColor for light/dark terminal support.
-var (
-	ColorPass   = lipgloss.AdaptiveColor{Light: "#86b300", Dark: "#c2d94c"}
-	ColorWarn   = lipgloss.AdaptiveColor{Light: "#f2ae49", Dark: "#ffb454"}
-	ColorFail   = lipgloss.AdaptiveColor{Light: "#f07171", Dark: "#f07178"}
-	ColorMuted  = lipgloss.AdaptiveColor{Light: "#828c99", Dark: "#6c7680"}
-	ColorAccent = lipgloss.AdaptiveColor{Light: "#399ee6", Dark: "#59c2ff"}
-)
-
-// Semantic text styles.
-var (
-	PassStyle   = lipgloss.NewStyle().Foreground(ColorPass)
-	WarnStyle   = lipgloss.NewStyle().Foreground(ColorWarn)
-	FailStyle   = lipgloss.NewStyle().Foreground(ColorFail)
-	MutedStyle  = lipgloss.NewStyle().Foreground(ColorMuted)
-	AccentStyle = lipgloss.NewStyle().Foreground(ColorAccent)
-)
-
-// Tab bar styles.
-var (
-	TabActiveStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
+// Palette is the set of colors a Theme is built from.
+type Palette struct {
+	Pass   lipgloss.TerminalColor
+	Warn   lipgloss.TerminalColor
+	Fail   lipgloss.TerminalColor
+	Muted  lipgloss.TerminalColor
+	Accent lipgloss.TerminalColor
+	Bar    lipgloss.TerminalColor // header and status bar background
+}
+
+// Theme is a named palette and the styles derived from it. A Theme is never
+// modified after it is built, so sessions may share one.
+type Theme struct {
+	Name    string
+	Palette Palette
+
+	// Semantic text styles.
+	PassStyle   lipgloss.Style
+	WarnStyle   lipgloss.Style
+	FailStyle   lipgloss.Style
+	MutedStyle  lipgloss.Style
+	AccentStyle lipgloss.Style
+
+	// Tab bar styles.
+	TabActiveStyle   lipgloss.Style
+	TabInactiveStyle lipgloss.Style
+
+	// StatusBarStyle for the bottom status bar.
+	StatusBarStyle lipgloss.Style
+
+	// Header bar styles (compact pane indicator replacing full tab bar).
+	HeaderBarStyle  lipgloss.Style
+	HeaderHintStyle lipgloss.Style
+
+	// Pane picker overlay styles.
+	PickerTitleStyle     lipgloss.Style
+	PickerRowStyle       lipgloss.Style
+	PickerActiveRowStyle lipgloss.Style
+	PickerCursorStyle    lipgloss.Style
+
+	// Pane styles.
+	PaneHeaderStyle lipgloss.Style
+	PaneBorderStyle lipgloss.Style
+
+	// Agent status icons with semantic colors.
+	IconWorking string
+	IconStale   string
+	IconStuck   string
+	IconIdle    string
+
+	// Mail status icons.
+	IconUnread string
+	IconRead   string
+}
+
+// New builds a Theme from a palette.
+func New(name string, p Palette) *Theme {
+	t := &Theme{
+		Name:    name,
+		Palette: p,
+
+		PassStyle:   lipgloss.NewStyle().Foreground(p.Pass),
+		WarnStyle:   lipgloss.NewStyle().Foreground(p.Warn),
+		FailStyle:   lipgloss.NewStyle().Foreground(p.Fail),
+		MutedStyle:  lipgloss.NewStyle().Foreground(p.Muted),
+		AccentStyle: lipgloss.NewStyle().Foreground(p.Accent),
+
+		TabActiveStyle: lipgloss.NewStyle().
+			Foreground(p.Accent).
 			Bold(true).
-			Padding(0, 1)
-
-	TabInactiveStyle = lipgloss.NewStyle().
-				Foreground(ColorMuted).
-				Padding(0, 1)
-)
-
-// StatusBarStyle for the bottom status bar.
-var StatusBarStyle = lipgloss.NewStyle().
-	Background(lipgloss.AdaptiveColor{Light: "#e7e8e9", Dark: "#1f2430"}).
-	Foreground(ColorMuted).
-	Padding(0, 1)
-
-// Header bar styles (compact pane indicator replacing full tab bar).
-var (
-	HeaderBarStyle = lipgloss.NewStyle().
-			Background(lipgloss.AdaptiveColor{Light: "#e7e8e9", Dark: "#1f2430"}).
-			Foreground(ColorAccent).
+			Padding(0, 1),
+		TabInactiveStyle: lipgloss.NewStyle().
+			Foreground(p.Muted).
+			Padding(0, 1),
+
+		StatusBarStyle: lipgloss.NewStyle().
+			Background(p.Bar).
+			Foreground(p.Muted).
+			Padding(0, 1),
+
+		HeaderBarStyle: lipgloss.NewStyle().
+			Background(p.Bar).
+			Foreground(p.Accent).
 			Bold(true).
-			Padding(0, 1)
-
-	HeaderHintStyle = lipgloss.NewStyle().
-			Background(lipgloss.AdaptiveColor{Light: "#e7e8e9", Dark: "#1f2430"}).
-			Foreground(ColorMuted).
-			Padding(0, 1)
-)
-
-// Pane picker overlay styles.
-var (
-	PickerTitleStyle = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true).
-				Padding(0, 1)
-
-	PickerRowStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Padding(0, 1)
-
-	PickerActiveRowStyle = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true).
-				Padding(0, 1)
-
-	PickerCursorStyle = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true)
-)
-
-// Pane styles.
-var (
-	PaneHeaderStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
-
-	PaneBorderStyle = lipgloss.NewStyle().
+			Padding(0, 1),
+		HeaderHintStyle: lipgloss.NewStyle().
+			Background(p.Bar).
+			Foreground(p.Muted).
+			Padding(0, 1),
+
+		PickerTitleStyle: lipgloss.NewStyle().
+			Foreground(p.Accent).
+			Bold(true).
+			Padding(0, 1),
+		PickerRowStyle: lipgloss.NewStyle().
+			Foreground(p.Muted).
+			Padding(0, 1),
+		PickerActiveRowStyle: lipgloss.NewStyle().
+			Foreground(p.Accent).
+			Bold(true).
+			Padding(0, 1),
+		PickerCursorStyle: lipgloss.NewStyle().
+			Foreground(p.Accent).
+			Bold(true),
+
+		PaneHeaderStyle: lipgloss.NewStyle().
+			Foreground(p.Accent).
+			Bold(true),
+		PaneBorderStyle: lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(ColorMuted)
-)
+			BorderForeground(p.Muted),
+	}
+	t.renderIcons()
+	return t
+}
+
+// renderIcons pre-renders the status icons from the semantic styles.
+func (t *Theme) renderIcons() {
+	t.IconWorking = t.PassStyle.Render("●")
+	t.IconStale = t.WarnStyle.Render("◐")
+	t.IconStuck = t.FailStyle.Render("○")
+	t.IconIdle = t.MutedStyle.Render("○")
+	t.IconUnread = t.AccentStyle.Render("●")
+	t.IconRead = t.MutedStyle.Render("○")
+}
@@ -0,0 +1,77 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestBuiltins(t *testing.T) {
+	want := []string{"ayu", "dracula", "solarized", "high-contrast", "monochrome"}
+	got := Builtins()
+	if len(got) != len(want) {
+		t.Fatalf("got %d built-in themes, want %d", len(got), len(want))
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Errorf("builtin %d = %s, want %s", i, got[i].Name, name)
+		}
+		if got[i].IconWorking == "" || got[i].IconRead == "" {
+			t.Errorf("%s: icons not rendered", name)
+		}
+	}
+	if Default().Name != DefaultName {
+		t.Errorf("Default() = %s, want %s", Default().Name, DefaultName)
+	}
+}
+
+func TestColorsValidate(t *testing.T) {
+	for _, c := range []Colors{
+		{},
+		{Pass: "#A3BE8C", Bar: "0", Accent: "255"},
+	} {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%+v: unexpected error %v", c, err)
+		}
+	}
+	for _, c := range []Colors{
+		{Pass: "green"},
+		{Warn: "#fff"},
+		{Fail: "256"},
+		{Muted: "-1"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", c)
+		}
+	}
+}
+
+func TestCustomFallsBackToDefault(t *testing.T) {
+	th := Custom("nord", Colors{Accent: "#88c0d0"})
+	if th.Palette.Accent != lipgloss.Color("#88c0d0") {
+		t.Errorf("accent = %v", th.Palette.Accent)
+	}
+	if th.Palette.Fail != Default().Palette.Fail {
+		t.Errorf("unset fail should come from the default theme, got %v", th.Palette.Fail)
+	}
+}
+
+func TestAllReplacesBuiltinByName(t *testing.T) {
+	themes := All(map[string]Colors{
+		"dracula": {Pass: "2"},
+		"nord":    {},
+	})
+	if len(themes) != len(builtins)+1 {
+		t.Fatalf("got %d themes, want %d", len(themes), len(builtins)+1)
+	}
+	d, ok := Find(themes, "dracula")
+	if !ok || d.Palette.Pass != lipgloss.Color("2") {
+		t.Error("custom dracula should replace the built-in")
+	}
+	if themes[len(themes)-1].Name != "nord" {
+		t.Errorf("custom themes should follow the built-ins, last = %s", themes[len(themes)-1].Name)
+	}
+	if builtins[1].Palette.Pass == lipgloss.Color("2") {
+		t.Error("All must not modify the built-in list")
+	}
+}